@@ -0,0 +1,199 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	CancelDocumentsFactType = hint.Type("mitum-blocksign-cancel-documents-operation-fact")
+	CancelDocumentsFactHint = hint.NewHint(CancelDocumentsFactType, "v0.0.1")
+	CancelDocumentsType     = hint.Type("mitum-blocksign-cancel-documents-operation")
+	CancelDocumentsHint     = hint.NewHint(CancelDocumentsType, "v0.0.1")
+)
+
+var MaxCancelDocumentsItems uint = 10
+
+type CancelDocumentsItem interface {
+	hint.Hinter
+	isvalid.IsValider
+	Bytes() []byte
+	DocumentId() currency.Big
+	Owner() base.Address
+	Remove() bool
+	Currency() currency.CurrencyID
+	Rebuild() CancelDocumentsItem
+}
+
+type CancelDocumentsFact struct {
+	h      valuehash.Hash
+	token  []byte
+	sender base.Address
+	items  []CancelDocumentsItem
+}
+
+func NewCancelDocumentsFact(token []byte, sender base.Address, items []CancelDocumentsItem) CancelDocumentsFact {
+	fact := CancelDocumentsFact{
+		token:  token,
+		sender: sender,
+		items:  items,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact CancelDocumentsFact) Hint() hint.Hint {
+	return CancelDocumentsFactHint
+}
+
+func (fact CancelDocumentsFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact CancelDocumentsFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact CancelDocumentsFact) Bytes() []byte {
+	is := make([][]byte, len(fact.items))
+	for i := range fact.items {
+		is[i] = fact.items[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		util.ConcatBytesSlice(is...),
+	)
+}
+
+func (fact CancelDocumentsFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for CancelDocumentsFact")
+	} else if n := len(fact.items); n < 1 {
+		return errors.Errorf("empty items")
+	} else if n > int(MaxCancelDocumentsItems) {
+		return errors.Errorf("items, %d over max, %d", n, MaxCancelDocumentsItems)
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.sender,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	// check duplicated document
+	foundDocId := map[string]bool{}
+	for i := range fact.items {
+		if err := fact.items[i].IsValid(nil); err != nil {
+			return err
+		}
+
+		if !fact.items[i].Owner().Equal(fact.sender) {
+			return errors.Errorf("sender is not owner of document, %s", fact.items[i].DocumentId())
+		}
+
+		k := fact.items[i].DocumentId().String()
+		if _, found := foundDocId[k]; found {
+			return errors.Errorf("duplicated document found, %s", k)
+		}
+		foundDocId[k] = true
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact CancelDocumentsFact) Token() []byte {
+	return fact.token
+}
+
+func (fact CancelDocumentsFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact CancelDocumentsFact) Items() []CancelDocumentsItem {
+	return fact.items
+}
+
+func (fact CancelDocumentsFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.Sender()}, nil
+}
+
+func (fact CancelDocumentsFact) Rebuild() CancelDocumentsFact {
+	items := make([]CancelDocumentsItem, len(fact.items))
+	for i := range fact.items {
+		it := fact.items[i]
+		items[i] = it.Rebuild()
+	}
+
+	fact.items = items
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+type CancelDocuments struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewCancelDocuments(fact CancelDocumentsFact, fs []operation.FactSign, memo string) (CancelDocuments, error) {
+	if bo, err := operation.NewBaseOperationFromFact(CancelDocumentsHint, fact, fs); err != nil {
+		return CancelDocuments{}, err
+	} else {
+		op := CancelDocuments{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (op CancelDocuments) Hint() hint.Hint {
+	return CancelDocumentsHint
+}
+
+func (op CancelDocuments) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op CancelDocuments) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op CancelDocuments) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
@@ -0,0 +1,83 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+type BaseCancelDocumentsItem struct {
+	hint   hint.Hint
+	id     currency.Big
+	owner  base.Address
+	remove bool // remove document from owner document inventory
+	cid    currency.CurrencyID
+}
+
+func NewBaseCancelDocumentsItem(ht hint.Hint,
+	id currency.Big,
+	owner base.Address,
+	remove bool,
+	cid currency.CurrencyID,
+) BaseCancelDocumentsItem {
+	return BaseCancelDocumentsItem{
+		hint:   ht,
+		id:     id,
+		owner:  owner,
+		remove: remove,
+		cid:    cid,
+	}
+}
+
+func (it BaseCancelDocumentsItem) Hint() hint.Hint {
+	return it.hint
+}
+
+func (it BaseCancelDocumentsItem) Bytes() []byte {
+	bs := make([][]byte, 4)
+	bs[0] = it.id.Bytes()
+	bs[1] = it.owner.Bytes()
+	bs[2] = util.BoolToBytes(it.remove)
+	bs[3] = it.cid.Bytes()
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (it BaseCancelDocumentsItem) IsValid([]byte) error {
+	if err := it.id.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.owner.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.cid.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it BaseCancelDocumentsItem) DocumentId() currency.Big {
+	return it.id
+}
+
+func (it BaseCancelDocumentsItem) Owner() base.Address {
+	return it.owner
+}
+
+// Remove returns true, if the canceled document is removed from owner document
+// inventory.
+func (it BaseCancelDocumentsItem) Remove() bool {
+	return it.remove
+}
+
+func (it BaseCancelDocumentsItem) Currency() currency.CurrencyID {
+	return it.cid
+}
+
+func (it BaseCancelDocumentsItem) Rebuild() CancelDocumentsItem {
+	return it
+}
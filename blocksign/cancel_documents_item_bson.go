@@ -0,0 +1,41 @@
+package blocksign // nolint:dupl
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (it BaseCancelDocumentsItem) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()),
+			bson.M{
+				"documentid": it.id,
+				"owner":      it.owner,
+				"remove":     it.remove,
+				"currency":   it.cid,
+			}),
+	)
+}
+
+type CancelDocumentsItemBSONUnpacker struct {
+	DI currency.Big        `bson:"documentid"`
+	OW base.AddressDecoder `bson:"owner"`
+	RM bool                `bson:"remove"`
+	CI string              `bson:"currency"`
+}
+
+func (it *BaseCancelDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ht bsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var ucd CancelDocumentsItemBSONUnpacker
+	if err := bson.Unmarshal(b, &ucd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.RM, ucd.CI)
+}
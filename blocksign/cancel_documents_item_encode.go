@@ -0,0 +1,32 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/encoder"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+func (it *BaseCancelDocumentsItem) unpack(
+	enc encoder.Encoder,
+	ht hint.Hint,
+	di currency.Big,
+	ow base.AddressDecoder,
+	rm bool,
+	scid string,
+) error {
+	it.hint = ht
+
+	it.id = di
+
+	a, err := ow.Encode(enc)
+	if err != nil {
+		return err
+	}
+	it.owner = a
+
+	it.remove = rm
+	it.cid = currency.CurrencyID(scid)
+
+	return nil
+}
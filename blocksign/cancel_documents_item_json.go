@@ -0,0 +1,46 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type CancelDocumentsItemJSONPacker struct {
+	jsonenc.HintedHead
+	DI currency.Big        `json:"documentid"`
+	OW base.Address        `json:"owner"`
+	RM bool                `json:"remove"`
+	CI currency.CurrencyID `json:"currency"`
+}
+
+func (it BaseCancelDocumentsItem) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(CancelDocumentsItemJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(it.Hint()),
+		DI:         it.id,
+		OW:         it.owner,
+		RM:         it.remove,
+		CI:         it.cid,
+	})
+}
+
+type CancelDocumentsItemJSONUnpacker struct {
+	DI currency.Big        `json:"documentid"`
+	OW base.AddressDecoder `json:"owner"`
+	RM bool                `json:"remove"`
+	CI string              `json:"currency"`
+}
+
+func (it *BaseCancelDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ht jsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var ucd CancelDocumentsItemJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &ucd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.RM, ucd.CI)
+}
@@ -0,0 +1,283 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op CancelDocuments) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+type CancelDocumentsItemProcessor struct {
+	cp      *currency.CurrencyPool
+	sender  base.Address
+	height  base.Height
+	h       valuehash.Hash
+	item    CancelDocumentsItem
+	nds     state.State          // document data state (key = document id)
+	signers []base.Address       // signers of document, who do not have to sign anymore
+	refund  currency.Big         // escrow of document, refunded to escrow payer
+	ncb     currency.AmountState // escrow payer balance state of escrow currency
+}
+
+func (opp *CancelDocumentsItemProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) error {
+	if err := opp.item.IsValid(nil); err != nil {
+		return err
+	}
+
+	if !opp.item.Owner().Equal(opp.sender) {
+		return errors.Errorf("sender is not owner of document, %v", opp.item.DocumentId())
+	}
+
+	// check existence of document data state with documentid
+	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.item.DocumentId()))); {
+	case err != nil:
+		return err
+	case !found:
+		return errors.Errorf("document not registered with documentid, %q", opp.item.DocumentId())
+	default:
+		opp.nds = st
+	}
+
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return err
+	}
+
+	if !dd.Creator().Equal(opp.item.Owner()) {
+		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
+	}
+
+	switch status := dd.StatusAt(opp.height); status {
+	case DocumentCanceled:
+		return errors.Errorf("document already canceled at height, %v", dd.Canceled())
+	case DocumentFullySigned:
+		return errors.Errorf("document can not be canceled, document %v", status)
+	}
+
+	opp.signers = make([]base.Address, len(dd.Signers()))
+	for i := range dd.Signers() {
+		opp.signers[i] = dd.Signers()[i].Address()
+	}
+
+	opp.refund = currency.ZeroBig
+	if dd.HasEscrow() {
+		st, err := loadEscrowBalance(dd.EscrowPayer(), dd.Escrow().Currency(), getState)
+		if err != nil {
+			return err
+		}
+		opp.ncb = st
+		opp.refund = dd.Escrow().Big()
+	}
+
+	return nil
+}
+
+func (opp *CancelDocumentsItemProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) ([]state.State, error) {
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE the rest of escrow is refunded to escrow payer
+	dd = dd.Cancel(opp.height).WithEscrow(currency.Amount{})
+
+	sts := make([]state.State, 1)
+	if dst, err := SetStateDocumentDataValue(opp.nds, dd); err != nil {
+		return nil, err
+	} else {
+		sts[0] = dst
+	}
+
+	return sts, nil
+}
+
+type CancelDocumentsProcessor struct {
+	cp     *currency.CurrencyPool
+	height base.Height // height of block, which documents are canceled in
+	CancelDocuments
+	docs     *documentPages                               // sender document inventory pages
+	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
+	ns       []*CancelDocumentsItemProcessor              // ItemProcessor
+	required map[currency.CurrencyID][2]currency.Big      // Fee
+	pending  *documentPages                               // pending document inventory pages of signers
+}
+
+func NewCancelDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(CancelDocuments); !ok {
+			return nil, errors.Errorf("not CancelDocuments, %T", op)
+		} else {
+			return &CancelDocumentsProcessor{
+				cp:              cp,
+				CancelDocuments: i,
+			}, nil
+		}
+	}
+}
+
+func (opp *CancelDocumentsProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(CancelDocumentsFact)
+
+	// check sender account state existence
+	if err := checkExistsState(currency.StateKeyAccount(fact.sender), getState); err != nil {
+		return nil, err
+	}
+
+	// check existence of documents in sender document inventory
+	docs := newOwnerDocumentPages()
+	if err := docs.migrate(fact.sender, getState); err != nil {
+		return nil, err
+	}
+
+	for i := range fact.items {
+		if err := docs.load(fact.sender, fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+
+		if !docs.exists(fact.sender, fact.items[i].DocumentId()) {
+			return nil, operation.NewBaseReasonError(
+				"document not found in sender document inventory, %v", fact.items[i].DocumentId())
+		}
+	}
+
+	if required, err := opp.calculateItemsFee(); err != nil {
+		return nil, operation.NewBaseReasonError("failed to calculate fee: %w", err)
+	} else if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
+		return nil, err
+	} else {
+		opp.required = required
+		opp.sb = sb
+	}
+
+	ns := make([]*CancelDocumentsItemProcessor, len(fact.items))
+	for i := range fact.items {
+		c := &CancelDocumentsItemProcessor{
+			cp:     opp.cp,
+			sender: fact.sender,
+			height: opp.height,
+			h:      opp.Hash(),
+			item:   fact.items[i],
+		}
+		if err := c.PreProcess(getState, setState); err != nil {
+			return nil, operation.NewBaseReasonErrorFromError(err)
+		}
+
+		ns[i] = c
+	}
+
+	// check fact sign
+	if err := checkFactSignsByState(fact.sender, opp.Signs(), getState); err != nil {
+		return nil, operation.NewBaseReasonError("invalid signing: %w", err)
+	}
+
+	pending := newPendingDocumentPages()
+	for i := range ns {
+		for j := range ns[i].signers {
+			if err := pending.load(ns[i].signers[j], ns[i].item.DocumentId(), getState); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	opp.docs = docs
+	opp.ns = ns
+	opp.pending = pending
+
+	return opp, nil
+}
+
+func (opp *CancelDocumentsProcessor) Process( // nolint:dupl
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := opp.Fact().(CancelDocumentsFact)
+
+	var sts []state.State // nolint:prealloc
+
+	eb := newEscrowBalances(opp.sb, opp.required)
+
+	for i := range opp.ns {
+		s, err := opp.ns[i].Process(getState, setState)
+		if err != nil {
+			return operation.NewBaseReasonError("failed to process cancel document item: %w", err)
+		}
+		sts = append(sts, s...)
+
+		if c := opp.ns[i]; c.refund.OverZero() {
+			eb.add(c.ncb, c.refund, currency.ZeroBig)
+		}
+
+		// canceled document is removed from pending document inventory of
+		// signers
+		for j := range opp.ns[i].signers {
+			if err := opp.pending.discard(opp.ns[i].signers[j], opp.ns[i].item.DocumentId()); err != nil {
+				return err
+			}
+		}
+
+		// remove canceled document from sender document inventory
+		if !opp.ns[i].item.Remove() {
+			continue
+		}
+
+		if err := opp.docs.remove(fact.sender, opp.ns[i].item.DocumentId()); err != nil {
+			return err
+		}
+	}
+
+	if dts, err := opp.docs.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, dts...)
+	}
+
+	if pts, err := opp.pending.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, pts...)
+	}
+
+	sts = append(sts, eb.states()...)
+
+	return setState(fact.Hash(), sts...)
+}
+
+func (opp *CancelDocumentsProcessor) lockedKeys() []string {
+	keys := opp.pending.keys()
+	for i := range opp.ns {
+		if c := opp.ns[i]; c.refund.OverZero() {
+			keys = append(keys, c.ncb.Key())
+		}
+	}
+
+	return keys
+}
+
+func (opp *CancelDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
+	fact := opp.Fact().(CancelDocumentsFact)
+
+	cids := make([]currency.CurrencyID, len(fact.items))
+	for i := range fact.items {
+		cids[i] = fact.items[i].Currency()
+	}
+
+	return CalculateFlatItemsFee(opp.cp, cids)
+}
@@ -0,0 +1,341 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testCancelDocumentsOperations struct {
+	baseTestOperationProcessor
+	cid      currency.CurrencyID
+	docid    currency.Big
+	fh       FileHash
+	fee      currency.Big
+	signcode string
+	title    string
+	size     currency.Big
+}
+
+func (t *testCancelDocumentsOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.fee = currency.NewBig(3)
+	t.signcode = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+}
+
+func (t *testCancelDocumentsOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(CancelDocuments{}, NewCancelDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testCancelDocumentsOperations) newCancelDocuments(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []CancelDocumentsItem,
+) CancelDocuments {
+	token := util.UUID().Bytes()
+	fact := NewCancelDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	op, err := NewCancelDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+
+	return op
+}
+
+func (t *testCancelDocumentsOperations) newTestDocumentData(ca base.Address, signers []DocSign) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	return NewDocumentData(info, ca, t.signcode, t.title, t.size, signers)
+}
+
+func (t *testCancelDocumentsOperations) newTestBalance() []currency.Amount {
+	return []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+}
+
+func (t *testCancelDocumentsOperations) process(
+	ca *account, dd DocumentData, item CancelDocumentsItem, sts ...[]state.State,
+) (*storage.Statepool, error) {
+	sts = append(sts, t.newStateDocument(ca.Address, dd))
+	pool, _ := t.statepool(sts...)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	return pool, opr.Process(t.newCancelDocuments(ca.Address, ca.Privs(), []CancelDocumentsItem{item}))
+}
+
+func (t *testCancelDocumentsOperations) TestNormalCase() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)})
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb)
+	t.NoError(err)
+
+	var dds, dinvs, sb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case StateKeyDocumentsPage(ca.Address, currency.ZeroBig):
+			dinvs = stu.GetState()
+		case currency.StateKeyBalance(ca.Address, t.cid):
+			sb = stu.GetState()
+		}
+	}
+	t.NotNil(dds)
+	t.Nil(dinvs) // document inventory not changed
+	t.NotNil(sb)
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	t.True(ndd.IsCanceled())
+	t.Equal(pool.Height(), ndd.Canceled())
+	t.Equal(DocumentCanceled, ndd.Status())
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+}
+
+func (t *testCancelDocumentsOperations) TestRefundEscrow() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, nil)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)}).
+		WithEscrow(currency.NewAmount(currency.NewBig(12), t.cid))
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb)
+	t.NoError(err)
+
+	var dds, sb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case currency.StateKeyBalance(ca.Address, t.cid):
+			sb = stu.GetState()
+		}
+	}
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+	t.True(ndd.IsCanceled())
+	t.False(ndd.HasEscrow())
+
+	t.Equal(t.fee, sb.(currency.AmountState).Fee())
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee).Add(currency.NewBig(12))))
+}
+
+func (t *testCancelDocumentsOperations) TestRefundEscrowToPayer() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, nil)
+	pa, stc := t.newAccount(true, balance) // escrow payer, previous owner
+
+	// NOTE document is transferred to ca after pa paid escrow
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)}).
+		WithEscrow(currency.NewAmount(currency.NewBig(12), t.cid)).
+		WithEscrowPayer(pa.Address)
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc)
+	t.NoError(err)
+
+	var sb, pb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case currency.StateKeyBalance(ca.Address, t.cid):
+			sb = stu.GetState()
+		case currency.StateKeyBalance(pa.Address, t.cid):
+			pb = stu.GetState()
+		}
+	}
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+
+	t.NotNil(pb)
+	pba, _ := currency.StateBalanceValue(pb)
+	t.True(pba.Big().Equal(balance[0].Big().Add(currency.NewBig(12))))
+}
+
+func (t *testCancelDocumentsOperations) TestPendingDocuments() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+	ga, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(sa.Address, "user1", false),
+		NewDocSign(ga.Address, "user2", true),
+	})
+
+	other := MustNewDocInfo(1, MustFileHash("EFGH"))
+	psts := []state.State{t.newStatePendingDocuments(sa.Address, currency.ZeroBig, []DocInfo{dd.Info(), other})}
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc, psts)
+	t.NoError(err)
+
+	inv, found := t.updatedPendingDocuments(pool, sa.Address)
+	t.True(found)
+	t.Equal(1, len(inv.Documents()))
+	t.True(inv.Documents()[0].Equal(other))
+
+	// NOTE signed signer does not have pending document
+	_, found = t.updatedPendingDocuments(pool, ga.Address)
+	t.False(found)
+}
+
+func (t *testCancelDocumentsOperations) TestRemoveFromInventory() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)})
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, true, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb)
+	t.NoError(err)
+
+	var dinvs state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentsPage(ca.Address, currency.ZeroBig) {
+			dinvs = stu.GetState()
+		}
+	}
+	t.NotNil(dinvs)
+
+	dinv, err := StateDocumentsValue(dinvs)
+	t.NoError(err)
+	t.False(dinv.Exists(t.docid))
+	t.Equal(0, len(dinv.Documents()))
+}
+
+func (t *testCancelDocumentsOperations) TestAlreadyCanceled() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)}).
+		Cancel(base.Height(0))
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document already canceled")
+}
+
+func (t *testCancelDocumentsOperations) TestFullySignedDocument() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", true)})
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document can not be canceled")
+}
+
+func (t *testCancelDocumentsOperations) TestNotOwnedDocument() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	oa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(oa.Address, nil)
+
+	item := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+
+	sts := [][]state.State{sta, stb, t.newStateDocument(oa.Address, dd)}
+	pool, _ := t.statepool(sts...)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	err := t.processor(cp, pool).Process(t.newCancelDocuments(ca.Address, ca.Privs(), []CancelDocumentsItem{item}))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document not found in sender document inventory")
+}
+
+func (t *testCancelDocumentsOperations) TestSameDocumentInProposal() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)})
+
+	pool, _ := t.statepool(sta, stb, t.newStateDocument(ca.Address, dd))
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	item0 := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, false, t.cid)
+	t.NoError(opr.Process(t.newCancelDocuments(ca.Address, ca.Privs(), []CancelDocumentsItem{item0})))
+
+	item1 := NewCancelDocumentsItemSingleFile(t.docid, ca.Address, true, t.cid)
+	err := opr.Process(t.newCancelDocuments(ca.Address, ca.Privs(), []CancelDocumentsItem{item1}))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "violates only one sender")
+}
+
+func TestCancelDocumentsOperations(t *testing.T) {
+	suite.Run(t, new(testCancelDocumentsOperations))
+}
@@ -0,0 +1,42 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	CancelItemSingleDocumentType   = hint.Type("mitum-blocksign-cancel-item-single-document")
+	CancelItemSingleDocumentHint   = hint.NewHint(CancelItemSingleDocumentType, "v0.0.1")
+	CancelItemSingleDocumentHinter = BaseCancelDocumentsItem{hint: CancelItemSingleDocumentHint}
+)
+
+type CancelDocumentsItemSingleFile struct {
+	BaseCancelDocumentsItem
+}
+
+func NewCancelDocumentsItemSingleFile(
+	docId currency.Big,
+	owner base.Address,
+	remove bool,
+	cid currency.CurrencyID,
+) CancelDocumentsItemSingleFile {
+	return CancelDocumentsItemSingleFile{
+		BaseCancelDocumentsItem: NewBaseCancelDocumentsItem(CancelItemSingleDocumentHint, docId, owner, remove, cid),
+	}
+}
+
+func (it CancelDocumentsItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseCancelDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it CancelDocumentsItemSingleFile) Rebuild() CancelDocumentsItem {
+	it.BaseCancelDocumentsItem = it.BaseCancelDocumentsItem.Rebuild().(BaseCancelDocumentsItem)
+
+	return it
+}
@@ -0,0 +1,82 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+func testCancelDocumentsItemSingleFileEncode(enc encoder.Encoder) suite.TestingSuite {
+	t := new(baseTestOperationEncode)
+
+	docId0 := currency.NewBig(0)
+	docId1 := currency.NewBig(1)
+	t.enc = enc
+	t.newObject = func() interface{} {
+		s := MustAddress(util.UUID().String())
+
+		token := util.UUID().Bytes()
+		items := []CancelDocumentsItem{
+			NewCancelDocumentsItemSingleFile(docId0, s, false, currency.CurrencyID("SHOWME")),
+			NewCancelDocumentsItemSingleFile(docId1, s, true, currency.CurrencyID("FINDME")),
+		}
+		fact := NewCancelDocumentsFact(token, s, items)
+
+		var fs []operation.FactSign
+
+		for _, pk := range []key.Privatekey{
+			key.MustNewBTCPrivatekey(),
+			key.MustNewBTCPrivatekey(),
+		} {
+			sig, err := operation.NewFactSignature(pk, fact, nil)
+			t.NoError(err)
+
+			fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+		}
+
+		op, err := NewCancelDocuments(fact, fs, util.UUID().String())
+		t.NoError(err)
+
+		return op
+	}
+
+	t.compare = func(a, b interface{}) {
+		ta := a.(CancelDocuments)
+		tb := b.(CancelDocuments)
+
+		t.Equal(ta.Memo, tb.Memo)
+
+		fact := ta.Fact().(CancelDocumentsFact)
+		ufact := tb.Fact().(CancelDocumentsFact)
+
+		t.True(fact.sender.Equal(ufact.sender))
+		t.Equal(len(fact.Items()), len(ufact.Items()))
+
+		for i := range fact.Items() {
+			a := fact.Items()[i]
+			b := ufact.Items()[i]
+			t.True(a.DocumentId().Equal(b.DocumentId()))
+			t.True(a.Owner().Equal(b.Owner()))
+			t.Equal(a.Remove(), b.Remove())
+			t.Equal(a.Currency(), (b.Currency()))
+			t.Equal(a.Bytes(), b.Bytes())
+		}
+	}
+
+	return t
+}
+
+func TestCancelDocumentsItemSingleFileEncodeJSON(t *testing.T) {
+	suite.Run(t, testCancelDocumentsItemSingleFileEncode(jsonenc.NewEncoder()))
+}
+
+func TestCancelDocumentsItemSingleFileEncodeBSON(t *testing.T) {
+	suite.Run(t, testCancelDocumentsItemSingleFileEncode(bsonenc.NewEncoder()))
+}
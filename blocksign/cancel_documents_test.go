@@ -0,0 +1,82 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+type testCancelDocuments struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+}
+
+func (t *testCancelDocuments) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+}
+
+func (t *testCancelDocuments) newOperation(sender base.Address, items []CancelDocumentsItem) CancelDocuments {
+	token := util.UUID().Bytes()
+	fact := NewCancelDocumentsFact(token, sender, items)
+
+	pk := key.MustNewBTCPrivatekey()
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	op, err := NewCancelDocuments(fact, fs, "")
+	t.NoError(err)
+
+	return op
+}
+
+func (t *testCancelDocuments) TestNew() {
+	s := MustAddress(util.UUID().String())
+
+	items := []CancelDocumentsItem{NewCancelDocumentsItemSingleFile(t.docId, s, false, t.cid)}
+	op := t.newOperation(s, items)
+
+	t.NoError(op.IsValid(nil))
+
+	t.Implements((*base.Fact)(nil), op.Fact())
+	t.Implements((*operation.Operation)(nil), op)
+
+	as, err := op.Fact().(CancelDocumentsFact).Addresses()
+	t.NoError(err)
+	t.Equal(1, len(as))
+}
+
+func (t *testCancelDocuments) TestDuplicatedDocuments() {
+	s := MustAddress(util.UUID().String())
+
+	items := []CancelDocumentsItem{
+		NewCancelDocumentsItemSingleFile(t.docId, s, false, t.cid),
+		NewCancelDocumentsItemSingleFile(t.docId, s, true, t.cid),
+	}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "duplicated document found")
+}
+
+func (t *testCancelDocuments) TestSenderNotOwner() {
+	s := MustAddress(util.UUID().String())
+	o := MustAddress(util.UUID().String())
+
+	items := []CancelDocumentsItem{NewCancelDocumentsItemSingleFile(t.docId, o, false, t.cid)}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "sender is not owner of document")
+}
+
+func TestCancelDocuments(t *testing.T) {
+	suite.Run(t, new(testCancelDocuments))
+}
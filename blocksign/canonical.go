@@ -0,0 +1,20 @@
+package blocksign
+
+import "github.com/spikeekips/mitum/util"
+
+// concatCanonicalBytes concatenates bytes with their lengths, so the
+// neighboring fields can not be confused with each other.
+func concatCanonicalBytes(bs ...[]byte) []byte {
+	var n int
+	for i := range bs {
+		n += 8 + len(bs[i])
+	}
+
+	b := make([]byte, 0, n)
+	for i := range bs {
+		b = append(b, util.Uint64ToBytes(uint64(len(bs[i])))...)
+		b = append(b, bs[i]...)
+	}
+
+	return b
+}
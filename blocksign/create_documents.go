@@ -33,6 +33,14 @@ type CreateDocumentsItem interface {
 	Signers() []base.Address
 	Signcodes() []string
 	Currency() currency.CurrencyID
+	IsDocumentIdOmitted() bool
+	Expiry() base.Height
+	SigningMode() SigningMode
+	Weights() []uint
+	Threshold() uint
+	Metadata() DocumentMetadata
+	Files() []DocFile
+	Sponsored() bool
 	Rebuild() CreateDocumentsItem
 }
 
@@ -96,15 +104,26 @@ func (fact CreateDocumentsFact) IsValid([]byte) error {
 	}
 
 	fhmap := map[string]bool{}
+	idmap := map[string]bool{}
 	for i := range fact.items {
 		if err := fact.items[i].IsValid(nil); err != nil {
 			return err
 		}
-		_, found := fhmap[fact.items[i].FileHash().String()]
-		if found {
-			return errors.Errorf("duplicated filehash, %v", fact.items[i].FileHash())
+		files := fact.items[i].Files()
+		for j := range files {
+			if _, found := fhmap[files[j].FileHash().String()]; found {
+				return errors.Errorf("duplicated filehash, %v", files[j].FileHash())
+			}
+			fhmap[files[j].FileHash().String()] = true
 		}
-		fhmap[fact.items[i].FileHash().String()] = true
+
+		if fact.items[i].IsDocumentIdOmitted() {
+			continue
+		}
+		if _, found := idmap[fact.items[i].DocumentId().String()]; found {
+			return errors.Errorf("duplicated documentid, %v", fact.items[i].DocumentId())
+		}
+		idmap[fact.items[i].DocumentId().String()] = true
 	}
 
 	if !fact.h.Equal(fact.GenerateHash()) {
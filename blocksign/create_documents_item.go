@@ -18,6 +18,12 @@ type BaseCreateDocumentsItem struct {
 	signers    []base.Address
 	signcodes  []string //signers signcode
 	cid        currency.CurrencyID
+	expiry     base.Height
+	mode       SigningMode
+	weights    []uint // signers weight
+	threshold  uint
+	metadata   DocumentMetadata
+	sponsored  bool // creator escrows the signing fees of signers
 }
 
 func NewBaseCreateDocumentsItem(ht hint.Hint,
@@ -38,6 +44,7 @@ func NewBaseCreateDocumentsItem(ht hint.Hint,
 		signers:    signers,
 		signcodes:  signcodes,
 		cid:        cid,
+		expiry:     base.NilHeight,
 	}
 }
 
@@ -46,6 +53,61 @@ func (it BaseCreateDocumentsItem) Hint() hint.Hint {
 }
 
 func (it BaseCreateDocumentsItem) Bytes() []byte {
+	if it.isLegacy() {
+		return it.legacyBytes()
+	}
+
+	bsg := make([][]byte, len(it.signers))
+	for i := range it.signers {
+		bsg[i] = it.signers[i].Bytes()
+	}
+
+	bsc := make([][]byte, len(it.signcodes))
+	for i := range it.signcodes {
+		bsc[i] = []byte(it.signcodes[i])
+	}
+
+	bwt := make([][]byte, len(it.weights))
+	for i := range it.weights {
+		bwt[i] = util.UintToBytes(it.weights[i])
+	}
+
+	// NOTE the omitted document id, currency.NilBig is distinguished from 1
+	// by string
+	return concatCanonicalBytes(
+		it.hint.Bytes(),
+		it.fileHash.Bytes(),
+		[]byte(it.documentid.String()),
+		[]byte(it.signcode),
+		[]byte(it.title),
+		it.size.Bytes(),
+		it.cid.Bytes(),
+		concatCanonicalBytes(bsg...),
+		concatCanonicalBytes(bsc...),
+		it.expiry.Bytes(),
+		[]byte{byte(it.mode)},
+		concatCanonicalBytes(bwt...),
+		util.UintToBytes(it.threshold),
+		it.metadata.Bytes(),
+		util.BoolToBytes(it.sponsored),
+	)
+}
+
+// isLegacy returns true when item has the hint before the canonical encoding.
+func (it BaseCreateDocumentsItem) isLegacy() bool {
+	return it.hint.Equal(CreateDocumentsItemSingleFileLegacyHint) ||
+		it.hint.Equal(CreateDocumentsItemMultiFilesLegacyHint)
+}
+
+// isLegacyCreateDocumentsItem returns true when item has the legacy hint; the
+// legacy items are only for reading chain history.
+func isLegacyCreateDocumentsItem(it CreateDocumentsItem) bool {
+	return it.Hint().Equal(CreateDocumentsItemSingleFileLegacyHint) ||
+		it.Hint().Equal(CreateDocumentsItemMultiFilesLegacyHint)
+}
+
+// legacyBytes is the encoding of the legacy hints.
+func (it BaseCreateDocumentsItem) legacyBytes() []byte {
 	bs := make([][]byte, len(it.signers)+len(it.signcodes)+6)
 	bs[0] = it.fileHash.Bytes()
 	bs[1] = it.documentid.Bytes()
@@ -60,17 +122,44 @@ func (it BaseCreateDocumentsItem) Bytes() []byte {
 		bs[i+len(it.signers)+6] = []byte(it.signcodes[i])
 	}
 
+	if !it.expiry.IsEmpty() {
+		bs = append(bs, it.expiry.Bytes())
+	}
+
+	if it.mode != SigningParallel {
+		bs = append(bs, []byte{byte(it.mode)})
+	}
+
+	if it.threshold > 0 {
+		for i := range it.weights {
+			bs = append(bs, util.UintToBytes(it.weights[i]))
+		}
+		bs = append(bs, util.UintToBytes(it.threshold))
+	}
+
+	if len(it.metadata) > 0 {
+		bs = append(bs, it.metadata.Bytes())
+	}
+
+	if it.sponsored {
+		bs = append(bs, []byte{1})
+	}
+
 	return util.ConcatBytesSlice(bs...)
 }
 
 func (it BaseCreateDocumentsItem) IsValid([]byte) error {
-	if len(it.fileHash) < 1 {
-		return errors.Errorf("empty fileHash")
+	if it.isLegacy() {
+		if err := it.fileHash.IsValidLegacy(); err != nil {
+			return err
+		}
+	} else if err := it.fileHash.IsValid(nil); err != nil {
+		return err
 	}
 	if (it.documentid == currency.Big{}) {
 		return errors.Errorf("empty documentid")
 	}
-	if !it.documentid.OverZero() {
+	if !it.documentid.OverZero() && !it.IsDocumentIdOmitted() {
 		return errors.Errorf("documentid is negative number")
 	}
 	if len(it.signcode) < 1 {
@@ -82,6 +171,56 @@ func (it BaseCreateDocumentsItem) IsValid([]byte) error {
 	if len(it.signers) != len(it.signcodes) {
 		return errors.Errorf("length of signers array is not same with length of signcodes array")
 	}
+	// NOTE the format of signcode is checked only in the current hints; the
+	// items of legacy hints are already in the existing blocks
+	if !it.isLegacy() {
+		if err := SignCode(it.signcode).IsValidFormat(); err != nil {
+			return errors.Wrap(err, "invalid creator signcode")
+		}
+		for i := range it.signcodes {
+			if err := SignCode(it.signcodes[i]).IsValidFormat(); err != nil {
+				return errors.Wrapf(err, "invalid signcode of signer, %v", it.signers[i])
+			}
+		}
+	}
+	if !it.expiry.IsEmpty() && it.expiry < base.Height(0) {
+		return errors.Errorf("invalid expiry height, %v", it.expiry)
+	}
+	if err := it.mode.IsValid(nil); err != nil {
+		return err
+	}
+	if it.threshold > 0 || len(it.weights) > 0 {
+		if err := it.isValidQuorum(); err != nil {
+			return err
+		}
+	}
+	if err := it.metadata.IsValid(nil); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isValidQuorum checks weights and threshold like currency.Keys.
+func (it BaseCreateDocumentsItem) isValidQuorum() error {
+	if len(it.weights) != len(it.signers) {
+		return errors.Errorf("length of weights array is not same with length of signers array")
+	}
+	if it.threshold < 1 || it.threshold > 100 {
+		return errors.Errorf("invalid threshold, %d, 1 <= threshold <= 100", it.threshold)
+	}
+
+	var sum uint
+	for i := range it.weights {
+		if w := it.weights[i]; w < 1 || w > 100 {
+			return errors.Errorf("invalid signer weight, %d, 1 <= weight <= 100", w)
+		}
+		sum += it.weights[i]
+	}
+
+	if sum < it.threshold {
+		return errors.Errorf("sum of weights under threshold, %d < %d", sum, it.threshold)
+	}
+
 	return nil
 }
 
@@ -90,10 +229,18 @@ func (it BaseCreateDocumentsItem) FileHash() FileHash {
 	return it.fileHash
 }
 
+// DocumentId returns the document id of item. The explicit document id should
+// be over zero and not registered yet.
 func (it BaseCreateDocumentsItem) DocumentId() currency.Big {
 	return it.documentid
 }
 
+// IsDocumentIdOmitted returns true when the document id is left to be
+// assigned by the chain.
+func (it BaseCreateDocumentsItem) IsDocumentIdOmitted() bool {
+	return it.documentid.Equal(currency.NilBig)
+}
+
 func (it BaseCreateDocumentsItem) Signcode() string {
 	return it.signcode
 }
@@ -119,6 +266,40 @@ func (it BaseCreateDocumentsItem) Currency() currency.CurrencyID {
 	return it.cid
 }
 
+// Expiry returns the height after which the document can not be signed
+// anymore; base.NilHeight means no expiry.
+func (it BaseCreateDocumentsItem) Expiry() base.Height {
+	return it.expiry
+}
+
+func (it BaseCreateDocumentsItem) SigningMode() SigningMode {
+	return it.mode
+}
+
+func (it BaseCreateDocumentsItem) Weights() []uint {
+	return it.weights
+}
+
+func (it BaseCreateDocumentsItem) Threshold() uint {
+	return it.threshold
+}
+
+// Files returns the files of document; single file item has one file, which
+// is named by title.
+func (it BaseCreateDocumentsItem) Files() []DocFile {
+	return []DocFile{NewDocFile(it.fileHash, it.title, it.size)}
+}
+
+func (it BaseCreateDocumentsItem) Metadata() DocumentMetadata {
+	return it.metadata
+}
+
+// Sponsored returns true when the creator pays the signing fees of signers
+// from escrow.
+func (it BaseCreateDocumentsItem) Sponsored() bool {
+	return it.sponsored
+}
+
 func (it BaseCreateDocumentsItem) Rebuild() CreateDocumentsItem {
 	return it
 }
@@ -8,19 +8,34 @@ import (
 )
 
 func (it BaseCreateDocumentsItem) MarshalBSON() ([]byte, error) {
-	return bsonenc.Marshal(
-		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()),
-			bson.M{
-				"filehash":   it.fileHash,
-				"documentid": it.documentid,
-				"signcode":   it.signcode,
-				"title":      it.title,
-				"size":       it.size,
-				"signers":    it.signers,
-				"signcodes":  it.signcodes,
-				"currency":   it.cid,
-			}),
-	)
+	return bsonenc.Marshal(bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()), it.bsonM()))
+}
+
+func (it BaseCreateDocumentsItem) bsonM() bson.M {
+	m := bson.M{
+		"filehash":    it.fileHash,
+		"documentid":  it.documentid,
+		"signcode":    it.signcode,
+		"title":       it.title,
+		"size":        it.size,
+		"signers":     it.signers,
+		"signcodes":   it.signcodes,
+		"currency":    it.cid,
+		"expiry":      it.expiry,
+		"signingmode": it.mode.String(),
+		"weights":     it.weights,
+		"threshold":   it.threshold,
+	}
+
+	if len(it.metadata) > 0 {
+		m["metadata"] = it.metadata
+	}
+
+	if it.sponsored {
+		m["sponsored"] = true
+	}
+
+	return m
 }
 
 type CreateDocumentsItemBSONUnpacker struct {
@@ -32,6 +47,12 @@ type CreateDocumentsItemBSONUnpacker struct {
 	SG []base.AddressDecoder `bson:"signers"`
 	SD []string              `bson:"signcodes"`
 	CI string                `bson:"currency"`
+	EX *base.Height          `bson:"expiry"`
+	SM string                `bson:"signingmode"`
+	WT []uint                `bson:"weights"`
+	TH uint                  `bson:"threshold"`
+	MD map[string]string     `bson:"metadata"`
+	SP bool                  `bson:"sponsored"`
 }
 
 func (it *BaseCreateDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
@@ -45,5 +66,5 @@ func (it *BaseCreateDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) er
 		return err
 	}
 
-	return it.unpack(enc, ht.H, ucd.FH, ucd.DI, ucd.SC, ucd.TL, ucd.SZ, ucd.SG, ucd.SD, ucd.CI)
+	return it.unpack(enc, ht.H, ucd.FH, ucd.DI, ucd.SC, ucd.TL, ucd.SZ, ucd.SG, ucd.SD, ucd.CI, ucd.EX, ucd.SM, ucd.WT, ucd.TH, ucd.MD, ucd.SP)
 }
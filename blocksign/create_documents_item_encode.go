@@ -18,7 +18,12 @@ func (it *BaseCreateDocumentsItem) unpack(
 	bsg []base.AddressDecoder,
 	bsd []string,
 	scid string,
-
+	bex *base.Height,
+	bsm string,
+	bwt []uint,
+	bth uint,
+	bmd map[string]string,
+	bsp bool,
 ) error {
 	it.hint = ht
 
@@ -33,12 +38,35 @@ func (it *BaseCreateDocumentsItem) unpack(
 	}
 	it.signers = signers
 	it.fileHash = FileHash(bfh)
+	if (bdi == currency.Big{}) {
+		bdi = currency.NilBig
+	}
 	it.documentid = bdi
 	it.signcode = bsc
 	it.title = btl
 	it.size = bsz
 	it.signcodes = bsd
 	it.cid = currency.CurrencyID(scid)
+	if bex == nil {
+		it.expiry = base.NilHeight
+	} else {
+		it.expiry = *bex
+	}
+
+	if len(bsm) < 1 {
+		it.mode = SigningParallel
+	} else {
+		mode, err := ParseSigningMode(bsm)
+		if err != nil {
+			return err
+		}
+		it.mode = mode
+	}
+
+	it.weights = bwt
+	it.threshold = bth
+	it.metadata = DocumentMetadata(bmd).Copy()
+	it.sponsored = bsp
 
 	return nil
 }
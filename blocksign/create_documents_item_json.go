@@ -16,10 +16,20 @@ type CreateDocumentsItemJSONPacker struct {
 	SG []base.Address      `json:"signers"`
 	SD []string            `json:"signcodes"`
 	CI currency.CurrencyID `json:"currency"`
+	EX base.Height         `json:"expiry"`
+	SM string              `json:"signingmode"`
+	WT []uint              `json:"weights"`
+	TH uint                `json:"threshold"`
+	MD DocumentMetadata    `json:"metadata,omitempty"`
+	SP bool                `json:"sponsored,omitempty"`
 }
 
 func (it BaseCreateDocumentsItem) MarshalJSON() ([]byte, error) {
-	return jsonenc.Marshal(CreateDocumentsItemJSONPacker{
+	return jsonenc.Marshal(it.packJSON())
+}
+
+func (it BaseCreateDocumentsItem) packJSON() CreateDocumentsItemJSONPacker {
+	return CreateDocumentsItemJSONPacker{
 		HintedHead: jsonenc.NewHintedHead(it.Hint()),
 		FH:         it.fileHash,
 		DI:         it.documentid,
@@ -29,7 +39,13 @@ func (it BaseCreateDocumentsItem) MarshalJSON() ([]byte, error) {
 		SG:         it.signers,
 		SD:         it.signcodes,
 		CI:         it.cid,
-	})
+		EX:         it.expiry,
+		SM:         it.mode.String(),
+		WT:         it.weights,
+		TH:         it.threshold,
+		MD:         it.metadata,
+		SP:         it.sponsored,
+	}
 }
 
 type CreateDocumentsItemJSONUnpacker struct {
@@ -41,6 +57,12 @@ type CreateDocumentsItemJSONUnpacker struct {
 	SG []base.AddressDecoder `json:"signers"`
 	SD []string              `json:"signcodes"`
 	CI string                `json:"currency"`
+	EX *base.Height          `json:"expiry"`
+	SM string                `json:"signingmode"`
+	WT []uint                `json:"weights"`
+	TH uint                  `json:"threshold"`
+	MD map[string]string     `json:"metadata"`
+	SP bool                  `json:"sponsored"`
 }
 
 func (it *BaseCreateDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
@@ -54,5 +76,5 @@ func (it *BaseCreateDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) er
 		return err
 	}
 
-	return it.unpack(enc, ht.H, ucd.FH, ucd.DI, ucd.SC, ucd.TL, ucd.SZ, ucd.SG, ucd.SD, ucd.CI)
+	return it.unpack(enc, ht.H, ucd.FH, ucd.DI, ucd.SC, ucd.TL, ucd.SZ, ucd.SG, ucd.SD, ucd.CI, ucd.EX, ucd.SM, ucd.WT, ucd.TH, ucd.MD, ucd.SP)
 }
@@ -0,0 +1,185 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	CreateDocumentsItemMultiFilesType   = hint.Type("mitum-blocksign-create-documents-multi-files")
+	CreateDocumentsItemMultiFilesHint   = hint.NewHint(CreateDocumentsItemMultiFilesType, "v0.0.2")
+	CreateDocumentsItemMultiFilesHinter = CreateDocumentsItemMultiFiles{
+		BaseCreateDocumentsItem: BaseCreateDocumentsItem{hint: CreateDocumentsItemMultiFilesHint},
+	}
+	// CreateDocumentsItemMultiFilesLegacyHint is the hint of item before the
+	// canonical encoding.
+	CreateDocumentsItemMultiFilesLegacyHint = hint.NewHint(CreateDocumentsItemMultiFilesType, "v0.0.1")
+)
+
+var MaxDocFilesInItem = 10
+
+// CreateDocumentsItemMultiFiles creates document, which has multiple files
+// under one document id. The first file is the main file of document; the
+// filehash of document is the filehash of main file and the size of document
+// is the sum of sizes of files.
+type CreateDocumentsItemMultiFiles struct {
+	BaseCreateDocumentsItem
+	files []DocFile
+}
+
+func NewCreateDocumentsItemMultiFiles(
+	documentid currency.Big,
+	signcode, title string,
+	files []DocFile,
+	signers []base.Address,
+	signcodes []string,
+	cid currency.CurrencyID,
+) CreateDocumentsItemMultiFiles {
+	var fh FileHash
+	if len(files) > 0 {
+		fh = files[0].FileHash()
+	}
+
+	return CreateDocumentsItemMultiFiles{
+		BaseCreateDocumentsItem: NewBaseCreateDocumentsItem(
+			CreateDocumentsItemMultiFilesHint,
+			fh,
+			documentid,
+			signcode,
+			title,
+			sumDocFilesSize(files),
+			signers,
+			signcodes,
+			cid,
+		),
+		files: files,
+	}
+}
+
+// NewCreateDocumentsItemMultiFilesWithoutId makes item, whose document id will
+// be assigned by the chain.
+func NewCreateDocumentsItemMultiFilesWithoutId(
+	signcode, title string,
+	files []DocFile,
+	signers []base.Address,
+	signcodes []string,
+	cid currency.CurrencyID,
+) CreateDocumentsItemMultiFiles {
+	return NewCreateDocumentsItemMultiFiles(currency.NilBig, signcode, title, files, signers, signcodes, cid)
+}
+
+func (it CreateDocumentsItemMultiFiles) Bytes() []byte {
+	if !it.isLegacy() {
+		bfs := make([][]byte, len(it.files))
+		for i := range it.files {
+			bfs[i] = it.files[i].Bytes()
+		}
+
+		return concatCanonicalBytes(it.BaseCreateDocumentsItem.Bytes(), concatCanonicalBytes(bfs...))
+	}
+
+	bs := make([][]byte, len(it.files)+1)
+	bs[0] = it.BaseCreateDocumentsItem.Bytes()
+	for i := range it.files {
+		bs[i+1] = it.files[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (it CreateDocumentsItemMultiFiles) Files() []DocFile {
+	return it.files
+}
+
+// WithExpiry sets the height, after which the document can not be signed.
+func (it CreateDocumentsItemMultiFiles) WithExpiry(height base.Height) CreateDocumentsItemMultiFiles {
+	it.expiry = height
+
+	return it
+}
+
+// WithSigningMode sets the signing mode of document.
+func (it CreateDocumentsItemMultiFiles) WithSigningMode(mode SigningMode) CreateDocumentsItemMultiFiles {
+	it.mode = mode
+
+	return it
+}
+
+// WithQuorum sets the weights of signers and threshold; the document is fully
+// signed when the sum of signed weights passes threshold.
+func (it CreateDocumentsItemMultiFiles) WithQuorum(weights []uint, threshold uint) CreateDocumentsItemMultiFiles {
+	it.weights = weights
+	it.threshold = threshold
+
+	return it
+}
+
+// WithMetadata sets the metadata of document.
+func (it CreateDocumentsItemMultiFiles) WithMetadata(md DocumentMetadata) CreateDocumentsItemMultiFiles {
+	it.metadata = md.Copy()
+
+	return it
+}
+
+// WithSponsored sets whether the creator escrows the signing fees of signers.
+func (it CreateDocumentsItemMultiFiles) WithSponsored(sponsored bool) CreateDocumentsItemMultiFiles {
+	it.sponsored = sponsored
+
+	return it
+}
+
+func (it CreateDocumentsItemMultiFiles) IsValid([]byte) error {
+	if err := it.BaseCreateDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	if n := len(it.files); n < 2 {
+		return errors.Errorf("multi files item should have at least 2 files, %d", n)
+	} else if n > MaxDocFilesInItem {
+		return errors.Errorf("files, %d over max, %d", n, MaxDocFilesInItem)
+	}
+
+	founds := map[string]bool{}
+	for i := range it.files {
+		f := it.files[i]
+		if err := f.IsValid(nil); err != nil {
+			return err
+		}
+		if !it.isLegacy() {
+			if err := f.FileHash().IsValid(nil); err != nil {
+				return err
+			}
+		}
+
+		if founds[f.FileHash().String()] {
+			return errors.Errorf("duplicated filehash in files, %v", f.FileHash())
+		}
+		founds[f.FileHash().String()] = true
+	}
+
+	if !it.fileHash.Equal(it.files[0].FileHash()) {
+		return errors.Errorf("filehash is not same with the main file, %v != %v", it.fileHash, it.files[0].FileHash())
+	}
+
+	if size := sumDocFilesSize(it.files); !it.size.Equal(size) {
+		return errors.Errorf("size is not same with the sum of file sizes, %v != %v", it.size, size)
+	}
+
+	return nil
+}
+
+func (it CreateDocumentsItemMultiFiles) Rebuild() CreateDocumentsItem {
+	return it
+}
+
+func sumDocFilesSize(files []DocFile) currency.Big {
+	size := currency.ZeroBig
+	for i := range files {
+		size = size.Add(files[i].Size())
+	}
+
+	return size
+}
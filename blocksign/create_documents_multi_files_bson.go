@@ -0,0 +1,36 @@
+package blocksign // nolint:dupl
+
+import (
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (it CreateDocumentsItemMultiFiles) MarshalBSON() ([]byte, error) {
+	m := it.BaseCreateDocumentsItem.bsonM()
+	m["files"] = it.files
+
+	return bsonenc.Marshal(bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()), m))
+}
+
+type CreateDocumentsItemMultiFilesBSONUnpacker struct {
+	FS bson.Raw `bson:"files"`
+}
+
+func (it *CreateDocumentsItemMultiFiles) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	if err := it.BaseCreateDocumentsItem.UnpackBSON(b, enc); err != nil {
+		return err
+	}
+
+	var ucd CreateDocumentsItemMultiFilesBSONUnpacker
+	if err := bson.Unmarshal(b, &ucd); err != nil {
+		return err
+	}
+
+	files, err := decodeDocFiles(enc, ucd.FS)
+	if err != nil {
+		return err
+	}
+	it.files = files
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package blocksign
+
+import (
+	"encoding/json"
+
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type CreateDocumentsItemMultiFilesJSONPacker struct {
+	CreateDocumentsItemJSONPacker
+	FS []DocFile `json:"files"`
+}
+
+func (it CreateDocumentsItemMultiFiles) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(CreateDocumentsItemMultiFilesJSONPacker{
+		CreateDocumentsItemJSONPacker: it.BaseCreateDocumentsItem.packJSON(),
+		FS:                            it.files,
+	})
+}
+
+type CreateDocumentsItemMultiFilesJSONUnpacker struct {
+	FS json.RawMessage `json:"files"`
+}
+
+func (it *CreateDocumentsItemMultiFiles) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	if err := it.BaseCreateDocumentsItem.UnpackJSON(b, enc); err != nil {
+		return err
+	}
+
+	var ucd CreateDocumentsItemMultiFilesJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &ucd); err != nil {
+		return err
+	}
+
+	files, err := decodeDocFiles(enc, ucd.FS)
+	if err != nil {
+		return err
+	}
+	it.files = files
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type testCreateDocumentsMultiFiles struct {
+	baseTest
+	cid    currency.CurrencyID
+	signer base.Address
+}
+
+func (t *testCreateDocumentsMultiFiles) SetupTest() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.signer = MustAddress(util.UUID().String())
+}
+
+func (t *testCreateDocumentsMultiFiles) files() []DocFile {
+	return []DocFile{
+		NewDocFile(MustFileHash("ABCD"), "main.pdf", currency.NewBig(555)),
+		NewDocFile(MustFileHash("EFGH"), "appendix.pdf", currency.NewBig(10)),
+	}
+}
+
+func (t *testCreateDocumentsMultiFiles) newItem(files []DocFile) CreateDocumentsItemMultiFiles {
+	return NewCreateDocumentsItemMultiFiles(
+		currency.NewBig(1),
+		"user0",
+		"title01",
+		files,
+		[]base.Address{t.signer},
+		[]string{"user1"},
+		t.cid,
+	)
+}
+
+func (t *testCreateDocumentsMultiFiles) TestNew() {
+	files := t.files()
+	item := t.newItem(files)
+	t.NoError(item.IsValid(nil))
+
+	t.True(item.FileHash().Equal(files[0].FileHash()))
+	t.True(item.Size().Equal(currency.NewBig(565)))
+	t.Equal(len(files), len(item.Files()))
+	t.Equal(CreateDocumentsItemMultiFilesHint, item.Hint())
+}
+
+func (t *testCreateDocumentsMultiFiles) TestSingleFile() {
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, t.cid)
+
+	t.Equal(1, len(item.Files()))
+	t.True(item.Files()[0].Equal(NewDocFile(MustFileHash("ABCD"), "title01", currency.NewBig(555))))
+}
+
+func (t *testCreateDocumentsMultiFiles) TestTooFewFiles() {
+	err := t.newItem(t.files()[:1]).IsValid(nil)
+	t.Contains(err.Error(), "files")
+}
+
+func (t *testCreateDocumentsMultiFiles) TestTooManyFiles() {
+	files := make([]DocFile, MaxDocFilesInItem+1)
+	for i := range files {
+		files[i] = NewDocFile(MustFileHash(util.UUID().String()), "file", currency.NewBig(1))
+	}
+
+	err := t.newItem(files).IsValid(nil)
+	t.Contains(err.Error(), "files")
+}
+
+func (t *testCreateDocumentsMultiFiles) TestDuplicatedFileHash() {
+	files := t.files()
+	files[1] = NewDocFile(files[0].FileHash(), "copy.pdf", currency.NewBig(1))
+
+	err := t.newItem(files).IsValid(nil)
+	t.Contains(err.Error(), "duplicated filehash")
+}
+
+func (t *testCreateDocumentsMultiFiles) TestInvalidFileName() {
+	for _, name := range []string{"", "../main.pdf", "a\\b", "a\nb"} {
+		files := t.files()
+		files[1] = NewDocFile(MustFileHash("EFGH"), name, currency.NewBig(1))
+
+		t.Error(t.newItem(files).IsValid(nil), name)
+	}
+}
+
+func (t *testCreateDocumentsMultiFiles) TestDuplicatedFileHashInFact() {
+	items := []CreateDocumentsItem{
+		t.newItem(t.files()),
+		NewCreateDocumentsItemSingleFile(MustFileHash("EFGH"), currency.NewBig(1), "user0", "title02", currency.NewBig(1), []base.Address{}, []string{}, t.cid),
+	}
+
+	fact := NewCreateDocumentsFact(util.UUID().Bytes(), MustAddress(util.UUID().String()), items)
+
+	err := fact.IsValid(nil)
+	t.Contains(err.Error(), "duplicated filehash")
+}
+
+func TestCreateDocumentsMultiFiles(t *testing.T) {
+	suite.Run(t, new(testCreateDocumentsMultiFiles))
+}
+
+func testCreateDocumentsMultiFilesEncode(enc encoder.Encoder) suite.TestingSuite {
+	t := new(baseTestOperationEncode)
+
+	t.enc = enc
+	t.newObject = func() interface{} {
+		ownerPrvk := key.MustNewBTCPrivatekey()
+		ownerKey, err := currency.NewKey(ownerPrvk.Publickey(), 100)
+		t.NoError(err)
+		ownerKeys, err := currency.NewKeys([]currency.Key{ownerKey}, 100)
+		t.NoError(err)
+		owner, _ := currency.NewAddressFromKeys(ownerKeys)
+
+		signer := MustAddress(util.UUID().String())
+
+		files := []DocFile{
+			NewDocFile(MustFileHash("ABCD"), "main.pdf", currency.NewBig(555)),
+			NewDocFile(MustFileHash("EFGH"), "appendix.pdf", currency.NewBig(10)),
+		}
+
+		item := NewCreateDocumentsItemMultiFiles(currency.NewBig(1), "user0", "title01", files, []base.Address{signer}, []string{"user1"}, currency.CurrencyID("SHOWME")).
+			WithExpiry(base.Height(33)).
+			WithMetadata(DocumentMetadata{MetadataKeyMimeType: "application/pdf"}).
+			WithSponsored(true)
+		fact := NewCreateDocumentsFact(util.UUID().Bytes(), owner, []CreateDocumentsItem{item})
+
+		var fs []operation.FactSign
+
+		sig, err := operation.NewFactSignature(ownerPrvk, fact, nil)
+		t.NoError(err)
+		fs = append(fs, operation.NewBaseFactSign(ownerPrvk.Publickey(), sig))
+
+		cd, err := NewCreateDocuments(fact, fs, util.UUID().String())
+		t.NoError(err)
+
+		return cd
+	}
+
+	t.compare = func(a, b interface{}) {
+		da := a.(CreateDocuments)
+		db := b.(CreateDocuments)
+
+		fact := da.Fact().(CreateDocumentsFact)
+		ufact := db.Fact().(CreateDocumentsFact)
+
+		t.Equal(len(fact.Items()), len(ufact.Items()))
+
+		for i := range fact.Items() {
+			a := fact.Items()[i]
+			b := ufact.Items()[i]
+
+			t.IsType(a, b)
+			t.Equal(a.Hint(), b.Hint())
+			t.True(a.FileHash().Equal(b.FileHash()))
+			t.True(a.Size().Equal(b.Size()))
+			t.Equal(a.Title(), b.Title())
+			t.Equal(a.Expiry(), b.Expiry())
+			t.True(a.Metadata().Equal(b.Metadata()))
+			t.True(b.Sponsored())
+
+			t.Equal(len(a.Files()), len(b.Files()))
+			for j := range a.Files() {
+				t.True(a.Files()[j].Equal(b.Files()[j]))
+			}
+		}
+	}
+
+	return t
+}
+
+func TestCreateDocumentsMultiFilesEncodeJSON(t *testing.T) {
+	suite.Run(t, testCreateDocumentsMultiFilesEncode(jsonenc.NewEncoder()))
+}
+
+func TestCreateDocumentsMultiFilesEncodeBSON(t *testing.T) {
+	suite.Run(t, testCreateDocumentsMultiFilesEncode(bsonenc.NewEncoder()))
+}
@@ -17,13 +17,18 @@ func (op CreateDocuments) Process(
 }
 
 type CreateDocumentsItemProcessor struct {
-	cp      *currency.CurrencyPool
-	sender  base.Address
-	h       valuehash.Hash
-	item    CreateDocumentsItem
-	nds     state.State // new document data state (key = document filehash)
-	docInfo DocInfo     // new document info
-
+	cp         *currency.CurrencyPool
+	policy     DocumentPolicy
+	sender     base.Address
+	height     base.Height
+	h          valuehash.Hash
+	item       CreateDocumentsItem
+	documentid currency.Big  // document id, assigned by chain if omitted in item
+	escrow     currency.Big  // escrow for signing fees of signers
+	nds        state.State   // new document data state (key = document filehash)
+	docInfo    DocInfo       // new document info
+	nfhs       []state.State // file hash index states of files
+	fhinvs     []DocumentInventory
 }
 
 func (opp *CreateDocumentsItemProcessor) PreProcess(
@@ -31,20 +36,29 @@ func (opp *CreateDocumentsItemProcessor) PreProcess(
 	_ func(valuehash.Hash, ...state.State) error,
 ) error {
 
+	if isLegacyCreateDocumentsItem(opp.item) {
+		return errors.Errorf("legacy item hint, %v is not allowed for new operation", opp.item.Hint())
+	}
+
 	if err := opp.item.IsValid(nil); err != nil {
 		return err
 	}
 
 	// check existence of new document state with documentid and get document state
-	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.item.DocumentId()))); {
+	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.documentid))); {
 	case err != nil:
 		return err
 	case found:
-		return errors.Errorf("documentid already registered, %q", opp.item.DocumentId())
+		return errors.Errorf("documentid already registered, %q", opp.documentid)
 	default:
 		opp.nds = st
 	}
 
+	// check expiry is over current height
+	if ex := opp.item.Expiry(); !ex.IsEmpty() && ex <= opp.height {
+		return errors.Errorf("expiry height, %v is not over current height, %v", ex, opp.height)
+	}
+
 	// check duplicated signers address
 	msigners := map[string]bool{}
 	for i := range opp.item.Signers() {
@@ -57,10 +71,23 @@ func (opp *CreateDocumentsItemProcessor) PreProcess(
 
 	// prepare doccInfo
 	opp.docInfo = DocInfo{
-		idx:      opp.item.DocumentId(),
+		idx:      opp.documentid,
 		filehash: opp.item.FileHash(),
 	}
 
+	// check file hash index of files
+	files := opp.item.Files()
+	opp.nfhs = make([]state.State, len(files))
+	opp.fhinvs = make([]DocumentInventory, len(files))
+	for i := range files {
+		st, fhinv, err := loadFileHashIndex(files[i].FileHash(), opp.documentid, opp.policy.UniqueFileHash(), getState)
+		if err != nil {
+			return err
+		}
+		opp.nfhs[i] = st
+		opp.fhinvs[i] = fhinv
+	}
+
 	// check sigenrs account existence
 	signers := opp.item.Signers()
 	for i := range signers {
@@ -88,16 +115,35 @@ func (opp *CreateDocumentsItemProcessor) Process(
 	// prepare signers from items
 	signers := make([]DocSign, len(opp.item.Signers()))
 	for i := range opp.item.Signers() {
-		signers[i] = NewDocSign(opp.item.Signers()[i], opp.item.Signcodes()[i], false)
+		// NOTE the commitment of signcode is recorded, signer submits signcode
+		signcode := SignCode(opp.item.Signcodes()[i]).Commitment()
+		signers[i] = NewDocSign(opp.item.Signers()[i], signcode.String(), false)
+		if opp.item.Threshold() > 0 {
+			signers[i] = signers[i].WithWeight(opp.item.Weights()[i])
+		}
 	}
 
 	// prepare document data
 	docData := DocumentData{
-		info:    opp.docInfo,
-		creator: DocSign{address: opp.sender, signcode: opp.item.Signcode(), signed: true},
-		title:   opp.item.Title(),
-		size:    opp.item.Size(),
-		signers: signers,
+		info:      opp.docInfo,
+		creator:   NewDocSignWithStatus(opp.sender, opp.item.Signcode(), DocSignSigned, opp.height),
+		title:     opp.item.Title(),
+		size:      opp.item.Size(),
+		signers:   signers,
+		expiry:    opp.item.Expiry(),
+		mode:      opp.item.SigningMode(),
+		threshold: opp.item.Threshold(),
+		canceled:  base.NilHeight,
+		metadata:  opp.item.Metadata().Copy(),
+	}
+
+	if files := opp.item.Files(); len(files) > 1 {
+		docData.files = files
+	}
+
+	if opp.escrow.OverZero() {
+		docData.escrow = currency.NewAmount(opp.escrow, opp.item.Currency())
+		docData.payer = opp.sender
 	}
 
 	// return document data state
@@ -107,17 +153,32 @@ func (opp *CreateDocumentsItemProcessor) Process(
 		sts[0] = dst
 	}
 
+	// return file hash index states
+	for i := range opp.nfhs {
+		if fhs, err := appendFileHashIndex(opp.nfhs[i], opp.fhinvs[i], opp.docInfo); err != nil {
+			return nil, err
+		} else {
+			sts = append(sts, fhs)
+		}
+	}
+
 	return sts, nil
 }
 
 type CreateDocumentsProcessor struct {
-	cp *currency.CurrencyPool
+	cp     *currency.CurrencyPool
+	height base.Height    // height of block, which documents are created in
+	policy DocumentPolicy // document policy of block
 	CreateDocuments
-	dinv     DocumentInventory
-	ndinvs   state.State
+	docs     *documentPages                               // document inventory pages of sender
+	lastid   currency.Big                                 // last document id after items are assigned
+	assigned []currency.Big                               // document ids, assigned to the omitted
+	nlids    state.State                                  // last document id state
 	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
 	ns       []*CreateDocumentsItemProcessor              // ItemProcessor
 	required map[currency.CurrencyID][2]currency.Big      // Fee
+	escrows  []currency.Big                               // escrow of items
+	pending  *documentPages                               // pending document inventory pages of signers
 }
 
 func NewCreateDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
@@ -144,20 +205,10 @@ func (opp *CreateDocumentsProcessor) PreProcess(
 		return nil, err
 	}
 
-	// check existence of document inventory state with address and get document inventory state
-	switch st, found, err := getState(StateKeyDocuments(fact.sender)); {
-	case err != nil:
+	// move the documents of legacy document inventory to the pages
+	docs := newOwnerDocumentPages()
+	if err := docs.migrate(fact.sender, getState); err != nil {
 		return nil, err
-	case !found:
-		opp.dinv = NewDocumentInventory(nil)
-		opp.ndinvs = st
-	default:
-		dinv, err := StateDocumentsValue(st)
-		if err != nil {
-			return nil, err
-		}
-		opp.dinv = dinv
-		opp.ndinvs = st
 	}
 
 	// prepare sender balance state
@@ -170,15 +221,69 @@ func (opp *CreateDocumentsProcessor) PreProcess(
 		opp.sb = sb
 	}
 
+	// get last document id state
+	switch st, found, err := getState(StateKeyLastDocumentId); {
+	case err != nil:
+		return nil, err
+	case !found:
+		opp.lastid = currency.NilBig
+		opp.nlids = st
+	default:
+		lastid, err := StateLastDocumentIdValue(st)
+		if err != nil {
+			return nil, err
+		}
+		opp.lastid = lastid.Index()
+		opp.nlids = st
+	}
+
+	// the explicit document ids of items are not assigned to the omitted
+	used := map[string]bool{}
+	for i := range fact.items {
+		if !fact.items[i].IsDocumentIdOmitted() {
+			used[fact.items[i].DocumentId().String()] = true
+		}
+	}
+
 	// prepare item processor for each items
+	pending := newPendingDocumentPages()
 	ns := make([]*CreateDocumentsItemProcessor, len(fact.items))
 	for i := range fact.items {
+		documentid, err := opp.assignDocumentId(fact.items[i], used, getState)
+		if err != nil {
+			return nil, operation.NewBaseReasonErrorFromError(err)
+		}
 
-		c := &CreateDocumentsItemProcessor{cp: opp.cp, sender: fact.sender, h: opp.Hash(), item: fact.items[i]}
+		c := &CreateDocumentsItemProcessor{
+			cp:         opp.cp,
+			policy:     opp.policy,
+			sender:     fact.sender,
+			height:     opp.height,
+			h:          opp.Hash(),
+			item:       fact.items[i],
+			documentid: documentid,
+			escrow:     opp.escrows[i],
+		}
 		if err := c.PreProcess(getState, setState); err != nil {
 			return nil, operation.NewBaseReasonErrorFromError(err)
 		}
 		ns[i] = c
+
+		if err := docs.load(fact.sender, documentid, getState); err != nil {
+			return nil, err
+		}
+
+		for j := range fact.items[i].Signers() {
+			if err := pending.load(fact.items[i].Signers()[j], documentid, getState); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if st, err := SetStateLastDocumentIdValue(opp.nlids, DocId(opp.lastid)); err != nil {
+		return nil, err
+	} else {
+		opp.nlids = st
 	}
 
 	// check fact sign
@@ -187,10 +292,24 @@ func (opp *CreateDocumentsProcessor) PreProcess(
 	}
 
 	opp.ns = ns
+	opp.docs = docs
+	opp.pending = pending
 
 	return opp, nil
 }
 
+// lockedKeys returns the pending document inventory keys of signers and the
+// keys of assigned document ids; the explicit document ids of the other
+// operations in same proposal can not take them.
+func (opp *CreateDocumentsProcessor) lockedKeys() []string {
+	keys := opp.pending.keys()
+	for i := range opp.assigned {
+		keys = append(keys, StateKeyDocumentData(DocId(opp.assigned[i])))
+	}
+
+	return keys
+}
+
 func (opp *CreateDocumentsProcessor) Process( // nolint:dupl
 	getState func(key string) (state.State, bool, error),
 	setState func(valuehash.Hash, ...state.State) error,
@@ -211,21 +330,41 @@ func (opp *CreateDocumentsProcessor) Process( // nolint:dupl
 				return err
 			}
 
-			if err := opp.dinv.Append(doc.Info()); err != nil {
+			if err := opp.docs.append(fact.sender, doc.Info()); err != nil {
 				return err
 			}
+
+			// add document to pending document inventory of signers
+			for j := range doc.Signers() {
+				signer := doc.Signers()[j].Address()
+				if opp.pending.exists(signer, doc.Info().Index()) {
+					continue
+				}
+
+				if err := opp.pending.append(signer, doc.Info()); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
-	opp.dinv.Sort(true)
+	// append document inventory states of sender
+	if dts, err := opp.docs.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, dts...)
+	}
 
-	// prepare document inventory state and append it
-	if dinvs, err := SetStateDocumentsValue(opp.ndinvs, opp.dinv); err != nil {
+	// append pending document inventory states of signers
+	if pts, err := opp.pending.states(); err != nil {
 		return err
 	} else {
-		sts = append(sts, dinvs)
+		sts = append(sts, pts...)
 	}
 
+	// append last document id state
+	sts = append(sts, opp.nlids)
+
 	// append sender balance state
 	for k := range opp.required {
 		rq := opp.required[k]
@@ -235,6 +374,53 @@ func (opp *CreateDocumentsProcessor) Process( // nolint:dupl
 	return setState(fact.Hash(), sts...)
 }
 
+// assignDocumentId returns the document id of item. The explicit document id
+// is used as it is. The omitted document id is assigned next to the last
+// document id from 1; the document ids, which are already registered or are
+// used by the other items of same fact, are skipped.
+func (opp *CreateDocumentsProcessor) assignDocumentId(
+	item CreateDocumentsItem,
+	used map[string]bool,
+	getState func(key string) (state.State, bool, error),
+) (currency.Big, error) {
+	if !item.IsDocumentIdOmitted() {
+		if item.DocumentId().Compare(opp.lastid) > 0 {
+			opp.lastid = item.DocumentId()
+		}
+
+		return item.DocumentId(), nil
+	}
+
+	id := opp.lastid.Add(currency.NewBig(1))
+	if !id.OverZero() {
+		id = currency.NewBig(1)
+	}
+
+	for {
+		if used[id.String()] {
+			id = id.Add(currency.NewBig(1))
+
+			continue
+		}
+
+		switch _, found, err := getState(StateKeyDocumentData(DocId(id))); {
+		case err != nil:
+			return currency.Big{}, err
+		case found:
+			id = id.Add(currency.NewBig(1))
+
+			continue
+		}
+
+		break
+	}
+
+	opp.lastid = id
+	opp.assigned = append(opp.assigned, id)
+
+	return id, nil
+}
+
 func (opp *CreateDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
 	fact := opp.Fact().(CreateDocumentsFact)
 
@@ -243,10 +429,52 @@ func (opp *CreateDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyI
 		items[i] = fact.items[i]
 	}
 
-	return CalculateDocumentItemsFee(opp.cp, items)
+	required, escrows, err := CalculateCreateDocumentsRequired(opp.cp, opp.policy, items)
+	if err != nil {
+		return nil, err
+	}
+	opp.escrows = escrows
+
+	return required, nil
+}
+
+// CalculateCreateDocumentsRequired returns the amounts, which are required to
+// sender balance, with the escrow of each item. The escrow of sponsored
+// document is subtracted from sender balance, but it is not fee.
+func CalculateCreateDocumentsRequired(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	items []CreateDocumentsItem,
+) (map[currency.CurrencyID][2]currency.Big, []currency.Big, error) {
+	required, err := CalculateDocumentItemsFee(cp, po, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	escrows := make([]currency.Big, len(items))
+	for i := range items {
+		escrow, err := CalculateSigningEscrow(cp, po, items[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		escrows[i] = escrow
+
+		if escrow.OverZero() {
+			rq := required[items[i].Currency()]
+			required[items[i].Currency()] = [2]currency.Big{rq[0].Add(escrow), rq[1]}
+		}
+	}
+
+	return required, escrows, nil
 }
 
-func CalculateDocumentItemsFee(cp *currency.CurrencyPool, items []CreateDocumentsItem) (map[currency.CurrencyID][2]currency.Big, error) {
+// CalculateDocumentItemsFee calculates the fee of create items by the
+// DocumentFeePolicy of DocumentPolicy.
+func CalculateDocumentItemsFee(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	items []CreateDocumentsItem,
+) (map[currency.CurrencyID][2]currency.Big, error) {
 	required := map[currency.CurrencyID][2]currency.Big{}
 
 	for i := range items {
@@ -268,7 +496,7 @@ func CalculateDocumentItemsFee(cp *currency.CurrencyPool, items []CreateDocument
 		if !found {
 			return nil, errors.Errorf("unknown currency id found, %q", it.Currency())
 		}
-		switch k, err := feeer.Fee(currency.ZeroBig); {
+		switch k, err := po.FeePolicy(it.Currency()).CreateFee(feeer, it); {
 		case err != nil:
 			return nil, err
 		case !k.OverZero():
@@ -82,8 +82,8 @@ func (t *testCreateDocumentsOperation) TestNormalCase() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	fh := FileHash("ABCD")
-	documentid := currency.NewBig(0)
+	fh := MustFileHash("ABCD")
+	documentid := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -125,7 +125,7 @@ func (t *testCreateDocumentsOperation) TestNormalCase() {
 			} else {
 				continue
 			}
-		} else if (IsStateDocumentsKey(stu.Key())) && (stu.Key() == StateKeyDocuments(sa.Address)) {
+		} else if (IsStateDocumentsPageKey(stu.Key())) && (stu.Key() == StateKeyDocumentsPage(sa.Address, currency.ZeroBig)) {
 			ns = stu.GetState()
 		} else if (IsStateDocumentDataKey(stu.Key())) && (stu.Key() == StateKeyDocumentData(DocId(documentid))) {
 			nds = stu.GetState()
@@ -143,10 +143,139 @@ func (t *testCreateDocumentsOperation) TestNormalCase() {
 	t.True(ndd.FileHash().Equal(fh))
 	t.True(ndd.Creator().Equal(sa.Address))
 
+	// the commitment of signcode is recorded instead of signcode
+	ds, found := ndd.Signer(sga.Address)
+	t.True(found)
+	t.True(SignCode(ds.Signcode()).IsCommitment())
+	t.True(SignCode(ds.Signcode()).Verify(signcode1))
+
 	ndinv, _ := StateDocumentsValue(ns)
 	t.True(ndinv.Documents()[0].FileHash().Equal(fh))
 }
 
+func (t *testCreateDocumentsOperation) TestExpiry() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa, st0 := t.newAccount(true, balance)
+	sga, st1 := t.newAccount(true, balance)
+
+	fee := currency.NewBig(1)
+	feeer := currency.NewFixedFeeer(sa.Address, fee)
+
+	documentid := currency.NewBig(1)
+
+	newItem := func(expiry base.Height) CreateDocumentsItem {
+		return NewCreateDocumentsItemSingleFile(
+			MustFileHash("ABCD"),
+			documentid,
+			"user0",
+			"title01",
+			currency.NewBig(555),
+			[]base.Address{sga.Address},
+			[]string{"user1"},
+			cid,
+		).WithExpiry(expiry)
+	}
+
+	{ // expiry is over current height
+		pool, _ := t.statepool(st0, st1)
+
+		cp := currency.NewCurrencyPool()
+		t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, feeer)))
+
+		opr := t.processor(cp, pool)
+
+		expiry := pool.Height() + 10
+		t.NoError(opr.Process(t.newOperation(sa.Address, []CreateDocumentsItem{newItem(expiry)}, sa.Privs())))
+
+		var nds state.State
+		for _, stu := range pool.Updates() {
+			if stu.Key() == StateKeyDocumentData(DocId(documentid)) {
+				nds = stu.GetState()
+			}
+		}
+		t.NotNil(nds)
+
+		ndd, err := StateDocumentDataValue(nds)
+		t.NoError(err)
+		t.Equal(expiry, ndd.Expiry())
+		t.Equal(DocumentDraft, ndd.StatusAt(expiry))
+		t.Equal(DocumentExpired, ndd.StatusAt(expiry+1))
+	}
+
+	{ // expiry is not over current height
+		pool, _ := t.statepool(st0, st1)
+
+		cp := currency.NewCurrencyPool()
+		t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, feeer)))
+
+		opr := t.processor(cp, pool)
+
+		err := opr.Process(t.newOperation(sa.Address, []CreateDocumentsItem{newItem(pool.Height())}, sa.Privs()))
+
+		var oper operation.ReasonError
+		t.True(xerrors.As(err, &oper))
+		t.Contains(err.Error(), "is not over current height")
+	}
+}
+
+func (t *testCreateDocumentsOperation) TestQuorum() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa, st0 := t.newAccount(true, balance)
+	sga0, st1 := t.newAccount(true, balance)
+	sga1, st2 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1, st2)
+
+	feeer := currency.NewFixedFeeer(sa.Address, currency.NewBig(1))
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, feeer)))
+
+	opr := t.processor(cp, pool)
+
+	documentid := currency.NewBig(1)
+	item := NewCreateDocumentsItemSingleFile(
+		MustFileHash("ABCD"),
+		documentid,
+		"user0",
+		"title01",
+		currency.NewBig(555),
+		[]base.Address{sga0.Address, sga1.Address},
+		[]string{"user1", "user2"},
+		cid,
+	).WithQuorum([]uint{2, 1}, 2)
+
+	t.NoError(opr.Process(t.newOperation(sa.Address, []CreateDocumentsItem{item}, sa.Privs())))
+
+	var nds state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentData(DocId(documentid)) {
+			nds = stu.GetState()
+		}
+	}
+	t.NotNil(nds)
+
+	ndd, err := StateDocumentDataValue(nds)
+	t.NoError(err)
+	t.Equal(uint(2), ndd.Threshold())
+	t.Equal(uint(2), ndd.Signers()[0].Weight())
+	t.Equal(uint(1), ndd.Signers()[1].Weight())
+
+	ndd, err = ndd.SetSignerStatus(sga0.Address, DocSignSigned, pool.Height())
+	t.NoError(err)
+	t.Equal(DocumentFullySigned, ndd.Status())
+}
+
 func (t *testCreateDocumentsOperation) TestSignerAccountsNotExist() {
 	cid := currency.CurrencyID("FINDME")
 	feeAmount := int64(1)
@@ -167,8 +296,8 @@ func (t *testCreateDocumentsOperation) TestSignerAccountsNotExist() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	fh := FileHash("ABCD")
-	documentid := currency.NewBig(0)
+	fh := MustFileHash("ABCD")
+	documentid := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -207,8 +336,8 @@ func (t *testCreateDocumentsOperation) TestDocumentAlreadyExists() {
 	sa0, st0 := t.newAccount(true, balance)
 	sa1, st1 := t.newAccount(true, balance)
 
-	filehash := FileHash("ABCD")
-	documentid := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid := currency.NewBig(1)
 	info := DocInfo{
 		idx:      documentid,
 		filehash: filehash,
@@ -252,6 +381,218 @@ func (t *testCreateDocumentsOperation) TestDocumentAlreadyExists() {
 	t.Contains(err.Error(), "documentid already registered")
 }
 
+func (t *testCreateDocumentsOperation) TestFileHashIndex() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+
+	filehash := MustFileHash("ABCD")
+	other := DocInfo{idx: currency.NewBig(3), filehash: filehash}
+
+	pool, _ := t.statepool(st0, st1, []state.State{t.newStateFileHash(filehash, []DocInfo{other})})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(filehash, currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{sa1.Address}, []string{"user1"}, cid),
+	}
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
+
+	var fhinv DocumentInventory
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyFileHash(filehash) {
+			i, err := StateFileHashValue(stu.GetState())
+			t.NoError(err)
+			fhinv = i
+		}
+	}
+
+	t.Equal(2, len(fhinv.Documents()))
+	t.True(fhinv.Exists(currency.NewBig(1)))
+	t.True(fhinv.Exists(currency.NewBig(3)))
+}
+
+func (t *testCreateDocumentsOperation) TestUniqueFileHash() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+
+	filehash := MustFileHash("ABCD")
+	other := DocInfo{idx: currency.NewBig(3), filehash: filehash}
+
+	pool, _ := t.statepool(st0, []state.State{
+		t.newStateFileHash(filehash, []DocInfo{other}),
+		t.newStateDocumentPolicy(NewDocumentPolicy(true)),
+	})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(filehash, currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	err := opr.Process(t.newOperation(sa0.Address, items, sa0.Privs()))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "filehash already registered")
+}
+
+func (t *testCreateDocumentsOperation) TestLegacyItem() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	single := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid)
+	single.hint = CreateDocumentsItemSingleFileLegacyHint
+
+	multi := NewCreateDocumentsItemMultiFiles(currency.NewBig(2), "user0", "title02", []DocFile{
+		NewDocFile(MustFileHash("EFGH"), "main.pdf", currency.NewBig(555)),
+		NewDocFile(MustFileHash("IJKL"), "attached.pdf", currency.NewBig(10)),
+	}, []base.Address{}, []string{}, cid)
+	multi.hint = CreateDocumentsItemMultiFilesLegacyHint
+
+	// NOTE legacy items are only for reading chain history
+	for _, item := range []CreateDocumentsItem{single, multi} {
+		err := opr.Process(t.newOperation(sa0.Address, []CreateDocumentsItem{item}, sa0.Privs()))
+
+		var oper operation.ReasonError
+		t.True(xerrors.As(err, &oper))
+		t.Contains(err.Error(), "legacy item hint")
+	}
+}
+
+func (t *testCreateDocumentsOperation) TestSameFileHashInProposal() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	filehash := MustFileHash("ABCD")
+	items0 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(filehash, "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	items1 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(filehash, "user1", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	_, err := opr.PreProcess(t.newOperation(sa0.Address, items0, sa0.Privs()))
+	t.NoError(err)
+
+	_, err = opr.PreProcess(t.newOperation(sa1.Address, items1, sa1.Privs()))
+	t.Error(err)
+	t.Contains(err.Error(), "already processed")
+}
+
+func (t *testCreateDocumentsOperation) TestSameSignerInProposal() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+	ga, stg := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1, stg)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items0 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{ga.Address}, []string{"user1"}, cid),
+	}
+	items1 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user0", "title02", currency.NewBig(555), []base.Address{ga.Address}, []string{"user1"}, cid),
+	}
+
+	_, err := opr.PreProcess(t.newOperation(sa0.Address, items0, sa0.Privs()))
+	t.NoError(err)
+
+	// NOTE the pending document inventory page of signer is locked by the
+	// previous operation
+	_, err = opr.PreProcess(t.newOperation(sa1.Address, items1, sa1.Privs()))
+	t.Error(err)
+	t.Contains(err.Error(), StateKeyPendingDocuments(ga.Address, currency.ZeroBig))
+	t.Contains(err.Error(), "already processed")
+}
+
+func (t *testCreateDocumentsOperation) TestMetadata() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	md := DocumentMetadata{MetadataKeyDepartment: "legal", MetadataKeyTags: "hr,contract"}
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid).
+			WithMetadata(md),
+	}
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
+
+	var dd DocumentData
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentData(NewDocId(1)) {
+			i, err := StateDocumentDataValue(stu.GetState())
+			t.NoError(err)
+			dd = i
+		}
+	}
+
+	t.True(md.Equal(dd.Metadata()))
+	t.Equal([]string{"hr", "contract"}, dd.Metadata().Tags())
+}
+
 func (t *testCreateDocumentsOperation) TestSameSenders() {
 	cid := currency.CurrencyID("FINDME")
 	feeAmount := int64(1)
@@ -273,9 +614,9 @@ func (t *testCreateDocumentsOperation) TestSameSenders() {
 
 	opr := t.processor(cp, pool)
 
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
-	documentid1 := currency.NewBig(1)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
+	documentid1 := currency.NewBig(2)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -336,9 +677,9 @@ func (t *testCreateDocumentsOperation) TestSameSendersWithInvalidOperation() {
 
 	opr := t.processor(cp, pool)
 
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
-	documentid1 := currency.NewBig(1)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
+	documentid1 := currency.NewBig(2)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -421,8 +762,8 @@ func (t *testCreateDocumentsOperation) TestSignerSameWithOwner() {
 
 	opr := t.processor(cp, pool)
 
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -469,8 +810,8 @@ func (t *testCreateDocumentsOperation) TestDuplicatedSigner() {
 
 	opr := t.processor(cp, pool)
 
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -497,73 +838,289 @@ func (t *testCreateDocumentsOperation) TestDuplicatedSigner() {
 	t.Contains(err.Error(), "duplicated signer")
 }
 
-func (t *testCreateDocumentsOperation) TestMultipleItemsWithFee() {
-	cid0 := currency.CurrencyID("SHOWME")
-	cid1 := currency.CurrencyID("FINDME")
+func (t *testCreateDocumentsOperation) TestMultiFiles() {
+	cid := currency.CurrencyID("SHOWME")
 
 	balance := []currency.Amount{
-		currency.NewAmount(currency.NewBig(33), cid0),
-		currency.NewAmount(currency.NewBig(33), cid1),
+		currency.NewAmount(currency.NewBig(33), cid),
 	}
 
-	sa, st := t.newAccount(true, balance)
+	sa0, st0 := t.newAccount(true, balance)
 
-	pool, _ := t.statepool(st)
+	pool, _ := t.statepool(st0)
 
-	fee0 := currency.NewBig(1)
-	fee1 := currency.NewBig(2)
-	feeer0 := currency.NewFixedFeeer(sa.Address, fee0)
-	feeer1 := currency.NewFixedFeeer(sa.Address, fee1)
+	fee := currency.NewBig(2)
 
 	cp := currency.NewCurrencyPool()
-	t.NoError(cp.Set(t.newCurrencyDesignState(cid0, currency.NewBig(99), sa.Address, feeer0)))
-	t.NoError(cp.Set(t.newCurrencyDesignState(cid1, currency.NewBig(99), sa.Address, feeer1)))
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, fee))))
 
 	opr := t.processor(cp, pool)
 
-	filehash0 := FileHash("ABCD")
-	filehash1 := FileHash("EFGH")
-	documentid0 := currency.NewBig(0)
-	documentid1 := currency.NewBig(1)
-	signcode0 := "user0"
-	title := "title01"
-	size := currency.NewBig(555)
+	files := []DocFile{
+		NewDocFile(MustFileHash("ABCD"), "main.pdf", currency.NewBig(555)),
+		NewDocFile(MustFileHash("EFGH"), "appendix-a.pdf", currency.NewBig(10)),
+		NewDocFile(MustFileHash("IJKL"), "appendix-b.pdf", currency.NewBig(20)),
+	}
 
 	items := []CreateDocumentsItem{
-		NewCreateDocumentsItemSingleFile(
-			filehash0,
-			documentid0,
-			signcode0,
-			title,
-			size,
-			[]base.Address{},
-			[]string{},
-			cid0,
-		),
-		NewCreateDocumentsItemSingleFile(
-			filehash1,
-			documentid1,
-			signcode0,
-			title,
-			size,
-			[]base.Address{},
-			[]string{},
-			cid1,
-		),
+		NewCreateDocumentsItemMultiFiles(currency.NewBig(1), "user0", "title01", files, []base.Address{}, []string{}, cid),
 	}
-	cd := t.newOperation(sa.Address, items, sa.Privs())
-
-	t.NoError(opr.Process(cd))
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
 
-	// check updated state
-	// new documents state
-	var ns state.State
-	// new document data state
-	var dds0, dds1 state.State
-	// sender balance state
-	sb := map[currency.CurrencyID]state.State{}
+	var dd DocumentData
+	var sb state.State
+	fhinvs := map[string]DocumentInventory{}
 	for _, stu := range pool.Updates() {
-		if currency.IsStateBalanceKey(stu.Key()) {
+		switch {
+		case stu.Key() == StateKeyDocumentData(NewDocId(1)):
+			i, err := StateDocumentDataValue(stu.GetState())
+			t.NoError(err)
+			dd = i
+		case stu.Key() == currency.StateKeyBalance(sa0.Address, cid):
+			sb = stu.GetState()
+		case IsStateFileHashKey(stu.Key()):
+			i, err := StateFileHashValue(stu.GetState())
+			t.NoError(err)
+			fhinvs[stu.Key()] = i
+		}
+	}
+
+	t.True(dd.FileHash().Equal(files[0].FileHash()))
+	t.True(dd.Size().Equal(currency.NewBig(585)))
+	t.Equal(len(files), len(dd.Files()))
+	for i := range files {
+		t.True(files[i].Equal(dd.Files()[i]))
+
+		fhinv, found := fhinvs[StateKeyFileHash(files[i].FileHash())]
+		t.True(found)
+		t.True(fhinv.Exists(currency.NewBig(1)))
+	}
+
+	// NOTE fee is charged per file
+	t.Equal(fee.MulInt64(int64(len(files))), sb.(currency.AmountState).Fee())
+}
+
+func (t *testCreateDocumentsOperation) TestFeePolicy() {
+	cid := currency.CurrencyID("SHOWME")
+
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(cid, NewDocumentFeePolicy(currency.NewBig(100), currency.NewBig(2))))
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+	sa2, st2 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1, st2, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(sa0.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{sa1.Address, sa2.Address}, []string{"user1", "user2"}, cid),
+	}
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
+
+	var sb state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == currency.StateKeyBalance(sa0.Address, cid) {
+			sb = stu.GetState()
+		}
+	}
+
+	// NOTE 6 units of size and 2 signers
+	t.Equal(currency.NewBig(10), sb.(currency.AmountState).Fee())
+}
+
+func (t *testCreateDocumentsOperation) TestSponsored() {
+	cid := currency.CurrencyID("SHOWME")
+
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(cid, NewDocumentFeePolicy(currency.NewBig(100), currency.NewBig(2))))
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, nil)
+	sa2, st2 := t.newAccount(true, nil)
+
+	pool, _ := t.statepool(st0, st1, st2, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(sa0.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{sa1.Address, sa2.Address}, []string{"user1", "user2"}, cid).
+			WithSponsored(true),
+	}
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
+
+	var sb, dds state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case currency.StateKeyBalance(sa0.Address, cid):
+			sb = stu.GetState()
+		case StateKeyDocumentData(DocId(currency.NewBig(1))):
+			dds = stu.GetState()
+		}
+	}
+
+	// NOTE escrow is 6 units of size for each signer; it is not fee
+	t.Equal(currency.NewBig(10), sb.(currency.AmountState).Fee())
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(currency.NewBig(33 - 10 - 12)))
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+	t.True(ndd.HasEscrow())
+	t.True(ndd.Escrow().Equal(currency.NewAmount(currency.NewBig(12), cid)))
+}
+
+func (t *testCreateDocumentsOperation) TestInsufficientBalanceForEscrow() {
+	cid := currency.CurrencyID("SHOWME")
+
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(cid, NewDocumentFeePolicy(currency.NewBig(100), currency.ZeroBig)))
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(10), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, nil)
+
+	pool, _ := t.statepool(st0, st1, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(sa0.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, feeer)))
+
+	opr := t.processor(cp, pool)
+
+	// NOTE fee 6 and escrow 6 are over balance
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{sa1.Address}, []string{"user1"}, cid).
+			WithSponsored(true),
+	}
+
+	err := opr.Process(t.newOperation(sa0.Address, items, sa0.Privs()))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "insufficient balance")
+}
+
+func (t *testCreateDocumentsOperation) TestInsufficientBalanceForFeePolicy() {
+	cid := currency.CurrencyID("SHOWME")
+
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(cid, NewDocumentFeePolicy(currency.NewBig(10), currency.ZeroBig)))
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(sa0.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, feeer)))
+
+	opr := t.processor(cp, pool)
+
+	// NOTE 56 units of size is over balance
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	err := opr.Process(t.newOperation(sa0.Address, items, sa0.Privs()))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "insufficient balance")
+}
+
+func (t *testCreateDocumentsOperation) TestMultipleItemsWithFee() {
+	cid0 := currency.CurrencyID("SHOWME")
+	cid1 := currency.CurrencyID("FINDME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid0),
+		currency.NewAmount(currency.NewBig(33), cid1),
+	}
+
+	sa, st := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st)
+
+	fee0 := currency.NewBig(1)
+	fee1 := currency.NewBig(2)
+	feeer0 := currency.NewFixedFeeer(sa.Address, fee0)
+	feeer1 := currency.NewFixedFeeer(sa.Address, fee1)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid0, currency.NewBig(99), sa.Address, feeer0)))
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid1, currency.NewBig(99), sa.Address, feeer1)))
+
+	opr := t.processor(cp, pool)
+
+	filehash0 := MustFileHash("ABCD")
+	filehash1 := MustFileHash("EFGH")
+	documentid0 := currency.NewBig(1)
+	documentid1 := currency.NewBig(2)
+	signcode0 := "user0"
+	title := "title01"
+	size := currency.NewBig(555)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(
+			filehash0,
+			documentid0,
+			signcode0,
+			title,
+			size,
+			[]base.Address{},
+			[]string{},
+			cid0,
+		),
+		NewCreateDocumentsItemSingleFile(
+			filehash1,
+			documentid1,
+			signcode0,
+			title,
+			size,
+			[]base.Address{},
+			[]string{},
+			cid1,
+		),
+	}
+	cd := t.newOperation(sa.Address, items, sa.Privs())
+
+	t.NoError(opr.Process(cd))
+
+	// check updated state
+	// new documents state
+	var ns state.State
+	// new document data state
+	var dds0, dds1 state.State
+	// sender balance state
+	sb := map[currency.CurrencyID]state.State{}
+	for _, stu := range pool.Updates() {
+		if currency.IsStateBalanceKey(stu.Key()) {
 			st := stu.GetState()
 
 			i, err := currency.StateBalanceValue(st)
@@ -574,8 +1131,8 @@ func (t *testCreateDocumentsOperation) TestMultipleItemsWithFee() {
 			} else {
 				continue
 			}
-		} else if IsStateDocumentsKey(stu.Key()) {
-			if stu.Key() == StateKeyDocuments(sa.Address) {
+		} else if IsStateDocumentsPageKey(stu.Key()) {
+			if stu.Key() == StateKeyDocumentsPage(sa.Address, currency.ZeroBig) {
 				ns = stu.GetState()
 			}
 		} else if IsStateDocumentDataKey(stu.Key()) {
@@ -639,8 +1196,8 @@ func (t *testCreateDocumentsOperation) TestInSufficientBalanceForFee() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -692,8 +1249,8 @@ func (t *testCreateDocumentsOperation) TestUnknownCurrencyID() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -741,8 +1298,8 @@ func (t *testCreateDocumentsOperation) TestEmptyCurrency() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -787,8 +1344,8 @@ func (t *testCreateDocumentsOperation) TestSenderBalanceNotExist() {
 	opr := t.processor(cp, pool)
 
 	// filedata
-	filehash := FileHash("ABCD")
-	documentid0 := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid0 := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -816,6 +1373,376 @@ func (t *testCreateDocumentsOperation) TestSenderBalanceNotExist() {
 	t.Contains(err.Error(), "currency of holder does not exist")
 }
 
+func (t *testCreateDocumentsOperation) TestAssignDocumentId() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa, st0 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, []state.State{t.newStateLastDocumentId(currency.NewBig(3))})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, currency.NewFixedFeeer(sa.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	cd := t.newOperation(sa.Address, items, sa.Privs())
+
+	t.NoError(opr.Process(cd))
+
+	var ns, nlids state.State
+	dds := map[string]state.State{}
+	for _, stu := range pool.Updates() {
+		switch {
+		case stu.Key() == StateKeyDocumentsPage(sa.Address, currency.ZeroBig):
+			ns = stu.GetState()
+		case stu.Key() == StateKeyLastDocumentId:
+			nlids = stu.GetState()
+		case IsStateDocumentDataKey(stu.Key()):
+			dds[stu.Key()] = stu.GetState()
+		}
+	}
+
+	t.Equal(2, len(dds))
+
+	ndd0, err := StateDocumentDataValue(dds[StateKeyDocumentData(NewDocId(4))])
+	t.NoError(err)
+	t.True(ndd0.FileHash().Equal(MustFileHash("ABCD")))
+
+	ndd1, err := StateDocumentDataValue(dds[StateKeyDocumentData(NewDocId(5))])
+	t.NoError(err)
+	t.True(ndd1.FileHash().Equal(MustFileHash("EFGH")))
+
+	ndinv, _ := StateDocumentsValue(ns)
+	t.True(ndinv.Exists(currency.NewBig(4)))
+	t.True(ndinv.Exists(currency.NewBig(5)))
+
+	lastid, err := StateLastDocumentIdValue(nlids)
+	t.NoError(err)
+	t.Equal(currency.NewBig(5), lastid.Index())
+}
+
+func (t *testCreateDocumentsOperation) TestAssignDocumentIdSkipRegistered() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+
+	// document, registered without last document id state
+	doc := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("ABCD")}, sa1.Address, "user0", "title01", currency.NewBig(555), []DocSign{})
+
+	pool, _ := t.statepool(st0, st1, []state.State{t.newStateDocumentData(doc)})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	t.NoError(opr.Process(t.newOperation(sa0.Address, items, sa0.Privs())))
+
+	var found bool
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentData(NewDocId(2)) {
+			found = true
+		}
+	}
+	t.True(found)
+}
+
+func (t *testCreateDocumentsOperation) TestAssignDocumentIdInProposal() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items0 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	items1 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user1", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	// NOTE PreProcess all the operations before Process like proposal processor
+	pr0, err := opr.PreProcess(t.newOperation(sa0.Address, items0, sa0.Privs()))
+	t.NoError(err)
+	pr1, err := opr.PreProcess(t.newOperation(sa1.Address, items1, sa1.Privs()))
+	t.NoError(err)
+
+	t.NoError(opr.Process(pr1))
+	t.NoError(opr.Process(pr0))
+
+	var nlids state.State
+	dds := map[string]state.State{}
+	for _, stu := range pool.Updates() {
+		switch {
+		case stu.Key() == StateKeyLastDocumentId:
+			nlids = stu.GetState()
+		case IsStateDocumentDataKey(stu.Key()):
+			dds[stu.Key()] = stu.GetState()
+		}
+	}
+
+	ndd0, err := StateDocumentDataValue(dds[StateKeyDocumentData(NewDocId(1))])
+	t.NoError(err)
+	t.True(ndd0.Creator().Equal(sa0.Address))
+
+	ndd1, err := StateDocumentDataValue(dds[StateKeyDocumentData(NewDocId(2))])
+	t.NoError(err)
+	t.True(ndd1.Creator().Equal(sa1.Address))
+
+	lastid, err := StateLastDocumentIdValue(nlids)
+	t.NoError(err)
+	t.Equal(currency.NewBig(2), lastid.Index())
+}
+
+func (t *testCreateDocumentsOperation) TestExplicitDocumentIdUnderLast() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa, st0 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, []state.State{t.newStateLastDocumentId(currency.NewBig(3))})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, currency.NewFixedFeeer(sa.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	// NOTE the free explicit document ids are accepted in any order; the
+	// omitted document id skips the explicit ids of same fact
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(2), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+		NewCreateDocumentsItemSingleFile(MustFileHash("IJKL"), currency.NewBig(4), "user0", "title03", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	t.NoError(opr.Process(t.newOperation(sa.Address, items, sa.Privs())))
+
+	var nlids state.State
+	dds := map[string]state.State{}
+	for _, stu := range pool.Updates() {
+		switch {
+		case stu.Key() == StateKeyLastDocumentId:
+			nlids = stu.GetState()
+		case IsStateDocumentDataKey(stu.Key()):
+			dds[stu.Key()] = stu.GetState()
+		}
+	}
+
+	t.Equal(3, len(dds))
+	for id, fh := range map[int64]FileHash{2: MustFileHash("ABCD"), 5: MustFileHash("EFGH"), 4: MustFileHash("IJKL")} {
+		ndd, err := StateDocumentDataValue(dds[StateKeyDocumentData(NewDocId(id))])
+		t.NoError(err)
+		t.True(ndd.FileHash().Equal(fh))
+	}
+
+	lastid, err := StateLastDocumentIdValue(nlids)
+	t.NoError(err)
+	t.Equal(currency.NewBig(5), lastid.Index())
+}
+
+func (t *testCreateDocumentsOperation) TestExplicitDocumentIdRegistered() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa, st0 := t.newAccount(true, balance)
+
+	doc := NewDocumentData(MustNewDocInfo(2, MustFileHash("ABCD")), sa.Address, "user0", "title01", currency.NewBig(555), []DocSign{})
+	pool, _ := t.statepool(st0, []state.State{t.newStateDocumentData(doc), t.newStateLastDocumentId(currency.NewBig(3))})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, currency.NewFixedFeeer(sa.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("EFGH"), currency.NewBig(2), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	err := opr.Process(t.newOperation(sa.Address, items, sa.Privs()))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "documentid already registered")
+	t.Empty(pool.Updates())
+}
+
+func (t *testCreateDocumentsOperation) TestExplicitDocumentIdAssignedInProposal() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+	sa1, st1 := t.newAccount(true, balance)
+
+	pool, _ := t.statepool(st0, st1)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	items0 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	items1 := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("EFGH"), currency.NewBig(1), "user1", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	_, err := opr.PreProcess(t.newOperation(sa0.Address, items0, sa0.Privs()))
+	t.NoError(err)
+
+	// NOTE document id 1 is already assigned to the previous operation
+	_, err = opr.PreProcess(t.newOperation(sa1.Address, items1, sa1.Privs()))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "already processed")
+}
+
+func (t *testCreateDocumentsOperation) TestZeroDocumentId() {
+	cid := currency.CurrencyID("SHOWME")
+
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.ZeroBig, "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "documentid is negative number")
+}
+
+func (t *testCreateDocumentsOperation) TestPendingDocuments() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), cid)}
+
+	sa, st0 := t.newAccount(true, balance)
+	sga, st1 := t.newAccount(true, balance)
+	sgb, st2 := t.newAccount(true, balance)
+
+	// sgb already has the pending document
+	old := MustNewDocInfo(9, MustFileHash("IJKL"))
+	pool, _ := t.statepool(st0, st1, st2, []state.State{t.newStatePendingDocuments(sgb.Address, currency.ZeroBig, []DocInfo{old})})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, currency.NewNilFeeer())))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(
+			MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555),
+			[]base.Address{sga.Address, sgb.Address}, []string{"user1", "user2"}, cid,
+		),
+		NewCreateDocumentsItemSingleFile(
+			MustFileHash("EFGH"), currency.NewBig(2), "user0", "title02", currency.NewBig(555),
+			[]base.Address{sga.Address}, []string{"user1"}, cid,
+		),
+	}
+	t.NoError(opr.Process(t.newOperation(sa.Address, items, sa.Privs())))
+
+	pending := map[string]DocumentInventory{}
+	for _, stu := range pool.Updates() {
+		if IsStatePendingDocumentsKey(stu.Key()) {
+			inv, err := StatePendingDocumentsValue(stu.GetState())
+			t.NoError(err)
+
+			pending[stu.Key()] = inv
+		}
+	}
+
+	// NOTE creator has no pending documents
+	t.Equal(2, len(pending))
+
+	inva := pending[StateKeyPendingDocuments(sga.Address, currency.ZeroBig)]
+	t.Equal(2, len(inva.Documents()))
+	t.True(inva.Exists(currency.NewBig(1)))
+	t.True(inva.Exists(currency.NewBig(2)))
+
+	invb := pending[StateKeyPendingDocuments(sgb.Address, currency.ZeroBig)]
+	t.Equal(2, len(invb.Documents()))
+	t.True(invb.Documents()[0].Index().Equal(currency.NewBig(1)))
+	t.True(invb.Documents()[1].Equal(old))
+}
+
+func (t *testCreateDocumentsOperation) TestMigrateLegacyDocuments() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), cid)}
+
+	sa, st0 := t.newAccount(true, balance)
+
+	// sender has the legacy document inventory
+	old := []DocInfo{MustNewDocInfo(1, MustFileHash("IJKL")), MustNewDocInfo(120, MustFileHash("MNOP"))}
+	pool, _ := t.statepool(st0, []state.State{
+		t.newStateLegacyDocuments(sa.Address, old),
+		t.newStateLastDocumentId(currency.NewBig(120)),
+	})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa.Address, currency.NewNilFeeer())))
+
+	opr := t.processor(cp, pool)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	t.NoError(opr.Process(t.newOperation(sa.Address, items, sa.Privs())))
+
+	invs := map[string]DocumentInventory{}
+	for _, stu := range pool.Updates() {
+		if IsStateDocumentsKey(stu.Key()) || IsStateDocumentsPageKey(stu.Key()) {
+			inv, err := StateDocumentsValue(stu.GetState())
+			t.NoError(err)
+
+			invs[stu.Key()] = inv
+		}
+	}
+	t.Equal(3, len(invs))
+
+	t.True(invs[StateKeyDocuments(sa.Address)].IsEmpty())
+
+	inv0 := invs[StateKeyDocumentsPage(sa.Address, currency.ZeroBig)]
+	t.Equal(1, len(inv0.Documents()))
+	t.True(inv0.Exists(currency.NewBig(1)))
+
+	inv1 := invs[StateKeyDocumentsPage(sa.Address, currency.NewBig(1))]
+	t.Equal(2, len(inv1.Documents()))
+	t.True(inv1.Exists(currency.NewBig(120)))
+	t.True(inv1.Exists(currency.NewBig(121)))
+}
+
 func TestCreateDocumentsOperation(t *testing.T) {
 	suite.Run(t, new(testCreateDocumentsOperation))
 }
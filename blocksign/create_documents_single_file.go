@@ -8,8 +8,11 @@ import (
 
 var (
 	CreateDocumentsItemSingleFileType   = hint.Type("mitum-blocksign-create-documents-single-file")
-	CreateDocumentsItemSingleFileHint   = hint.NewHint(CreateDocumentsItemSingleFileType, "v0.0.1")
+	CreateDocumentsItemSingleFileHint   = hint.NewHint(CreateDocumentsItemSingleFileType, "v0.0.2")
 	CreateDocumentsItemSingleFileHinter = BaseCreateDocumentsItem{hint: CreateDocumentsItemSingleFileHint}
+	// CreateDocumentsItemSingleFileLegacyHint is the hint of item before the
+	// canonical encoding.
+	CreateDocumentsItemSingleFileLegacyHint = hint.NewHint(CreateDocumentsItemSingleFileType, "v0.0.1")
 )
 
 type CreateDocumentsItemSingleFile struct {
@@ -40,6 +43,56 @@ func NewCreateDocumentsItemSingleFile(
 	}
 }
 
+// NewCreateDocumentsItemSingleFileWithoutId makes item, whose document id will
+// be assigned by the chain.
+func NewCreateDocumentsItemSingleFileWithoutId(
+	fh FileHash,
+	signcode, title string,
+	size currency.Big,
+	signers []base.Address,
+	signcodes []string,
+	cid currency.CurrencyID,
+) CreateDocumentsItemSingleFile {
+	return NewCreateDocumentsItemSingleFile(fh, currency.NilBig, signcode, title, size, signers, signcodes, cid)
+}
+
+// WithExpiry sets the height, after which the document can not be signed.
+func (it CreateDocumentsItemSingleFile) WithExpiry(height base.Height) CreateDocumentsItemSingleFile {
+	it.expiry = height
+
+	return it
+}
+
+// WithSigningMode sets the signing mode of document.
+func (it CreateDocumentsItemSingleFile) WithSigningMode(mode SigningMode) CreateDocumentsItemSingleFile {
+	it.mode = mode
+
+	return it
+}
+
+// WithQuorum sets the weights of signers and threshold; the document is fully
+// signed when the sum of signed weights passes threshold.
+func (it CreateDocumentsItemSingleFile) WithQuorum(weights []uint, threshold uint) CreateDocumentsItemSingleFile {
+	it.weights = weights
+	it.threshold = threshold
+
+	return it
+}
+
+// WithMetadata sets the metadata of document.
+func (it CreateDocumentsItemSingleFile) WithMetadata(md DocumentMetadata) CreateDocumentsItemSingleFile {
+	it.metadata = md.Copy()
+
+	return it
+}
+
+// WithSponsored sets whether the creator escrows the signing fees of signers.
+func (it CreateDocumentsItemSingleFile) WithSponsored(sponsored bool) CreateDocumentsItemSingleFile {
+	it.sponsored = sponsored
+
+	return it
+}
+
 func (it CreateDocumentsItemSingleFile) IsValid([]byte) error {
 	if err := it.BaseCreateDocumentsItem.IsValid(nil); err != nil {
 		return err
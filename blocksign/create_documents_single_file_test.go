@@ -1,6 +1,7 @@
 package blocksign
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/spikeekips/mitum-currency/currency"
@@ -52,8 +53,8 @@ func (t *testCreateDocumentsSingleFile) TestNew() {
 	cid := currency.CurrencyID("SHOWME")
 
 	// uploaderSignCode for document
-	fh := FileHash("ABCD")
-	documentid := currency.NewBig(0)
+	fh := MustFileHash("ABCD")
+	documentid := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -122,7 +123,7 @@ func (t *testCreateDocumentsSingleFile) TestEmptyFileHash() {
 
 	// Empty FileHash
 	efh := FileHash("")
-	documentid := currency.NewBig(0)
+	documentid := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -159,6 +160,74 @@ func (t *testCreateDocumentsSingleFile) TestEmptyFileHash() {
 	t.Contains(err.Error(), "empty fileHash")
 }
 
+func (t *testCreateDocumentsSingleFile) TestInvalidSigncode() {
+	signers := []base.Address{MustAddress(util.UUID().String())}
+	cid := currency.CurrencyID("SHOWME")
+
+	item := NewCreateDocumentsItemSingleFile(
+		MustFileHash("ABCD"), currency.NewBig(1), "user 0", "title", currency.NewBig(555), signers, []string{"user1"}, cid,
+	)
+	err := item.IsValid(nil)
+	t.Error(err)
+	t.Contains(err.Error(), "invalid creator signcode")
+
+	item = NewCreateDocumentsItemSingleFile(
+		MustFileHash("ABCD"), currency.NewBig(1), "user0", "title", currency.NewBig(555), signers, []string{""}, cid,
+	)
+	err = item.IsValid(nil)
+	t.Error(err)
+	t.Contains(err.Error(), "invalid signcode of signer")
+
+	// NOTE the format of signcode is not checked in the legacy hint
+	item = NewCreateDocumentsItemSingleFile(
+		MustFileHash("ABCD"), currency.NewBig(1), "user 0", "title", currency.NewBig(555), signers, []string{""}, cid,
+	)
+	item.hint = CreateDocumentsItemSingleFileLegacyHint
+	t.NoError(item.IsValid(nil))
+}
+
+func (t *testCreateDocumentsSingleFile) TestPlainFileHash() {
+	cid := currency.CurrencyID("SHOWME")
+
+	for _, s := range []string{"ABCD", "AB CD", "foo:bar", "md5:" + strings.Repeat("a", 32), "SHA256:" + strings.Repeat("ab", 32)} {
+		item := NewCreateDocumentsItemSingleFile(
+			FileHash(s), currency.NewBig(1), "user0", "title", currency.NewBig(555), []base.Address{}, []string{}, cid,
+		)
+		t.Error(item.IsValid(nil), s)
+
+		// NOTE the plain FileHash is accepted only in the legacy hint
+		item.hint = CreateDocumentsItemSingleFileLegacyHint
+		t.NoError(item.IsValid(nil), s)
+	}
+}
+
+func (t *testCreateDocumentsSingleFile) TestBytes() {
+	signers := []base.Address{MustAddress(util.UUID().String()), MustAddress(util.UUID().String())}
+	cid := currency.CurrencyID("SHOWME")
+
+	newItem := func(signcodes []string) CreateDocumentsItemSingleFile {
+		return NewCreateDocumentsItemSingleFile(
+			MustFileHash("ABCD"), currency.NewBig(1), "user0", "title", currency.NewBig(555), signers, signcodes, cid,
+		)
+	}
+
+	a := newItem([]string{"ab", "c"})
+	b := newItem([]string{"a", "bc"})
+	t.NotEqual(a.Bytes(), b.Bytes())
+
+	// omitted document id
+	c := NewCreateDocumentsItemSingleFileWithoutId(
+		MustFileHash("ABCD"), "user0", "title", currency.NewBig(555), signers, []string{"ab", "c"}, cid,
+	)
+	t.NotEqual(a.Bytes(), c.Bytes())
+
+	// NOTE the legacy encoding is kept for the items of existing chain
+	a.hint = CreateDocumentsItemSingleFileLegacyHint
+	b.hint = CreateDocumentsItemSingleFileLegacyHint
+	t.Equal(a.Bytes(), b.Bytes())
+	t.Equal(a.legacyBytes(), a.Bytes())
+}
+
 func TestCreateDocumentsSingleFile(t *testing.T) {
 	suite.Run(t, new(testCreateDocumentsSingleFile))
 }
@@ -194,15 +263,19 @@ func testCreateDocumentsSingleFileEncode(enc encoder.Encoder) suite.TestingSuite
 
 		cid := currency.CurrencyID("SHOWME")
 
-		filehash := FileHash("ABCD")
-		documentid := currency.NewBig(0)
+		filehash := MustFileHash("ABCD")
+		documentid := currency.NewBig(1)
 		signcode0 := "user0"
 		title := "title01"
 		size := currency.NewBig(555)
 		signcode1 := "user1"
 		signcode2 := "user2"
 		// FileData for document
-		item := NewCreateDocumentsItemSingleFile(filehash, documentid, signcode0, title, size, []base.Address{signer0, signer1}, []string{signcode1, signcode2}, cid)
+		item := NewCreateDocumentsItemSingleFile(filehash, documentid, signcode0, title, size, []base.Address{signer0, signer1}, []string{signcode1, signcode2}, cid).
+			WithExpiry(base.Height(33)).
+			WithSigningMode(SigningSequential).
+			WithQuorum([]uint{1, 2}, 2).
+			WithMetadata(DocumentMetadata{MetadataKeyMimeType: "application/pdf", MetadataKeyTags: "hr,contract"})
 		fact := NewCreateDocumentsFact(util.UUID().Bytes(), sender, []CreateDocumentsItem{item})
 
 		var fs []operation.FactSign
@@ -239,6 +312,11 @@ func testCreateDocumentsSingleFileEncode(enc encoder.Encoder) suite.TestingSuite
 			}
 
 			t.Equal(a.Currency(), (b.Currency()))
+			t.Equal(a.Expiry(), b.Expiry())
+			t.Equal(a.SigningMode(), b.SigningMode())
+			t.Equal(a.Weights(), b.Weights())
+			t.Equal(a.Threshold(), b.Threshold())
+			t.True(a.Metadata().Equal(b.Metadata()))
 		}
 	}
 
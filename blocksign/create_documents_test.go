@@ -34,8 +34,8 @@ func (t *testCreateDocuments) TestNew() {
 
 	token := util.UUID().Bytes()
 
-	filehash := FileHash("ABCD")
-	documentid := currency.NewBig(0)
+	filehash := MustFileHash("ABCD")
+	documentid := currency.NewBig(1)
 	signcode0 := "user0"
 	title := "title01"
 	size := currency.NewBig(555)
@@ -77,8 +77,8 @@ func (t *testCreateDocuments) TestDuplicatedDocumentId() {
 	skeys, _ := currency.NewKeys([]currency.Key{skey}, 100)
 	sender, _ := currency.NewAddressFromKeys(skeys)
 	{
-		filehash := FileHash("ABCD")
-		documentid := currency.NewBig(0)
+		filehash := MustFileHash("ABCD")
+		documentid := currency.NewBig(1)
 		signcode0 := "user0"
 		title := "title01"
 		size := currency.NewBig(555)
@@ -102,6 +102,121 @@ func (t *testCreateDocuments) TestDuplicatedDocumentId() {
 	t.Contains(err.Error(), "duplicated filehash")
 }
 
+func (t *testCreateDocuments) TestOmittedDocumentId() {
+	cid := currency.CurrencyID("SHOWME")
+
+	pk := key.MustNewBTCPrivatekey()
+	skey, err := currency.NewKey(pk.Publickey(), 100)
+	t.NoError(err)
+
+	skeys, _ := currency.NewKeys([]currency.Key{skey}, 100)
+	sender, _ := currency.NewAddressFromKeys(skeys)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("ABCD"), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+		NewCreateDocumentsItemSingleFileWithoutId(MustFileHash("EFGH"), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	t.True(items[0].IsDocumentIdOmitted())
+
+	fact := NewCreateDocumentsFact(util.UUID().Bytes(), sender, items)
+
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	op, err := NewCreateDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+}
+
+func (t *testCreateDocuments) TestNegativeDocumentId() {
+	cid := currency.CurrencyID("SHOWME")
+
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(-2), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "documentid is negative number")
+}
+
+func (t *testCreateDocuments) TestInvalidExpiry() {
+	cid := currency.CurrencyID("SHOWME")
+
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid)
+	t.Equal(base.NilHeight, item.Expiry())
+	t.NoError(item.IsValid(nil))
+
+	t.NoError(item.WithExpiry(base.Height(3)).IsValid(nil))
+
+	err := item.WithExpiry(base.PreGenesisHeight).IsValid(nil)
+	t.Contains(err.Error(), "invalid expiry height")
+}
+
+func (t *testCreateDocuments) TestQuorum() {
+	cid := currency.CurrencyID("SHOWME")
+
+	signers := []base.Address{
+		MustAddress(util.UUID().String()),
+		MustAddress(util.UUID().String()),
+		MustAddress(util.UUID().String()),
+	}
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), signers, []string{"user1", "user2", "user3"}, cid)
+
+	t.NoError(item.WithQuorum([]uint{1, 1, 1}, 2).IsValid(nil))
+
+	err := item.WithQuorum([]uint{1, 1}, 2).IsValid(nil)
+	t.Contains(err.Error(), "length of weights array is not same")
+
+	err = item.WithQuorum([]uint{1, 1, 1}, 0).IsValid(nil)
+	t.Contains(err.Error(), "invalid threshold")
+
+	err = item.WithQuorum([]uint{1, 0, 1}, 2).IsValid(nil)
+	t.Contains(err.Error(), "invalid signer weight")
+
+	err = item.WithQuorum([]uint{1, 1, 1}, 4).IsValid(nil)
+	t.Contains(err.Error(), "sum of weights under threshold")
+}
+
+func (t *testCreateDocuments) TestInvalidSigningMode() {
+	cid := currency.CurrencyID("SHOWME")
+
+	item := NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid)
+	t.Equal(SigningParallel, item.SigningMode())
+
+	t.NoError(item.WithSigningMode(SigningSequential).IsValid(nil))
+
+	err := item.WithSigningMode(SigningMode(9)).IsValid(nil)
+	t.Contains(err.Error(), "unknown signing mode")
+}
+
+func (t *testCreateDocuments) TestDuplicatedDocumentIdWithDifferentFileHash() {
+	cid := currency.CurrencyID("SHOWME")
+
+	pk := key.MustNewBTCPrivatekey()
+	skey, err := currency.NewKey(pk.Publickey(), 100)
+	t.NoError(err)
+
+	skeys, _ := currency.NewKeys([]currency.Key{skey}, 100)
+	sender, _ := currency.NewAddressFromKeys(skeys)
+
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(MustFileHash("ABCD"), currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+		NewCreateDocumentsItemSingleFile(MustFileHash("EFGH"), currency.NewBig(1), "user0", "title02", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+
+	fact := NewCreateDocumentsFact(util.UUID().Bytes(), sender, items)
+
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	op, err := NewCreateDocuments(fact, fs, "")
+	t.NoError(err)
+
+	err = op.IsValid(nil)
+	t.Contains(err.Error(), "duplicated documentid")
+}
+
 func TestCreateDocuments(t *testing.T) {
 	suite.Run(t, new(testCreateDocuments))
 }
@@ -2,9 +2,13 @@ package blocksign
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/big"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spikeekips/mitum-currency/currency"
@@ -20,15 +24,119 @@ import (
 
 var (
 	DocumentDataType = hint.Type("mitum-blocksign-document-data")
-	DocumentDataHint = hint.NewHint(DocumentDataType, "v0.0.1")
+	DocumentDataHint = hint.NewHint(DocumentDataType, "v0.0.2")
+	// DocumentDataLegacyHint is the hint of document data before the canonical
+	// encoding; the documents of existing chain keep the legacy hash.
+	DocumentDataLegacyHint = hint.NewHint(DocumentDataType, "v0.0.1")
 )
 
+// DocumentStatus is the lifecycle status of document, which is derived from
+// the signing status of signers.
+type DocumentStatus uint8
+
+const (
+	DocumentDraft DocumentStatus = iota
+	DocumentPartiallySigned
+	DocumentFullySigned
+	DocumentRejected
+	DocumentExpired
+	DocumentCanceled
+)
+
+func ParseDocumentStatus(s string) (DocumentStatus, error) {
+	switch s {
+	case "draft":
+		return DocumentDraft, nil
+	case "partially-signed":
+		return DocumentPartiallySigned, nil
+	case "fully-signed":
+		return DocumentFullySigned, nil
+	case "rejected":
+		return DocumentRejected, nil
+	case "expired":
+		return DocumentExpired, nil
+	case "canceled":
+		return DocumentCanceled, nil
+	default:
+		return DocumentDraft, errors.Errorf("unknown document status, %q", s)
+	}
+}
+
+func (st DocumentStatus) String() string {
+	switch st {
+	case DocumentDraft:
+		return "draft"
+	case DocumentPartiallySigned:
+		return "partially-signed"
+	case DocumentFullySigned:
+		return "fully-signed"
+	case DocumentRejected:
+		return "rejected"
+	case DocumentExpired:
+		return "expired"
+	case DocumentCanceled:
+		return "canceled"
+	default:
+		return "<unknown>"
+	}
+}
+
+// SigningMode decides the order of signing; in sequential mode, signers must
+// sign in the order of document signers.
+type SigningMode uint8
+
+const (
+	SigningParallel SigningMode = iota
+	SigningSequential
+)
+
+func ParseSigningMode(s string) (SigningMode, error) {
+	switch s {
+	case "parallel":
+		return SigningParallel, nil
+	case "sequential":
+		return SigningSequential, nil
+	default:
+		return SigningParallel, errors.Errorf("unknown signing mode, %q", s)
+	}
+}
+
+func (mode SigningMode) String() string {
+	switch mode {
+	case SigningParallel:
+		return "parallel"
+	case SigningSequential:
+		return "sequential"
+	default:
+		return "<unknown>"
+	}
+}
+
+func (mode SigningMode) IsValid([]byte) error {
+	switch mode {
+	case SigningParallel, SigningSequential:
+		return nil
+	default:
+		return errors.Errorf("unknown signing mode, %d", mode)
+	}
+}
+
 type DocumentData struct {
-	info    DocInfo
-	creator DocSign
-	title   string
-	size    currency.Big
-	signers []DocSign
+	hint      hint.Hint
+	info      DocInfo
+	creator   DocSign
+	title     string
+	size      currency.Big
+	signers   []DocSign
+	expiry    base.Height
+	mode      SigningMode
+	threshold uint          // sum of signed weights to be fully signed; 0 means all signers
+	revisions []DocRevision // superseded revisions, oldest first
+	canceled  base.Height   // height, at which document is canceled by creator
+	metadata  DocumentMetadata
+	files     []DocFile       // files of document, which has multiple files
+	escrow    currency.Amount // escrow of creator for signing fees of signers
+	payer     base.Address    // account, which paid escrow
 }
 
 func NewDocumentData(info DocInfo,
@@ -38,15 +146,13 @@ func NewDocumentData(info DocInfo,
 	size currency.Big,
 	signers []DocSign) DocumentData {
 	doc := DocumentData{
-		info: info,
-		creator: DocSign{
-			address:  creator,
-			signcode: signcode,
-			signed:   true,
-		},
-		title:   title,
-		size:    size,
-		signers: signers,
+		info:     info,
+		creator:  NewDocSign(creator, signcode, true),
+		title:    title,
+		size:     size,
+		signers:  signers,
+		expiry:   base.NilHeight,
+		canceled: base.NilHeight,
 	}
 
 	return doc
@@ -62,22 +168,105 @@ func MustNewDocumentData(info DocInfo, creator base.Address, signcode string, ti
 }
 
 func (doc DocumentData) Hint() hint.Hint {
-	return DocumentDataHint
+	if len(doc.hint.Type()) < 1 {
+		return DocumentDataHint
+	}
+
+	return doc.hint
 }
 
 func (doc DocumentData) Bytes() []byte {
+	if doc.Hint().Equal(DocumentDataLegacyHint) {
+		return doc.legacyBytes()
+	}
+
+	signers := doc.orderedSigners(DocSign.canonicalBytes)
+	bsg := make([][]byte, len(signers))
+	for i := range signers {
+		bsg[i] = signers[i].canonicalBytes()
+	}
+
+	brv := make([][]byte, len(doc.revisions))
+	for i := range doc.revisions {
+		brv[i] = doc.revisions[i].Bytes()
+	}
+
+	bfs := make([][]byte, len(doc.files))
+	for i := range doc.files {
+		bfs[i] = doc.files[i].Bytes()
+	}
+
+	var bes []byte
+	if doc.HasEscrow() {
+		bes = concatCanonicalBytes(doc.escrow.Bytes(), doc.EscrowPayer().Bytes())
+	}
+
+	return concatCanonicalBytes(
+		doc.Hint().Bytes(),
+		doc.info.Bytes(),
+		doc.creator.canonicalBytes(),
+		[]byte(doc.title),
+		doc.size.Bytes(),
+		concatCanonicalBytes(bsg...),
+		doc.expiry.Bytes(),
+		[]byte{byte(doc.mode)},
+		util.UintToBytes(doc.threshold),
+		concatCanonicalBytes(brv...),
+		doc.canceled.Bytes(),
+		doc.metadata.Bytes(),
+		concatCanonicalBytes(bfs...),
+		bes,
+	)
+}
+
+// legacyBytes is the encoding of DocumentDataLegacyHint.
+func (doc DocumentData) legacyBytes() []byte {
 	bs := make([][]byte, len(doc.signers)+4)
 
-	sort.Slice(doc.signers, func(i, j int) bool {
-		return bytes.Compare(doc.signers[i].Bytes(), doc.signers[j].Bytes()) < 0
-	})
+	// NOTE signers are encoded by their own hint; the signer of upgraded
+	// docsign in legacy document keeps it's status and height
+	signers := doc.orderedSigners(DocSign.Bytes)
 
 	bs[0] = doc.info.Bytes()
 	bs[1] = doc.creator.Bytes()
 	bs[2] = []byte(doc.title)
 	bs[3] = doc.size.Bytes()
-	for i := range doc.signers {
-		bs[i+3] = doc.signers[i].Bytes()
+	// NOTE the first signer overwrites size and the last slot is left empty;
+	// it is kept for the hash of existing documents.
+	for i := range signers {
+		bs[i+3] = signers[i].Bytes()
+	}
+
+	if !doc.expiry.IsEmpty() {
+		bs = append(bs, doc.expiry.Bytes())
+	}
+
+	if doc.mode != SigningParallel {
+		bs = append(bs, []byte{byte(doc.mode)})
+	}
+
+	if doc.threshold > 0 {
+		bs = append(bs, util.UintToBytes(doc.threshold))
+	}
+
+	for i := range doc.revisions {
+		bs = append(bs, doc.revisions[i].Bytes())
+	}
+
+	if doc.IsCanceled() {
+		bs = append(bs, doc.canceled.Bytes())
+	}
+
+	if len(doc.metadata) > 0 {
+		bs = append(bs, doc.metadata.Bytes())
+	}
+
+	for i := range doc.files {
+		bs = append(bs, doc.files[i].Bytes())
+	}
+
+	if doc.HasEscrow() {
+		bs = append(bs, doc.escrow.Bytes(), doc.EscrowPayer().Bytes())
 	}
 
 	return util.ConcatBytesSlice(bs...)
@@ -97,20 +286,54 @@ func (doc DocumentData) IsEmpty() bool {
 
 func (doc DocumentData) IsValid([]byte) error {
 	if err := isvalid.Check([]isvalid.IsValider{
-		doc.info.FileHash(),
+		doc.info,
 		doc.creator,
+		doc.mode,
+		doc.metadata,
 	}, nil, false); err != nil {
 		return errors.Wrap(err, "invalid document data")
 	}
 
+	signers := map[string]bool{}
 	for i := range doc.signers {
 		c := doc.signers[i]
 		if err := c.IsValid(nil); err != nil {
 			return err
 		}
+
+		k := c.Address().String()
+		switch {
+		case signers[k]:
+			return errors.Errorf("duplicated signer, %v", c.Address())
+		case c.Address().Equal(doc.creator.Address()):
+			return errors.Errorf("document creator can not be signer, %v", c.Address())
+		}
+		signers[k] = true
 	}
 
-	// TODO : check owner and signer are not same
+	for i := range doc.revisions {
+		if err := doc.revisions[i].IsValid(nil); err != nil {
+			return err
+		}
+	}
+
+	for i := range doc.files {
+		if err := doc.files[i].IsValid(nil); err != nil {
+			return err
+		}
+	}
+
+	if doc.HasEscrow() {
+		if err := doc.escrow.IsValid(nil); err != nil {
+			return errors.Wrap(err, "invalid escrow")
+		}
+
+		if doc.payer != nil {
+			if err := doc.payer.IsValid(nil); err != nil {
+				return errors.Wrap(err, "invalid escrow payer")
+			}
+		}
+	}
 
 	return nil
 }
@@ -143,6 +366,291 @@ func (doc DocumentData) Signers() []DocSign {
 	return doc.signers
 }
 
+// Signer returns the DocSign of signer address.
+func (doc DocumentData) Signer(a base.Address) (DocSign, bool) {
+	for i := range doc.signers {
+		if doc.signers[i].Address().Equal(a) {
+			return doc.signers[i], true
+		}
+	}
+
+	return DocSign{}, false
+}
+
+// SetSignerStatus returns new DocumentData, which has the updated status of
+// signer; the signers of original DocumentData are not changed.
+func (doc DocumentData) SetSignerStatus(a base.Address, status DocSignStatus, height base.Height) (DocumentData, error) {
+	signers := make([]DocSign, len(doc.signers))
+	copy(signers, doc.signers)
+
+	for i := range signers {
+		if signers[i].Address().Equal(a) {
+			signers[i].SetStatus(status, height)
+			doc.signers = signers
+
+			return doc, nil
+		}
+	}
+
+	return DocumentData{}, errors.Errorf("signer not found in document signers, %v", a)
+}
+
+// Status returns the lifecycle status of document; if any signer rejects, the
+// document is rejected.
+func (doc DocumentData) Status() DocumentStatus {
+	if doc.IsCanceled() {
+		return DocumentCanceled
+	}
+
+	if doc.threshold > 0 {
+		return doc.statusByThreshold()
+	}
+
+	var signed int
+	for i := range doc.signers {
+		switch {
+		case doc.signers[i].Rejected():
+			return DocumentRejected
+		case doc.signers[i].Signed():
+			signed++
+		}
+	}
+
+	switch {
+	case signed == len(doc.signers):
+		return DocumentFullySigned
+	case signed > 0:
+		return DocumentPartiallySigned
+	default:
+		return DocumentDraft
+	}
+}
+
+// statusByThreshold returns the lifecycle status by the weights of signers
+// like currency.Keys; the document is fully signed when the sum of signed
+// weights passes threshold and it is rejected when threshold can not be passed
+// anymore.
+func (doc DocumentData) statusByThreshold() DocumentStatus {
+	var signed, rejected uint
+	var total uint
+	for i := range doc.signers {
+		w := doc.signers[i].Weight()
+		total += w
+
+		switch {
+		case doc.signers[i].Rejected():
+			rejected += w
+		case doc.signers[i].Signed():
+			signed += w
+		}
+	}
+
+	switch {
+	case signed >= doc.threshold:
+		return DocumentFullySigned
+	case total-rejected < doc.threshold:
+		return DocumentRejected
+	case signed > 0:
+		return DocumentPartiallySigned
+	default:
+		return DocumentDraft
+	}
+}
+
+// StatusAt returns the lifecycle status of document at the given height; the
+// document, which is not yet fully signed after expiry, is expired.
+func (doc DocumentData) StatusAt(height base.Height) DocumentStatus {
+	status := doc.Status()
+	switch status {
+	case DocumentDraft, DocumentPartiallySigned:
+		if doc.IsExpired(height) {
+			return DocumentExpired
+		}
+	}
+
+	return status
+}
+
+// Expiry returns the height after which the document can not be signed;
+// base.NilHeight means no expiry.
+func (doc DocumentData) Expiry() base.Height {
+	return doc.expiry
+}
+
+func (doc DocumentData) IsExpired(height base.Height) bool {
+	return !doc.expiry.IsEmpty() && height > doc.expiry
+}
+
+func (doc DocumentData) WithExpiry(height base.Height) DocumentData {
+	doc.expiry = height
+
+	return doc
+}
+
+func (doc DocumentData) Threshold() uint {
+	return doc.threshold
+}
+
+func (doc DocumentData) WithThreshold(threshold uint) DocumentData {
+	doc.threshold = threshold
+
+	return doc
+}
+
+func (doc DocumentData) Metadata() DocumentMetadata {
+	return doc.metadata
+}
+
+// WithMetadata sets the metadata of document.
+func (doc DocumentData) WithMetadata(md DocumentMetadata) DocumentData {
+	doc.metadata = md.Copy()
+
+	return doc
+}
+
+// Files returns the files of document, which has multiple files; document with
+// single file returns nil.
+func (doc DocumentData) Files() []DocFile {
+	return doc.files
+}
+
+func (doc DocumentData) WithFiles(files []DocFile) DocumentData {
+	doc.files = files
+
+	return doc
+}
+
+// Escrow returns the amount, which is escrowed by creator to pay the signing
+// fees of signers.
+func (doc DocumentData) Escrow() currency.Amount {
+	return doc.escrow
+}
+
+func (doc DocumentData) HasEscrow() bool {
+	return doc.escrow.Big().OverZero()
+}
+
+// WithEscrow sets escrow; empty amount removes escrow with the payer.
+func (doc DocumentData) WithEscrow(am currency.Amount) DocumentData {
+	if !am.Big().OverZero() {
+		am = currency.Amount{}
+		doc.payer = nil
+	}
+
+	doc.escrow = am
+
+	return doc
+}
+
+// EscrowPayer returns the account, which paid escrow; the rest of escrow is
+// refunded to the payer even if document is transferred. Escrow without payer
+// is paid by creator.
+func (doc DocumentData) EscrowPayer() base.Address {
+	if doc.payer == nil {
+		return doc.creator.Address()
+	}
+
+	return doc.payer
+}
+
+func (doc DocumentData) WithEscrowPayer(payer base.Address) DocumentData {
+	doc.payer = payer
+
+	return doc
+}
+
+func (doc DocumentData) SigningMode() SigningMode {
+	return doc.mode
+}
+
+func (doc DocumentData) WithSigningMode(mode SigningMode) DocumentData {
+	doc.mode = mode
+
+	return doc
+}
+
+// NextSigner returns the signer, who is expected to sign next in sequential
+// mode; in parallel mode, there is no next signer.
+func (doc DocumentData) NextSigner() (DocSign, bool) {
+	if doc.mode != SigningSequential || doc.Status() == DocumentFullySigned {
+		return DocSign{}, false
+	}
+
+	for i := range doc.signers {
+		if !doc.signers[i].Signed() {
+			return doc.signers[i], true
+		}
+	}
+
+	return DocSign{}, false
+}
+
+// Canceled returns the height, at which document is canceled; base.NilHeight
+// means not canceled.
+func (doc DocumentData) Canceled() base.Height {
+	return doc.canceled
+}
+
+func (doc DocumentData) IsCanceled() bool {
+	return !doc.canceled.IsEmpty()
+}
+
+// Cancel returns new DocumentData, which is voided at the given height; the
+// canceled document can not be signed anymore.
+func (doc DocumentData) Cancel(height base.Height) DocumentData {
+	doc.canceled = height
+
+	return doc
+}
+
+// Revisions returns the superseded revisions of document, oldest first.
+func (doc DocumentData) Revisions() []DocRevision {
+	return doc.revisions
+}
+
+// Revise returns new DocumentData, which has the new file of document; the
+// current file and signatures are kept in revisions and the signatures of
+// signers are reset.
+func (doc DocumentData) Revise(fh FileHash, title string, size currency.Big, height base.Height) DocumentData {
+	revisions := make([]DocRevision, len(doc.revisions)+1)
+	copy(revisions, doc.revisions)
+	revisions[len(doc.revisions)] = NewDocRevision(doc.FileHash(), doc.title, doc.size, doc.signers, height)
+
+	signers := make([]DocSign, len(doc.signers))
+	for i := range doc.signers {
+		signers[i] = NewDocSignWithStatus(
+			doc.signers[i].Address(), doc.signers[i].Signcode(), DocSignPending, base.NilHeight,
+		).WithWeight(doc.signers[i].Weight())
+	}
+
+	doc.info = doc.info.WithData(doc.info.Index(), fh)
+	doc.title = title
+	doc.size = size
+	doc.signers = signers
+	doc.revisions = revisions
+	// NOTE revised document has single file
+	doc.files = nil
+
+	return doc
+}
+
+// orderedSigners returns the copy of signers; the signers are sorted by key
+// except in sequential mode, where the order of signers matters.
+func (doc DocumentData) orderedSigners(key func(DocSign) []byte) []DocSign {
+	signers := make([]DocSign, len(doc.signers))
+	copy(signers, doc.signers)
+
+	if doc.mode == SigningSequential {
+		return signers
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(key(signers[i]), key(signers[j])) < 0
+	})
+
+	return signers
+}
+
 func (doc DocumentData) Addresses() ([]base.Address, error) {
 	addresses := make(map[base.Address]bool)
 	addresses[doc.creator.Address()] = true
@@ -172,8 +680,11 @@ func (doc DocumentData) String() string {
 }
 
 func (doc DocumentData) Equal(b DocumentData) bool {
+	if !doc.Hint().Equal(b.Hint()) {
+		return false
+	}
 
-	if doc.FileHash() != b.FileHash() {
+	if !doc.info.Equal(b.info) {
 		return false
 	}
 
@@ -185,19 +696,67 @@ func (doc DocumentData) Equal(b DocumentData) bool {
 		return false
 	}
 
-	if !doc.size.Equal(b.size) {
+	if !equalBig(doc.size, b.size) {
 		return false
 	}
 
-	sort.Slice(doc.signers, func(i, j int) bool {
-		return bytes.Compare(doc.signers[i].Bytes(), doc.signers[j].Bytes()) < 0
-	})
-	sort.Slice(b.signers, func(i, j int) bool {
-		return bytes.Compare(b.signers[i].Bytes(), b.signers[j].Bytes()) < 0
-	})
+	if doc.expiry != b.expiry {
+		return false
+	}
 
-	for i := range doc.signers {
-		if !doc.signers[i].Equal(b.signers[i]) {
+	if doc.mode != b.mode {
+		return false
+	}
+
+	if doc.threshold != b.threshold {
+		return false
+	}
+
+	if doc.canceled != b.canceled {
+		return false
+	}
+
+	if !doc.metadata.Equal(b.metadata) {
+		return false
+	}
+
+	if len(doc.files) != len(b.files) {
+		return false
+	}
+
+	for i := range doc.files {
+		if !doc.files[i].Equal(b.files[i]) {
+			return false
+		}
+	}
+
+	switch {
+	case doc.HasEscrow() != b.HasEscrow():
+		return false
+	case doc.HasEscrow() && !doc.escrow.Equal(b.escrow):
+		return false
+	case doc.HasEscrow() && !doc.EscrowPayer().Equal(b.EscrowPayer()):
+		return false
+	}
+
+	if len(doc.signers) != len(b.signers) {
+		return false
+	}
+
+	as := doc.orderedSigners(DocSign.canonicalBytes)
+	bs := b.orderedSigners(DocSign.canonicalBytes)
+	for i := range as {
+		if !as[i].Equal(bs[i]) {
+			return false
+		}
+	}
+
+	if len(doc.revisions) != len(b.revisions) {
+		return false
+	}
+
+	for i := range doc.revisions {
+		if !doc.revisions[i].Equal(b.revisions[i]) {
 			return false
 		}
 	}
@@ -214,43 +773,81 @@ func (doc DocumentData) WithData(info DocInfo, creator DocSign, signcode string,
 	return doc
 }
 
-type FileHash string
+var (
+	DocSignType = hint.Type("mitum-blocksign-docsign")
+	DocSignHint = hint.NewHint(DocSignType, "v0.0.2")
+	// DocSignLegacyHint is the hint of docsign before the canonical encoding.
+	DocSignLegacyHint = hint.NewHint(DocSignType, "v0.0.1")
+)
 
-func (fh FileHash) Bytes() []byte {
-	return []byte(fh)
-}
+// DocSignStatus is the signing status of a signer in document.
+type DocSignStatus uint8
 
-func (fh FileHash) String() string {
-	return string(fh)
-}
+const (
+	DocSignPending DocSignStatus = iota
+	DocSignSigned
+	DocSignRejected
+)
 
-func (fh FileHash) IsValid([]byte) error {
-	if len(fh) < 1 {
-		return errors.Errorf("empty fileHash")
+func ParseDocSignStatus(s string) (DocSignStatus, error) {
+	switch s {
+	case "pending":
+		return DocSignPending, nil
+	case "signed":
+		return DocSignSigned, nil
+	case "rejected":
+		return DocSignRejected, nil
+	default:
+		return DocSignPending, errors.Errorf("unknown docsign status, %q", s)
 	}
-	return nil
 }
 
-func (fh FileHash) Equal(b FileHash) bool {
-	return fh == b
+func (st DocSignStatus) String() string {
+	switch st {
+	case DocSignPending:
+		return "pending"
+	case DocSignSigned:
+		return "signed"
+	case DocSignRejected:
+		return "rejected"
+	default:
+		return "<unknown>"
+	}
 }
 
-var (
-	DocSignType = hint.Type("mitum-blocksign-docsign")
-	DocSignHint = hint.NewHint(DocSignType, "v0.0.1")
-)
+func (st DocSignStatus) IsValid([]byte) error {
+	switch st {
+	case DocSignPending, DocSignSigned, DocSignRejected:
+		return nil
+	default:
+		return errors.Errorf("unknown docsign status, %d", st)
+	}
+}
 
 type DocSign struct {
+	hint     hint.Hint
 	address  base.Address
 	signcode string
-	signed   bool
+	status   DocSignStatus
+	height   base.Height // height at which status is changed
+	weight   uint        // signing weight; 0 when document has no threshold
 }
 
 func NewDocSign(address base.Address, signcode string, signed bool) DocSign {
+	status := DocSignPending
+	if signed {
+		status = DocSignSigned
+	}
+
+	return NewDocSignWithStatus(address, signcode, status, base.NilHeight)
+}
+
+func NewDocSignWithStatus(address base.Address, signcode string, status DocSignStatus, height base.Height) DocSign {
 	doc := DocSign{
 		address:  address,
 		signcode: signcode,
-		signed:   signed,
+		status:   status,
+		height:   height,
 	}
 	return doc
 }
@@ -267,16 +864,36 @@ func (doc DocSign) Address() base.Address {
 	return doc.address
 }
 
+func (ds DocSign) Signcode() string {
+	return ds.signcode
+}
+
 func (ds DocSign) Bytes() []byte {
-	bs := make([][]byte, 2)
+	if ds.Hint().Equal(DocSignLegacyHint) {
+		return ds.legacyBytes()
+	}
+
+	return ds.canonicalBytes()
+}
 
-	bs[0] = ds.address.Bytes()
+func (ds DocSign) canonicalBytes() []byte {
+	return concatCanonicalBytes(
+		ds.address.Bytes(),
+		[]byte(ds.signcode),
+		[]byte{byte(ds.status)},
+		ds.height.Bytes(),
+		util.UintToBytes(ds.weight),
+	)
+}
+
+// legacyBytes is the encoding of DocSignLegacyHint, address and signed flag.
+func (ds DocSign) legacyBytes() []byte {
 	var v int8
-	if ds.signed {
+	if ds.status == DocSignSigned {
 		v = 1
 	}
-	bs[1] = []byte{byte(v)}
-	return util.ConcatBytesSlice(bs...)
+
+	return util.ConcatBytesSlice(ds.address.Bytes(), []byte{byte(v)})
 }
 
 func (ds DocSign) Hash() valuehash.Hash {
@@ -288,25 +905,44 @@ func (ds DocSign) GenerateHash() valuehash.Hash {
 }
 
 func (ds DocSign) Hint() hint.Hint {
-	return DocSignHint
+	if len(ds.hint.Type()) < 1 {
+		return DocSignHint
+	}
+
+	return ds.hint
 }
 
 func (ds DocSign) IsValid([]byte) error {
+	if ds.address == nil {
+		return errors.Errorf("empty address of docsign")
+	}
+
+	// NOTE signcode is not checked; the signers of existing documents may
+	// have empty signcode
+	if err := isvalid.Check([]isvalid.IsValider{
+		ds.address,
+		ds.status,
+	}, nil, false); err != nil {
+		return errors.Wrap(err, "invalid docsign")
+	}
+
 	return nil
 }
 
 func (ds DocSign) IsEmpty() bool {
-	return len(ds.address.Raw()) < 1
+	return ds.address == nil || len(ds.address.Raw()) < 1
 }
 
 func (ds DocSign) String() string {
-	v := fmt.Sprintf("%v", ds.signed)
-	return fmt.Sprintf("%s:%s", ds.address.Raw(), v)
+	return fmt.Sprintf("%s:%s", ds.address.Raw(), ds.status)
 }
 
 func (ds DocSign) Equal(b DocSign) bool {
+	if !ds.Hint().Equal(b.Hint()) {
+		return false
+	}
 
-	if !ds.address.Equal(b.address) {
+	if !equalAddress(ds.address, b.address) {
 		return false
 	}
 
@@ -314,7 +950,15 @@ func (ds DocSign) Equal(b DocSign) bool {
 		return false
 	}
 
-	if ds.signed != b.signed {
+	if ds.status != b.status {
+		return false
+	}
+
+	if ds.height != b.height {
+		return false
+	}
+
+	if ds.weight != b.weight {
 		return false
 	}
 
@@ -322,11 +966,40 @@ func (ds DocSign) Equal(b DocSign) bool {
 }
 
 func (ds *DocSign) Signed() bool {
-	return ds.signed
+	return ds.status == DocSignSigned
+}
+
+func (ds *DocSign) Rejected() bool {
+	return ds.status == DocSignRejected
+}
+
+func (ds DocSign) Status() DocSignStatus {
+	return ds.status
+}
+
+func (ds DocSign) Height() base.Height {
+	return ds.height
+}
+
+func (ds DocSign) Weight() uint {
+	return ds.weight
+}
+
+func (ds DocSign) WithWeight(weight uint) DocSign {
+	ds.weight = weight
+	if weight > 0 {
+		ds.hint = DocSignHint
+	}
+
+	return ds
 }
 
-func (ds *DocSign) SetSigned() {
-	ds.signed = true
+// SetStatus changes the signing status; the legacy docsign is upgraded to
+// DocSignHint, because DocSignLegacyHint does not cover status and height.
+func (ds *DocSign) SetStatus(status DocSignStatus, height base.Height) {
+	ds.status = status
+	ds.height = height
+	ds.hint = DocSignHint
 }
 
 type DocSignJSONPacker struct {
@@ -334,6 +1007,9 @@ type DocSignJSONPacker struct {
 	AD base.Address `json:"address"`
 	SC string       `json:"signcode"`
 	SG bool         `json:"signed"`
+	ST string       `json:"status"`
+	HT base.Height  `json:"height"`
+	WT uint         `json:"weight"`
 }
 
 func (ds DocSign) MarshalJSON() ([]byte, error) {
@@ -341,14 +1017,21 @@ func (ds DocSign) MarshalJSON() ([]byte, error) {
 		HintedHead: jsonenc.NewHintedHead(ds.Hint()),
 		AD:         ds.address,
 		SC:         ds.signcode,
-		SG:         ds.signed,
+		SG:         ds.Signed(),
+		ST:         ds.status.String(),
+		HT:         ds.height,
+		WT:         ds.weight,
 	})
 }
 
 type DocSignJSONUnpacker struct {
+	H  hint.Hint           `json:"_hint"`
 	AD base.AddressDecoder `json:"address"`
 	SC string              `json:"signcode"`
 	SG bool                `json:"signed"`
+	ST string              `json:"status"`
+	HT base.Height         `json:"height"`
+	WT uint                `json:"weight"`
 }
 
 func (ds *DocSign) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
@@ -357,13 +1040,16 @@ func (ds *DocSign) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
 		return err
 	}
 
-	return ds.unpack(enc, uds.AD, uds.SC, uds.SG)
+	return ds.unpack(enc, uds.H, uds.AD, uds.SC, uds.SG, uds.ST, uds.HT, uds.WT)
 }
 
 type DocSignBSONPacker struct {
 	AD base.Address `bson:"address"`
 	SC string       `bson:"signcode"`
 	SG bool         `bson:"signed"`
+	ST string       `bson:"status"`
+	HT base.Height  `bson:"height"`
+	WT uint         `bson:"weight"`
 }
 
 func (ds DocSign) MarshalBSON() ([]byte, error) {
@@ -372,15 +1058,22 @@ func (ds DocSign) MarshalBSON() ([]byte, error) {
 		bson.M{
 			"address":  ds.address,
 			"signcode": ds.signcode,
-			"signed":   ds.signed,
+			"signed":   ds.Signed(),
+			"status":   ds.status.String(),
+			"height":   ds.height,
+			"weight":   ds.weight,
 		}),
 	)
 }
 
 type DocSignBSONUnpacker struct {
+	H  hint.Hint           `bson:"_hint"`
 	AD base.AddressDecoder `bson:"address"`
 	SC string              `bson:"signcode"`
 	SG bool                `bson:"signed"`
+	ST string              `bson:"status"`
+	HT base.Height         `bson:"height"`
+	WT uint                `bson:"weight"`
 }
 
 func (ds *DocSign) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
@@ -389,7 +1082,7 @@ func (ds *DocSign) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
 		return err
 	}
 
-	return ds.unpack(enc, uds.AD, uds.SC, uds.SG)
+	return ds.unpack(enc, uds.H, uds.AD, uds.SC, uds.SG, uds.ST, uds.HT, uds.WT)
 }
 
 var (
@@ -464,13 +1157,13 @@ func (di DocInfo) Hint() hint.Hint {
 }
 
 func (di DocInfo) IsValid([]byte) error {
-	if err := di.idx.IsValid(nil); err != nil {
-		return err
-	} else if err := di.filehash.IsValid(nil); err != nil {
-		return err
+	if !di.idx.OverNil() {
+		return errors.Errorf("invalid document id, %v", di.idx)
 	}
 
-	return nil
+	// NOTE DocInfo of the existing documents may have the plain FileHash; the
+	// FileHash of new documents is checked by the items
+	return di.filehash.IsValidLegacy()
 }
 
 func (di DocInfo) IsEmpty() bool {
@@ -482,7 +1175,24 @@ func (di DocInfo) String() string {
 }
 
 func (di DocInfo) Equal(b DocInfo) bool {
-	return di.idx.Equal(b.idx) && di.filehash.Equal(b.filehash)
+	return equalBig(di.idx, b.idx) && di.filehash.Equal(b.filehash)
+}
+
+// equalBig compares Big safely; the empty Big is only equal to the empty Big.
+func equalBig(a, b currency.Big) bool {
+	if a.Int == nil || b.Int == nil {
+		return a.Int == nil && b.Int == nil
+	}
+
+	return a.Equal(b)
+}
+
+func equalAddress(a, b base.Address) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Equal(b)
 }
 
 func (di DocInfo) WithData(idx currency.Big, fh FileHash) DocInfo {
@@ -554,6 +1264,11 @@ func (di *DocInfo) UnmarshalBSON(b []byte) error {
 	return nil
 }
 
+var (
+	DocIdType = hint.Type("mitum-blocksign-document-id")
+	DocIdHint = hint.NewHint(DocIdType, "v0.0.1")
+)
+
 type DocId currency.Big
 
 func NewDocId(idx int64) DocId {
@@ -626,6 +1341,67 @@ func (di DocId) WithData(idx currency.Big) DocId {
 	return DocId(idx)
 }
 
+func (di DocId) Hint() hint.Hint {
+	return DocIdHint
+}
+
+type DocIdJSONPacker struct {
+	jsonenc.HintedHead
+	ID currency.Big `json:"documentid"`
+}
+
+func (di DocId) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocIdJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(di.Hint()),
+		ID:         di.Index(),
+	})
+}
+
+type DocIdJSONUnpacker struct {
+	ID currency.Big `json:"documentid"`
+}
+
+func (di *DocId) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var udi DocIdJSONUnpacker
+	if err := enc.Unmarshal(b, &udi); err != nil {
+		return err
+	}
+
+	*di = DocId(udi.ID)
+
+	return nil
+}
+
+func (di DocId) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(di.Hint()),
+		bson.M{
+			"documentid": di.Index(),
+		}),
+	)
+}
+
+type DocIdBSONUnpacker struct {
+	ID currency.Big `bson:"documentid"`
+}
+
+func (di *DocId) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var udi DocIdBSONUnpacker
+	if err := bsonenc.Unmarshal(b, &udi); err != nil {
+		return err
+	}
+
+	*di = DocId(udi.ID)
+
+	return nil
+}
+
+// MaxSignCodeLength limits the length of signcode.
+var MaxSignCodeLength = 100
+
+// NOTE signcode can not have space and control characters
+var reSignCode = regexp.MustCompile(`^[^\s[:cntrl:]]+$`)
+
 type SignCode string
 
 func (sc SignCode) Bytes() []byte {
@@ -636,6 +1412,71 @@ func (sc SignCode) String() string {
 	return string(sc)
 }
 
+// IsValid checks signcode is not empty; the signcode of existing documents
+// can have any format, so the format of new signcode is checked by
+// IsValidFormat.
 func (sc SignCode) IsValid([]byte) error {
+	if len(sc) < 1 {
+		return errors.Errorf("empty signcode")
+	}
+
 	return nil
 }
+
+// IsValidFormat checks the format of new signcode.
+func (sc SignCode) IsValidFormat() error {
+	switch {
+	case len(sc) < 1:
+		return errors.Errorf("empty signcode")
+	case len(sc) > MaxSignCodeLength:
+		return errors.Errorf("signcode too long, %d > %d", len(sc), MaxSignCodeLength)
+	case !reSignCode.MatchString(string(sc)):
+		return errors.Errorf("invalid signcode, %q", sc)
+	default:
+		return nil
+	}
+}
+
+// SignCodeCommitmentPrefix is the prefix of signcode commitment,
+// "sha256:<hex encoded sha256 digest of signcode>".
+const SignCodeCommitmentPrefix = "sha256:"
+
+// Commitment returns the commitment of signcode, which is recorded in document
+// instead of signcode; the commitment is returned as it is.
+func (sc SignCode) Commitment() SignCode {
+	if sc.IsCommitment() {
+		return sc
+	}
+
+	d := sha256.Sum256(sc.Bytes())
+
+	return SignCode(SignCodeCommitmentPrefix + hex.EncodeToString(d[:]))
+}
+
+// IsCommitment returns true if signcode is the commitment of signcode.
+func (sc SignCode) IsCommitment() bool {
+	if !strings.HasPrefix(string(sc), SignCodeCommitmentPrefix) {
+		return false
+	}
+
+	d := string(sc[len(SignCodeCommitmentPrefix):])
+	if len(d) != sha256.Size*2 || strings.ToLower(d) != d {
+		return false
+	}
+
+	_, err := hex.DecodeString(d)
+
+	return err == nil
+}
+
+// Verify checks the signcode, submitted by signer, is the preimage of
+// commitment; the plain signcode of existing documents is compared as it is.
+func (sc SignCode) Verify(signcode string) bool {
+	if !sc.IsCommitment() {
+		return string(sc) == signcode
+	}
+
+	d := sha256.Sum256([]byte(signcode))
+
+	return string(sc) == SignCodeCommitmentPrefix+hex.EncodeToString(d[:])
+}
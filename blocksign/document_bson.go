@@ -2,29 +2,61 @@ package blocksign
 
 import (
 	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
 	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/hint"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
 func (doc DocumentData) MarshalBSON() ([]byte, error) {
-	return bsonenc.Marshal(bsonenc.MergeBSONM(
-		bsonenc.NewHintedDoc(doc.Hint()),
-		bson.M{
-			"documentinfo": doc.info,
-			"creator":      doc.creator,
-			"title":        doc.title,
-			"size":         doc.size,
-			"signers":      doc.signers,
-		}),
-	)
+	m := bson.M{
+		"documentinfo": doc.info,
+		"creator":      doc.creator,
+		"title":        doc.title,
+		"size":         doc.size,
+		"signers":      doc.signers,
+		"expiry":       doc.expiry,
+		"signingmode":  doc.mode.String(),
+		"threshold":    doc.threshold,
+		"canceled":     doc.canceled,
+	}
+
+	if len(doc.revisions) > 0 {
+		m["revisions"] = doc.revisions
+	}
+
+	if len(doc.metadata) > 0 {
+		m["metadata"] = doc.metadata
+	}
+
+	if len(doc.files) > 0 {
+		m["files"] = doc.files
+	}
+
+	if doc.HasEscrow() {
+		m["escrow"] = doc.escrow
+		m["escrowpayer"] = doc.EscrowPayer()
+	}
+
+	return bsonenc.Marshal(bsonenc.MergeBSONM(bsonenc.NewHintedDoc(doc.Hint()), m))
 }
 
 type DocumentBSONUnpacker struct {
-	DI bson.Raw     `bson:"documentinfo"`
-	CR bson.Raw     `bson:"creator"`
-	TL string       `bson:"title"`
-	SZ currency.Big `bson:"size"`
-	SG bson.Raw     `bson:"signers"`
+	H  hint.Hint           `bson:"_hint"`
+	DI bson.Raw            `bson:"documentinfo"`
+	CR bson.Raw            `bson:"creator"`
+	TL string              `bson:"title"`
+	SZ currency.Big        `bson:"size"`
+	SG bson.Raw            `bson:"signers"`
+	EX *base.Height        `bson:"expiry"`
+	SM string              `bson:"signingmode"`
+	TH uint                `bson:"threshold"`
+	RV bson.Raw            `bson:"revisions"`
+	CN *base.Height        `bson:"canceled"`
+	MD map[string]string   `bson:"metadata"`
+	FS bson.Raw            `bson:"files"`
+	ES bson.Raw            `bson:"escrow"`
+	EP base.AddressDecoder `bson:"escrowpayer"`
 }
 
 func (doc *DocumentData) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
@@ -33,5 +65,5 @@ func (doc *DocumentData) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
 		return err
 	}
 
-	return doc.unpack(enc, udoc.DI, udoc.CR, udoc.TL, udoc.SZ, udoc.SG)
+	return doc.unpack(enc, udoc.H, udoc.DI, udoc.CR, udoc.TL, udoc.SZ, udoc.SG, udoc.EX, udoc.SM, udoc.TH, udoc.RV, udoc.CN, udoc.MD, udoc.FS, udoc.ES, udoc.EP)
 }
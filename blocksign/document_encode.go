@@ -5,17 +5,30 @@ import (
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/util/encoder"
+	"github.com/spikeekips/mitum/util/hint"
 )
 
 func (doc *DocumentData) unpack(
 	enc encoder.Encoder,
+	ht hint.Hint,
 	di []byte,
 	cr []byte, // creator
 	tl string,
 	sz currency.Big,
 	bsg []byte, // signers
+	ex *base.Height,
+	sm string,
+	th uint,
+	brv []byte, // revisions
+	cn *base.Height,
+	md map[string]string,
+	bfs []byte, // files
+	bes []byte, // escrow
+	ep base.AddressDecoder, // escrow payer
 ) error {
 
+	doc.hint = ht
+
 	// unpack document info
 	if hinter, err := enc.Decode(di); err != nil {
 		return err
@@ -54,23 +67,121 @@ func (doc *DocumentData) unpack(
 	}
 	doc.signers = signers
 
+	if ex == nil {
+		doc.expiry = base.NilHeight
+	} else {
+		doc.expiry = *ex
+	}
+
+	// NOTE document without signing mode is signed in parallel
+	if len(sm) < 1 {
+		doc.mode = SigningParallel
+	} else {
+		mode, err := ParseSigningMode(sm)
+		if err != nil {
+			return err
+		}
+		doc.mode = mode
+	}
+
+	doc.threshold = th
+
+	// NOTE document without revisions has never been revised
+	if len(brv) > 0 {
+		hits, err := enc.DecodeSlice(brv)
+		if err != nil {
+			return err
+		}
+
+		revisions := make([]DocRevision, len(hits))
+		for i := range hits {
+			r, ok := hits[i].(DocRevision)
+			if !ok {
+				return errors.Errorf("not DocRevision : %T", hits[i])
+			}
+
+			revisions[i] = r
+		}
+		doc.revisions = revisions
+	}
+
+	if cn == nil {
+		doc.canceled = base.NilHeight
+	} else {
+		doc.canceled = *cn
+	}
+
+	doc.metadata = DocumentMetadata(md).Copy()
+
+	// NOTE document with single file does not have files
+	if len(bfs) > 0 {
+		files, err := decodeDocFiles(enc, bfs)
+		if err != nil {
+			return err
+		}
+		doc.files = files
+	}
+
+	// NOTE document without escrow is not sponsored
+	if len(bes) > 0 {
+		hinter, err := enc.Decode(bes)
+		if err != nil {
+			return err
+		}
+
+		am, ok := hinter.(currency.Amount)
+		if !ok {
+			return errors.Errorf("not currency.Amount: %T", hinter)
+		}
+		doc.escrow = am
+
+		payer, err := ep.Encode(enc)
+		if err != nil {
+			return err
+		}
+		doc.payer = payer
+	}
+
 	return nil
 }
 
 func (ds *DocSign) unpack(
 	enc encoder.Encoder,
+	h hint.Hint,
 	ad base.AddressDecoder, // address
 	sc string,
 	sg bool, // signed
+	st string, // status
+	ht base.Height,
+	wt uint, // weight
 ) error {
 
 	a, err := ad.Encode(enc)
 	if err != nil {
 		return err
 	}
+	ds.hint = h
 	ds.address = a
 	ds.signcode = sc
-	ds.signed = sg
+	ds.weight = wt
+
+	// NOTE docsign without status has only signed flag
+	if len(st) < 1 {
+		ds.status = DocSignPending
+		if sg {
+			ds.status = DocSignSigned
+		}
+		ds.height = base.NilHeight
+
+		return nil
+	}
+
+	status, err := ParseDocSignStatus(st)
+	if err != nil {
+		return err
+	}
+	ds.status = status
+	ds.height = ht
 
 	return nil
 }
@@ -0,0 +1,101 @@
+package blocksign
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+)
+
+// CalculateSigningEscrow returns the amount, which the creator of sponsored
+// document escrows to pay the signing fees of signers.
+func CalculateSigningEscrow(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	it CreateDocumentsItem,
+) (currency.Big, error) {
+	if !it.Sponsored() || cp == nil || len(it.Signers()) < 1 {
+		return currency.ZeroBig, nil
+	}
+
+	feeer, found := cp.Feeer(it.Currency())
+	if !found {
+		return currency.ZeroBig, errors.Errorf("unknown currency id found, %q", it.Currency())
+	}
+
+	fee, err := po.FeePolicy(it.Currency()).SizeFee(feeer, it.Size())
+	if err != nil {
+		return currency.ZeroBig, err
+	}
+
+	return fee.MulInt64(int64(len(it.Signers()))), nil
+}
+
+// escrowBalances collects the balance states, which are changed by the fee of
+// sender and the escrow of escrow payers; the same balance state is
+// changed only once.
+type escrowBalances map[string]currency.AmountState
+
+func newEscrowBalances(
+	sb map[currency.CurrencyID]currency.AmountState,
+	required map[currency.CurrencyID][2]currency.Big,
+) escrowBalances {
+	eb := escrowBalances{}
+	for cid := range required {
+		rq := required[cid]
+		st := sb[cid].Sub(rq[0]).AddFee(rq[1])
+		eb[st.Key()] = st
+	}
+
+	return eb
+}
+
+// add refunds the escrow to the escrow payer balance; fee is the signing fee, paid
+// from the escrow.
+func (eb escrowBalances) add(st currency.AmountState, refund, fee currency.Big) {
+	if i, found := eb[st.Key()]; found {
+		st = i
+	}
+
+	if refund.OverZero() {
+		st = st.Add(refund)
+	}
+
+	if fee.OverZero() {
+		st = st.AddFee(fee)
+	}
+
+	eb[st.Key()] = st
+}
+
+func (eb escrowBalances) states() []state.State {
+	keys := make([]string, 0, len(eb))
+	for k := range eb {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sts := make([]state.State, len(keys))
+	for i := range keys {
+		sts[i] = eb[keys[i]]
+	}
+
+	return sts
+}
+
+// loadEscrowBalance loads the balance state of escrow payer for the currency of
+// escrow.
+func loadEscrowBalance(
+	payer base.Address,
+	cid currency.CurrencyID,
+	getState func(key string) (state.State, bool, error),
+) (currency.AmountState, error) {
+	st, err := existsState(currency.StateKeyBalance(payer, cid), "balance of escrow payer", getState)
+	if err != nil {
+		return currency.AmountState{}, err
+	}
+
+	return currency.NewAmountState(st, cid), nil
+}
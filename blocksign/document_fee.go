@@ -0,0 +1,178 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/util"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DocumentFeePolicy decides the fee of document by it's size and signers. The
+// feeer of currency calculates the fee against the size in the unit of
+// sizeUnit, so with currency.RatioFeeer the fee grows with the size; zero
+// sizeUnit charges the flat fee of feeer regardless of size. signerFee is
+// charged per signer of new document. DocumentFeePolicy of currency is held by
+// DocumentPolicy.
+type DocumentFeePolicy struct {
+	sizeUnit  currency.Big
+	signerFee currency.Big
+}
+
+// DefaultDocumentFeePolicy charges the flat fee of feeer.
+var DefaultDocumentFeePolicy = NewDocumentFeePolicy(currency.ZeroBig, currency.ZeroBig)
+
+func NewDocumentFeePolicy(sizeUnit, signerFee currency.Big) DocumentFeePolicy {
+	return DocumentFeePolicy{sizeUnit: sizeUnit, signerFee: signerFee}
+}
+
+func (po DocumentFeePolicy) SizeUnit() currency.Big {
+	return po.sizeUnit
+}
+
+func (po DocumentFeePolicy) SignerFee() currency.Big {
+	return po.signerFee
+}
+
+func (po DocumentFeePolicy) Bytes() []byte {
+	return util.ConcatBytesSlice(po.sizeUnit.Bytes(), po.signerFee.Bytes())
+}
+
+func (po DocumentFeePolicy) IsValid([]byte) error {
+	if !po.sizeUnit.OverNil() {
+		return errors.Errorf("size unit is negative number, %v", po.sizeUnit)
+	}
+
+	if !po.signerFee.OverNil() {
+		return errors.Errorf("signer fee is negative number, %v", po.signerFee)
+	}
+
+	return nil
+}
+
+// SizeFee returns the fee of document, which has the given size.
+func (po DocumentFeePolicy) SizeFee(feeer currency.Feeer, size currency.Big) (currency.Big, error) {
+	amount := currency.ZeroBig
+	if po.sizeUnit.OverZero() && size.OverZero() {
+		// NOTE the partial unit is charged as one unit
+		amount = size.Add(po.sizeUnit).Sub(currency.NewBig(1)).Div(po.sizeUnit)
+	}
+
+	return feeer.Fee(amount)
+}
+
+// SignersFee returns the fee of signers; currency without fee does not charge
+// it.
+func (po DocumentFeePolicy) SignersFee(feeer currency.Feeer, n int) currency.Big {
+	if feeer.Type() == currency.FeeerNil || n < 1 {
+		return currency.ZeroBig
+	}
+
+	return po.signerFee.MulInt64(int64(n))
+}
+
+// CreateFee returns the fee of new document; the size fee is charged per
+// file.
+func (po DocumentFeePolicy) CreateFee(feeer currency.Feeer, it CreateDocumentsItem) (currency.Big, error) {
+	fee := currency.ZeroBig
+
+	files := it.Files()
+	for i := range files {
+		k, err := po.SizeFee(feeer, files[i].Size())
+		if err != nil {
+			return currency.ZeroBig, err
+		}
+		fee = fee.Add(k)
+	}
+
+	return fee.Add(po.SignersFee(feeer, len(it.Signers()))), nil
+}
+
+// CalculateFlatItemsFee returns the amounts, which are required to sender
+// balance for the items charged by the fee of zero amount regardless of
+// document, like rejecting or transferring document; cids are the currency ids
+// of items.
+func CalculateFlatItemsFee(
+	cp *currency.CurrencyPool,
+	cids []currency.CurrencyID,
+) (map[currency.CurrencyID][2]currency.Big, error) {
+	required := map[currency.CurrencyID][2]currency.Big{}
+
+	for i := range cids {
+		rq := [2]currency.Big{currency.ZeroBig, currency.ZeroBig}
+		if k, found := required[cids[i]]; found {
+			rq = k
+		}
+
+		if cp == nil {
+			required[cids[i]] = rq
+
+			continue
+		}
+
+		feeer, found := cp.Feeer(cids[i])
+		if !found {
+			return nil, errors.Errorf("unknown currency id found, %q", cids[i])
+		}
+
+		switch k, err := feeer.Fee(currency.ZeroBig); {
+		case err != nil:
+			return nil, err
+		case !k.OverZero():
+			required[cids[i]] = rq
+		default:
+			required[cids[i]] = [2]currency.Big{rq[0].Add(k), rq[1].Add(k)}
+		}
+	}
+
+	return required, nil
+}
+
+type DocumentFeePolicyJSONPacker struct {
+	SU currency.Big `json:"size_unit"`
+	SF currency.Big `json:"signer_fee"`
+}
+
+func (po DocumentFeePolicy) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocumentFeePolicyJSONPacker{
+		SU: po.sizeUnit,
+		SF: po.signerFee,
+	})
+}
+
+func (po *DocumentFeePolicy) UnmarshalJSON(b []byte) error {
+	var upo DocumentFeePolicyJSONPacker
+	if err := jsonenc.Unmarshal(b, &upo); err != nil {
+		return err
+	}
+
+	po.sizeUnit = upo.SU
+	po.signerFee = upo.SF
+
+	return nil
+}
+
+func (po DocumentFeePolicy) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bson.M{
+		"size_unit":  po.sizeUnit,
+		"signer_fee": po.signerFee,
+	})
+}
+
+type DocumentFeePolicyBSONUnpacker struct {
+	SU currency.Big `bson:"size_unit"`
+	SF currency.Big `bson:"signer_fee"`
+}
+
+func (po *DocumentFeePolicy) UnmarshalBSON(b []byte) error {
+	var upo DocumentFeePolicyBSONUnpacker
+	if err := bsonenc.Unmarshal(b, &upo); err != nil {
+		return err
+	}
+
+	po.sizeUnit = upo.SU
+	po.signerFee = upo.SF
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/stretchr/testify/suite"
+)
+
+type testDocumentFeePolicy struct {
+	suite.Suite
+}
+
+func (t *testDocumentFeePolicy) TestDefault() {
+	feeer := currency.NewFixedFeeer(NewTestAddress(), currency.NewBig(3))
+
+	fee, err := DefaultDocumentFeePolicy.SizeFee(feeer, currency.NewBig(1000))
+	t.NoError(err)
+	t.True(fee.Equal(currency.NewBig(3)))
+
+	t.True(DefaultDocumentFeePolicy.SignersFee(feeer, 3).IsZero())
+}
+
+func (t *testDocumentFeePolicy) TestSizeFee() {
+	po := NewDocumentFeePolicy(currency.NewBig(100), currency.ZeroBig)
+	feeer := currency.NewRatioFeeer(NewTestAddress(), 0.5, currency.NewBig(1), currency.NewBig(50))
+
+	for _, c := range [][2]int64{
+		{0, 1},      // min
+		{1, 1},      // partial unit
+		{400, 2},    // 4 units
+		{401, 2},    // 5 units, partial unit
+		{1000, 5},   // 10 units
+		{20000, 50}, // max
+	} {
+		fee, err := po.SizeFee(feeer, currency.NewBig(c[0]))
+		t.NoError(err)
+		t.True(fee.Equal(currency.NewBig(c[1])), "size=%d fee=%v", c[0], fee)
+	}
+}
+
+func (t *testDocumentFeePolicy) TestSignersFee() {
+	po := NewDocumentFeePolicy(currency.ZeroBig, currency.NewBig(2))
+
+	t.True(po.SignersFee(currency.NewFixedFeeer(NewTestAddress(), currency.NewBig(1)), 3).Equal(currency.NewBig(6)))
+	t.True(po.SignersFee(currency.NewFixedFeeer(NewTestAddress(), currency.NewBig(1)), 0).IsZero())
+	t.True(po.SignersFee(currency.NewNilFeeer(), 3).IsZero())
+}
+
+func (t *testDocumentFeePolicy) TestCreateFee() {
+	po := NewDocumentFeePolicy(currency.NewBig(10), currency.NewBig(5))
+	feeer := currency.NewRatioFeeer(NewTestAddress(), 1, currency.NewBig(1), currency.ZeroBig)
+
+	files := []DocFile{
+		NewDocFile(MustFileHash("ABCD"), "main.pdf", currency.NewBig(95)),
+		NewDocFile(MustFileHash("EFGH"), "appendix.pdf", currency.NewBig(20)),
+	}
+
+	signers := []base.Address{NewTestAddress(), NewTestAddress()}
+	item := NewCreateDocumentsItemMultiFiles(currency.NewBig(0), "user0", "title", files, signers, []string{"a", "b"}, currency.CurrencyID("SHOWME"))
+
+	// NOTE 10 units + 2 units + 2 signers
+	fee, err := po.CreateFee(feeer, item)
+	t.NoError(err)
+	t.True(fee.Equal(currency.NewBig(22)), "fee=%v", fee)
+}
+
+func (t *testDocumentFeePolicy) TestInvalid() {
+	t.Error(NewDocumentFeePolicy(currency.NewBig(-1), currency.ZeroBig).IsValid(nil))
+	t.Error(NewDocumentFeePolicy(currency.ZeroBig, currency.NewBig(-1)).IsValid(nil))
+
+	po := NewDocumentPolicy(false).
+		WithFeePolicy(currency.CurrencyID("SHOWME"), NewDocumentFeePolicy(currency.NewBig(-1), currency.ZeroBig))
+	err := po.IsValid(nil)
+	t.Contains(err.Error(), "invalid document fee policy")
+}
+
+func (t *testDocumentFeePolicy) TestByCurrency() {
+	cid := currency.CurrencyID("FEEME")
+	fee := NewDocumentFeePolicy(currency.NewBig(1024), currency.NewBig(1))
+
+	t.Equal(DefaultDocumentFeePolicy, DefaultDocumentPolicy.FeePolicy(cid))
+
+	po := DefaultDocumentPolicy.WithFeePolicy(cid, fee)
+	t.Equal(fee, po.FeePolicy(cid))
+	t.Equal(DefaultDocumentFeePolicy, po.FeePolicy(currency.CurrencyID("SHOWME")))
+
+	// NOTE DefaultDocumentPolicy is not changed
+	t.Equal(DefaultDocumentFeePolicy, DefaultDocumentPolicy.FeePolicy(cid))
+}
+
+func (t *testDocumentFeePolicy) TestFlatItemsFee() {
+	cid := currency.CurrencyID("SHOWME")
+	de := currency.NewCurrencyDesign(
+		currency.NewAmount(currency.NewBig(99), cid),
+		NewTestAddress(),
+		currency.NewCurrencyPolicy(currency.ZeroBig, currency.NewFixedFeeer(NewTestAddress(), currency.NewBig(3))),
+	)
+
+	st, err := state.NewStateV0(currency.StateKeyCurrencyDesign(cid), nil, base.NilHeight)
+	t.NoError(err)
+	nst, err := currency.SetStateCurrencyDesignValue(st, de)
+	t.NoError(err)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(nst))
+
+	// NOTE fee is charged per item regardless of document
+	required, err := CalculateFlatItemsFee(cp, []currency.CurrencyID{cid, cid})
+	t.NoError(err)
+	t.True(required[cid][0].Equal(currency.NewBig(6)))
+	t.True(required[cid][1].Equal(currency.NewBig(6)))
+
+	required, err = CalculateFlatItemsFee(nil, []currency.CurrencyID{cid})
+	t.NoError(err)
+	t.True(required[cid][1].IsZero())
+
+	_, err = CalculateFlatItemsFee(cp, []currency.CurrencyID{currency.CurrencyID("FINDME")})
+	t.Contains(err.Error(), "unknown currency id")
+}
+
+func TestDocumentFeePolicy(t *testing.T) {
+	suite.Run(t, new(testDocumentFeePolicy))
+}
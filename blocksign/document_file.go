@@ -0,0 +1,174 @@
+package blocksign
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	DocFileType = hint.Type("mitum-blocksign-document-file")
+	DocFileHint = hint.NewHint(DocFileType, "v0.0.1")
+)
+
+var MaxDocFileNameLength = 255
+
+// DocFile is the attached file of document, which has multiple files.
+type DocFile struct {
+	filehash FileHash
+	name     string
+	size     currency.Big
+}
+
+func NewDocFile(filehash FileHash, name string, size currency.Big) DocFile {
+	return DocFile{
+		filehash: filehash,
+		name:     name,
+		size:     size,
+	}
+}
+
+func (df DocFile) Hint() hint.Hint {
+	return DocFileHint
+}
+
+func (df DocFile) Bytes() []byte {
+	return util.ConcatBytesSlice(
+		df.filehash.Bytes(),
+		[]byte(df.name),
+		df.size.Bytes(),
+	)
+}
+
+func (df DocFile) IsValid([]byte) error {
+	// NOTE the FileHash of new file is checked by the items
+	if err := df.filehash.IsValidLegacy(); err != nil {
+		return err
+	}
+
+	switch {
+	case len(df.name) < 1:
+		return errors.Errorf("empty file name")
+	case len(df.name) > MaxDocFileNameLength:
+		return errors.Errorf("file name too long, %d > %d", len(df.name), MaxDocFileNameLength)
+	case strings.ContainsAny(df.name, `/\`):
+		return errors.Errorf("file name has path separator, %q", df.name)
+	case strings.IndexFunc(df.name, unicode.IsControl) >= 0:
+		return errors.Errorf("file name has control character, %q", df.name)
+	}
+
+	if !df.size.OverNil() {
+		return errors.Errorf("file size is negative number, %v", df.size)
+	}
+
+	return nil
+}
+
+func (df DocFile) FileHash() FileHash {
+	return df.filehash
+}
+
+func (df DocFile) Name() string {
+	return df.name
+}
+
+func (df DocFile) Size() currency.Big {
+	return df.size
+}
+
+func (df DocFile) Equal(b DocFile) bool {
+	return df.filehash.Equal(b.filehash) && df.name == b.name && equalBig(df.size, b.size)
+}
+
+type DocFileJSONPacker struct {
+	jsonenc.HintedHead
+	FH FileHash     `json:"filehash"`
+	NM string       `json:"name"`
+	SZ currency.Big `json:"size"`
+}
+
+func (df DocFile) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocFileJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(df.Hint()),
+		FH:         df.filehash,
+		NM:         df.name,
+		SZ:         df.size,
+	})
+}
+
+type DocFileJSONUnpacker struct {
+	FH string       `json:"filehash"`
+	NM string       `json:"name"`
+	SZ currency.Big `json:"size"`
+}
+
+func (df *DocFile) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var udf DocFileJSONUnpacker
+	if err := enc.Unmarshal(b, &udf); err != nil {
+		return err
+	}
+
+	df.filehash = FileHash(udf.FH)
+	df.name = udf.NM
+	df.size = udf.SZ
+
+	return nil
+}
+
+func (df DocFile) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(df.Hint()),
+		bson.M{
+			"filehash": df.filehash,
+			"name":     df.name,
+			"size":     df.size,
+		}),
+	)
+}
+
+type DocFileBSONUnpacker struct {
+	FH string       `bson:"filehash"`
+	NM string       `bson:"name"`
+	SZ currency.Big `bson:"size"`
+}
+
+func (df *DocFile) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var udf DocFileBSONUnpacker
+	if err := enc.Unmarshal(b, &udf); err != nil {
+		return err
+	}
+
+	df.filehash = FileHash(udf.FH)
+	df.name = udf.NM
+	df.size = udf.SZ
+
+	return nil
+}
+
+// decodeDocFiles decodes the slice of DocFile.
+func decodeDocFiles(enc encoder.Encoder, b []byte) ([]DocFile, error) {
+	hits, err := enc.DecodeSlice(b)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]DocFile, len(hits))
+	for i := range hits {
+		f, ok := hits[i].(DocFile)
+		if !ok {
+			return nil, errors.Errorf("not DocFile : %T", hits[i])
+		}
+
+		files[i] = f
+	}
+
+	return files, nil
+}
@@ -57,25 +57,59 @@ func (div DocumentInventory) IsEmpty() bool {
 }
 
 func (div DocumentInventory) IsValid([]byte) error {
+	ids := map[string]bool{}
 	for i := range div.documents {
 		if err := div.documents[i].IsValid(nil); err != nil {
 			return err
 		}
+
+		k := div.documents[i].idx.String()
+		if ids[k] {
+			return errors.Errorf("duplicated document id, %v", k)
+		}
+		ids[k] = true
 	}
+
 	return nil
 }
 
+// Equal compares the documents regardless of order; the documents of both
+// inventories are not sorted.
 func (div DocumentInventory) Equal(b DocumentInventory) bool {
-	div.Sort(true)
-	b.Sort(true)
-	for i := range div.documents {
-		if !div.documents[i].Equal(b.documents[i]) {
+	if len(div.documents) != len(b.documents) {
+		return false
+	}
+
+	as := div.sorted()
+	bs := b.sorted()
+	for i := range as {
+		if !as[i].Equal(bs[i]) {
 			return false
 		}
 	}
+
 	return true
 }
 
+func (div DocumentInventory) sorted() []DocInfo {
+	docs := make([]DocInfo, len(div.documents))
+	copy(docs, div.documents)
+
+	// NOTE the empty document id comes first
+	sort.Slice(docs, func(i, j int) bool {
+		switch a, b := docs[i].idx, docs[j].idx; {
+		case b.Int == nil:
+			return false
+		case a.Int == nil:
+			return true
+		default:
+			return a.Compare(b) < 0
+		}
+	})
+
+	return docs
+}
+
 func (div *DocumentInventory) Sort(ascending bool) {
 	sort.Slice(div.documents, func(i, j int) bool {
 		if ascending {
@@ -149,7 +183,7 @@ func (div DocumentInventory) MarshalJSON() ([]byte, error) {
 }
 
 type DocumentInventoryJSONUnpacker struct {
-	DI json.RawMessage `json:"address"`
+	DI json.RawMessage `json:"documents"`
 }
 
 func (div *DocumentInventory) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
@@ -0,0 +1,314 @@
+package blocksign
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+)
+
+// DocumentInventoryPageSize is the number of document ids, which one page of
+// document inventory covers. The documents of address are stored in the pages
+// by their document ids, so the document is found by loading only one page and
+// the size of page state is limited.
+var DocumentInventoryPageSize = currency.NewBig(100)
+
+// DocumentInventoryPage returns the page of document inventory, which document
+// id belongs to.
+func DocumentInventoryPage(id currency.Big) currency.Big {
+	return id.Div(DocumentInventoryPageSize)
+}
+
+// documentPages collects the pages of document inventories, which are loaded
+// and changed by operation; the same page state is changed only once. With
+// legacy key, the legacy inventory, which has all the documents of address in
+// one state, is also looked up and migrate moves its documents to the pages.
+// The documents of inventories are indexed by their document ids, so the
+// document is found without scanning inventory.
+type documentPages struct {
+	pageKey   func(base.Address, currency.Big) string
+	legacyKey func(base.Address) string
+	sts       map[string]state.State
+	invs      map[string]DocumentInventory
+	ids       map[string]map[string]int // position of document by document id
+	changed   map[string]bool
+}
+
+func newDocumentPages(
+	pageKey func(base.Address, currency.Big) string,
+	legacyKey func(base.Address) string,
+) *documentPages {
+	return &documentPages{
+		pageKey:   pageKey,
+		legacyKey: legacyKey,
+		sts:       map[string]state.State{},
+		invs:      map[string]DocumentInventory{},
+		ids:       map[string]map[string]int{},
+		changed:   map[string]bool{},
+	}
+}
+
+// newOwnerDocumentPages is for the documents owned by address.
+func newOwnerDocumentPages() *documentPages {
+	return newDocumentPages(StateKeyDocumentsPage, StateKeyDocuments)
+}
+
+// newPendingDocumentPages is for the documents, which signer has to sign.
+func newPendingDocumentPages() *documentPages {
+	return newDocumentPages(StateKeyPendingDocuments, nil)
+}
+
+// load loads the page of document id with the legacy inventory of address.
+func (dp *documentPages) load(
+	a base.Address,
+	id currency.Big,
+	getState func(key string) (state.State, bool, error),
+) error {
+	if err := dp.loadKey(dp.pageKey(a, DocumentInventoryPage(id)), getState); err != nil {
+		return err
+	}
+
+	if dp.legacyKey == nil {
+		return nil
+	}
+
+	return dp.loadKey(dp.legacyKey(a), getState)
+}
+
+func (dp *documentPages) loadKey(k string, getState func(key string) (state.State, bool, error)) error {
+	if _, found := dp.sts[k]; found {
+		return nil
+	}
+
+	st, found, err := getState(k)
+	switch {
+	case err != nil:
+		return err
+	case !found:
+		dp.invs[k] = NewDocumentInventory(nil)
+	default:
+		inv, err := StateDocumentsValue(st)
+		if err != nil {
+			return err
+		}
+
+		// NOTE copy documents not to modify the value of loaded state
+		docs := make([]DocInfo, len(inv.Documents()))
+		copy(docs, inv.Documents())
+		dp.invs[k] = NewDocumentInventory(docs)
+	}
+
+	ids := map[string]int{}
+	for i, info := range dp.invs[k].documents {
+		ids[info.Index().String()] = i
+	}
+	dp.ids[k] = ids
+
+	dp.sts[k] = st
+
+	return nil
+}
+
+func (dp *documentPages) has(k string, id currency.Big) bool {
+	_, found := dp.ids[k][id.String()]
+
+	return found
+}
+
+// appendKey appends document to inventory of key; the existence of document
+// should be checked before.
+func (dp *documentPages) appendKey(k string, info DocInfo) error {
+	if err := info.IsValid(nil); err != nil {
+		return err
+	}
+
+	inv := dp.invs[k]
+	dp.ids[k][info.Index().String()] = len(inv.documents)
+	inv.documents = append(inv.documents, info)
+
+	dp.invs[k] = inv
+	dp.changed[k] = true
+
+	return nil
+}
+
+// migrate moves all the documents in the legacy inventory of address to the
+// pages and empties the legacy inventory.
+func (dp *documentPages) migrate(a base.Address, getState func(key string) (state.State, bool, error)) error {
+	if dp.legacyKey == nil {
+		return nil
+	}
+
+	k := dp.legacyKey(a)
+	if err := dp.loadKey(k, getState); err != nil {
+		return err
+	}
+
+	legacy := dp.invs[k]
+	if legacy.IsEmpty() {
+		return nil
+	}
+
+	for _, info := range legacy.Documents() {
+		pk := dp.pageKey(a, DocumentInventoryPage(info.Index()))
+		if err := dp.loadKey(pk, getState); err != nil {
+			return err
+		}
+
+		if dp.has(pk, info.Index()) {
+			continue
+		}
+
+		if err := dp.appendKey(pk, info); err != nil {
+			return err
+		}
+	}
+
+	dp.invs[k] = NewDocumentInventory(nil)
+	dp.ids[k] = map[string]int{}
+	dp.changed[k] = true
+
+	return nil
+}
+
+// inventory returns the inventory, which has the document of address.
+func (dp *documentPages) inventory(a base.Address, id currency.Big) (string, bool) {
+	if k := dp.pageKey(a, DocumentInventoryPage(id)); dp.has(k, id) {
+		return k, true
+	}
+
+	if dp.legacyKey != nil {
+		if k := dp.legacyKey(a); dp.has(k, id) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func (dp *documentPages) exists(a base.Address, id currency.Big) bool {
+	_, found := dp.inventory(a, id)
+
+	return found
+}
+
+func (dp *documentPages) get(a base.Address, id currency.Big) (DocInfo, error) {
+	k, found := dp.inventory(a, id)
+	if !found {
+		return DocInfo{}, errors.Errorf("document not found in document inventory of %v, %v", a, id)
+	}
+
+	return dp.invs[k].documents[dp.ids[k][id.String()]], nil
+}
+
+func (dp *documentPages) append(a base.Address, info DocInfo) error {
+	k := dp.pageKey(a, DocumentInventoryPage(info.Index()))
+
+	switch _, found := dp.invs[k]; {
+	case !found:
+		return errors.Errorf("page of document inventory not loaded, %v", info.Index())
+	case dp.exists(a, info.Index()):
+		return errors.Errorf("document id %v already exists in document inventory", info.Index())
+	}
+
+	return dp.appendKey(k, info)
+}
+
+func (dp *documentPages) remove(a base.Address, id currency.Big) error {
+	k, found := dp.inventory(a, id)
+	if !found {
+		return errors.Errorf("document id %v not found in document inventory", id)
+	}
+
+	// NOTE the last document is moved to the position of removed one
+	inv := dp.invs[k]
+	ids := dp.ids[k]
+	i, last := ids[id.String()], len(inv.documents)-1
+	if i != last {
+		inv.documents[i] = inv.documents[last]
+		ids[inv.documents[i].Index().String()] = i
+	}
+	inv.documents = inv.documents[:last]
+	delete(ids, id.String())
+
+	dp.invs[k] = inv
+	dp.changed[k] = true
+
+	return nil
+}
+
+// set appends document; the document, which already exists, is replaced, like
+// the revised document.
+func (dp *documentPages) set(a base.Address, info DocInfo) error {
+	if dp.exists(a, info.Index()) {
+		if err := dp.remove(a, info.Index()); err != nil {
+			return err
+		}
+	}
+
+	return dp.append(a, info)
+}
+
+// discard removes document if it exists.
+func (dp *documentPages) discard(a base.Address, id currency.Big) error {
+	if !dp.exists(a, id) {
+		return nil
+	}
+
+	return dp.remove(a, id)
+}
+
+// keys returns the keys of the loaded inventories, which may be changed by
+// operation.
+func (dp *documentPages) keys() []string {
+	keys := make([]string, 0, len(dp.sts))
+	for k := range dp.sts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (dp *documentPages) states() ([]state.State, error) {
+	keys := make([]string, 0, len(dp.changed))
+	for k := range dp.changed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sts := make([]state.State, len(keys))
+	for i := range keys {
+		// NOTE sort the copy not to break the positions of documents
+		docs := make([]DocInfo, len(dp.invs[keys[i]].documents))
+		copy(docs, dp.invs[keys[i]].documents)
+
+		inv := NewDocumentInventory(docs)
+		inv.Sort(true)
+
+		st, err := SetStateDocumentsValue(dp.sts[keys[i]], inv)
+		if err != nil {
+			return nil, err
+		}
+		sts[i] = st
+	}
+
+	return sts, nil
+}
+
+// loadOwnerDocument returns the document info from the document inventory of
+// owner.
+func loadOwnerDocument(
+	owner base.Address,
+	id currency.Big,
+	getState func(key string) (state.State, bool, error),
+) (DocInfo, error) {
+	dp := newOwnerDocumentPages()
+	if err := dp.load(owner, id, getState); err != nil {
+		return DocInfo{}, err
+	}
+
+	return dp.get(owner, id)
+}
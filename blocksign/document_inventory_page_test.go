@@ -0,0 +1,312 @@
+package blocksign
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/stretchr/testify/suite"
+)
+
+func newTestGetState(sts ...state.State) func(string) (state.State, bool, error) {
+	m := map[string]state.State{}
+	for i := range sts {
+		m[sts[i].Key()] = sts[i]
+	}
+
+	return func(key string) (state.State, bool, error) {
+		if st, found := m[key]; found {
+			return st, true, nil
+		}
+
+		st, err := state.NewStateV0(key, nil, base.NilHeight)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return st, false, nil
+	}
+}
+
+func newTestDocInfos(n int) []DocInfo {
+	docs := make([]DocInfo, n)
+	for i := range docs {
+		docs[i] = MustNewDocInfo(int64(i), MustFileHash(fmt.Sprintf("FH%06d", i)))
+	}
+
+	return docs
+}
+
+type testDocumentPages struct {
+	baseTestOperationProcessor
+}
+
+func (t *testDocumentPages) TestPage() {
+	a := NewTestAddress()
+
+	t.True(DocumentInventoryPage(currency.NewBig(0)).Equal(currency.ZeroBig))
+	t.True(DocumentInventoryPage(currency.NewBig(99)).Equal(currency.ZeroBig))
+	t.True(DocumentInventoryPage(currency.NewBig(100)).Equal(currency.NewBig(1)))
+	t.True(DocumentInventoryPage(currency.NewBig(250)).Equal(currency.NewBig(2)))
+
+	k := StateKeyDocumentsPage(a, currency.NewBig(1))
+	t.NotEqual(StateKeyDocumentsPage(a, currency.ZeroBig), k)
+	t.True(IsStateDocumentsPageKey(k))
+	t.False(IsStateDocumentsKey(k))
+	t.False(IsStateDocumentsPageKey(StateKeyDocuments(a)))
+}
+
+func (t *testDocumentPages) TestAppend() {
+	a := NewTestAddress()
+	info := MustNewDocInfo(150, MustFileHash("ABCD"))
+
+	dp := newOwnerDocumentPages()
+
+	// page is not loaded
+	err := dp.append(a, info)
+	t.Error(err)
+	t.Contains(err.Error(), "page of document inventory not loaded")
+
+	t.NoError(dp.load(a, info.Index(), newTestGetState()))
+	t.False(dp.exists(a, info.Index()))
+
+	t.NoError(dp.append(a, info))
+	t.True(dp.exists(a, info.Index()))
+
+	err = dp.append(a, info)
+	t.Error(err)
+	t.Contains(err.Error(), "already exists")
+
+	sts, err := dp.states()
+	t.NoError(err)
+	t.Equal(1, len(sts))
+	t.Equal(StateKeyDocumentsPage(a, currency.NewBig(1)), sts[0].Key())
+
+	inv, err := StateDocumentsValue(sts[0])
+	t.NoError(err)
+	t.Equal(1, len(inv.Documents()))
+	t.True(inv.Documents()[0].Equal(info))
+}
+
+func (t *testDocumentPages) TestRemove() {
+	a := NewTestAddress()
+	docs := newTestDocInfos(3)
+
+	st := t.newStateDocuments(a, docs[0])
+	inv, _ := StateDocumentsValue(st)
+	t.NoError(inv.Append(docs[1]))
+	st, err := SetStateDocumentsValue(st, inv)
+	t.NoError(err)
+
+	dp := newOwnerDocumentPages()
+	t.NoError(dp.load(a, docs[0].Index(), newTestGetState(st)))
+
+	t.NoError(dp.remove(a, docs[0].Index()))
+	t.False(dp.exists(a, docs[0].Index()))
+	t.True(dp.exists(a, docs[1].Index()))
+
+	err = dp.remove(a, docs[2].Index())
+	t.Error(err)
+	t.Contains(err.Error(), "not found in document inventory")
+
+	// NOTE the loaded state is not modified
+	oinv, err := StateDocumentsValue(st)
+	t.NoError(err)
+	t.Equal(2, len(oinv.Documents()))
+}
+
+func (t *testDocumentPages) TestRemoveAndAppend() {
+	a := NewTestAddress()
+	docs := newTestDocInfos(5)
+
+	dp := newOwnerDocumentPages()
+	t.NoError(dp.load(a, docs[0].Index(), newTestGetState()))
+
+	for i := range docs {
+		t.NoError(dp.append(a, docs[i]))
+	}
+
+	// NOTE the moved document is still found by it's document id
+	t.NoError(dp.remove(a, docs[1].Index()))
+	t.NoError(dp.remove(a, docs[4].Index()))
+	t.NoError(dp.append(a, docs[1]))
+
+	for _, i := range []int{0, 1, 2, 3} {
+		info, err := dp.get(a, docs[i].Index())
+		t.NoError(err)
+		t.True(info.Equal(docs[i]))
+	}
+	t.False(dp.exists(a, docs[4].Index()))
+
+	sts, err := dp.states()
+	t.NoError(err)
+	t.Equal(1, len(sts))
+
+	inv, err := StateDocumentsValue(sts[0])
+	t.NoError(err)
+	t.Equal(docs[:4], inv.Documents())
+
+	// NOTE states does not break the index of documents
+	t.NoError(dp.remove(a, docs[3].Index()))
+	info, err := dp.get(a, docs[1].Index())
+	t.NoError(err)
+	t.True(info.Equal(docs[1]))
+}
+
+func (t *testDocumentPages) TestLegacy() {
+	a := NewTestAddress()
+	docs := []DocInfo{MustNewDocInfo(3, MustFileHash("ABCD")), MustNewDocInfo(150, MustFileHash("EFGH"))}
+
+	getState := newTestGetState(t.newStateLegacyDocuments(a, docs))
+
+	// document in legacy inventory is found without migration
+	info, err := loadOwnerDocument(a, docs[1].Index(), getState)
+	t.NoError(err)
+	t.True(info.Equal(docs[1]))
+
+	_, err = loadOwnerDocument(a, currency.NewBig(4), getState)
+	t.Error(err)
+	t.Contains(err.Error(), "document not found in document inventory")
+}
+
+func (t *testDocumentPages) TestMigrate() {
+	a := NewTestAddress()
+	docs := []DocInfo{MustNewDocInfo(3, MustFileHash("ABCD")), MustNewDocInfo(150, MustFileHash("EFGH"))}
+
+	legacy := t.newStateLegacyDocuments(a, docs)
+
+	dp := newOwnerDocumentPages()
+	t.NoError(dp.migrate(a, newTestGetState(legacy)))
+
+	for i := range docs {
+		t.True(dp.exists(a, docs[i].Index()))
+	}
+
+	sts, err := dp.states()
+	t.NoError(err)
+	t.Equal(3, len(sts))
+
+	invs := map[string]DocumentInventory{}
+	for i := range sts {
+		inv, err := StateDocumentsValue(sts[i])
+		t.NoError(err)
+		invs[sts[i].Key()] = inv
+	}
+
+	t.True(invs[StateKeyDocuments(a)].IsEmpty())
+
+	inv0 := invs[StateKeyDocumentsPage(a, currency.ZeroBig)]
+	t.Equal(1, len(inv0.Documents()))
+	t.True(inv0.Documents()[0].Equal(docs[0]))
+
+	inv1 := invs[StateKeyDocumentsPage(a, currency.NewBig(1))]
+	t.Equal(1, len(inv1.Documents()))
+	t.True(inv1.Documents()[0].Equal(docs[1]))
+
+	// migrated legacy inventory is not migrated again
+	nlegacy, err := SetStateDocumentsValue(legacy, NewDocumentInventory(nil))
+	t.NoError(err)
+
+	dp = newOwnerDocumentPages()
+	t.NoError(dp.migrate(a, newTestGetState(nlegacy)))
+
+	sts, err = dp.states()
+	t.NoError(err)
+	t.Empty(sts)
+}
+
+func TestDocumentPages(t *testing.T) {
+	suite.Run(t, new(testDocumentPages))
+}
+
+func benchmarkDocumentInventoryStates(n int) (base.Address, state.State, []state.State) {
+	a := NewTestAddress()
+	docs := newTestDocInfos(n)
+
+	value, _ := state.NewHintedValue(NewDocumentInventory(docs))
+	legacy, _ := state.NewStateV0(StateKeyDocuments(a), value, base.NilHeight)
+
+	dp := newOwnerDocumentPages()
+	if err := dp.migrate(a, newTestGetState(legacy)); err != nil {
+		panic(err)
+	}
+
+	pages, err := dp.states()
+	if err != nil {
+		panic(err)
+	}
+
+	return a, legacy, pages
+}
+
+func BenchmarkDocumentInventoryExistsLegacy(b *testing.B) {
+	_, legacy, _ := benchmarkDocumentInventoryStates(10000)
+	inv, _ := StateDocumentsValue(legacy)
+	id := currency.NewBig(9999)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !inv.Exists(id) {
+			b.Fatal("document not found")
+		}
+	}
+}
+
+func BenchmarkDocumentInventoryExistsPaged(b *testing.B) {
+	a, _, pages := benchmarkDocumentInventoryStates(10000)
+	getState := newTestGetState(pages...)
+	id := currency.NewBig(9999)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := loadOwnerDocument(a, id, getState); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDocumentInventoryAppendLegacy(b *testing.B) {
+	_, legacy, _ := benchmarkDocumentInventoryStates(10000)
+	info := MustNewDocInfo(10000, MustFileHash("NEWDOC"))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		inv, _ := StateDocumentsValue(legacy)
+		docs := make([]DocInfo, len(inv.Documents()))
+		copy(docs, inv.Documents())
+
+		ninv := NewDocumentInventory(docs)
+		if err := ninv.Append(info); err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := SetStateDocumentsValue(legacy, ninv); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDocumentInventoryAppendPaged(b *testing.B) {
+	a, legacy, pages := benchmarkDocumentInventoryStates(10000)
+	nlegacy, _ := SetStateDocumentsValue(legacy, NewDocumentInventory(nil))
+	getState := newTestGetState(append(pages, nlegacy)...)
+	info := MustNewDocInfo(10000, MustFileHash("NEWDOC"))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dp := newOwnerDocumentPages()
+		if err := dp.load(a, info.Index(), getState); err != nil {
+			b.Fatal(err)
+		}
+
+		if err := dp.append(a, info); err != nil {
+			b.Fatal(err)
+		}
+
+		if _, err := dp.states(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,19 +4,37 @@ import (
 	"encoding/json"
 
 	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
 	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
 )
 
 type DocumentJSONPacker struct {
 	jsonenc.HintedHead
-	DI DocInfo      `json:"documentinfo"`
-	CR DocSign      `json:"creator"`
-	TL string       `json:"title"`
-	SZ currency.Big `json:"size"`
-	SG []DocSign    `json:"signers"`
+	DI DocInfo          `json:"documentinfo"`
+	CR DocSign          `json:"creator"`
+	TL string           `json:"title"`
+	SZ currency.Big     `json:"size"`
+	SG []DocSign        `json:"signers"`
+	EX base.Height      `json:"expiry"`
+	SM string           `json:"signingmode"`
+	TH uint             `json:"threshold"`
+	RV []DocRevision    `json:"revisions,omitempty"`
+	CN base.Height      `json:"canceled"`
+	MD DocumentMetadata `json:"metadata,omitempty"`
+	FS []DocFile        `json:"files,omitempty"`
+	ES *currency.Amount `json:"escrow,omitempty"`
+	EP base.Address     `json:"escrowpayer,omitempty"`
 }
 
 func (doc DocumentData) MarshalJSON() ([]byte, error) {
+	var es *currency.Amount
+	var ep base.Address
+	if doc.HasEscrow() {
+		es = &doc.escrow
+		ep = doc.EscrowPayer()
+	}
+
 	return jsonenc.Marshal(DocumentJSONPacker{
 		HintedHead: jsonenc.NewHintedHead(doc.Hint()),
 		DI:         doc.info,
@@ -24,15 +42,34 @@ func (doc DocumentData) MarshalJSON() ([]byte, error) {
 		TL:         doc.title,
 		SZ:         doc.size,
 		SG:         doc.signers,
+		EX:         doc.expiry,
+		SM:         doc.mode.String(),
+		TH:         doc.threshold,
+		RV:         doc.revisions,
+		CN:         doc.canceled,
+		MD:         doc.metadata,
+		FS:         doc.files,
+		ES:         es,
+		EP:         ep,
 	})
 }
 
 type DocumentJSONUnpacker struct {
-	DI json.RawMessage `json:"documentinfo"`
-	CR json.RawMessage `json:"creator"`
-	TL string          `json:"title"`
-	SZ currency.Big    `json:"size"`
-	SG json.RawMessage `json:"signers"`
+	H  hint.Hint           `json:"_hint"`
+	DI json.RawMessage     `json:"documentinfo"`
+	CR json.RawMessage     `json:"creator"`
+	TL string              `json:"title"`
+	SZ currency.Big        `json:"size"`
+	SG json.RawMessage     `json:"signers"`
+	EX *base.Height        `json:"expiry"`
+	SM string              `json:"signingmode"`
+	TH uint                `json:"threshold"`
+	RV json.RawMessage     `json:"revisions"`
+	CN *base.Height        `json:"canceled"`
+	MD map[string]string   `json:"metadata"`
+	FS json.RawMessage     `json:"files"`
+	ES json.RawMessage     `json:"escrow"`
+	EP base.AddressDecoder `json:"escrowpayer"`
 }
 
 func (doc *DocumentData) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
@@ -41,5 +78,5 @@ func (doc *DocumentData) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
 		return err
 	}
 
-	return doc.unpack(enc, udoc.DI, udoc.CR, udoc.TL, udoc.SZ, udoc.SG)
+	return doc.unpack(enc, udoc.H, udoc.DI, udoc.CR, udoc.TL, udoc.SZ, udoc.SG, udoc.EX, udoc.SM, udoc.TH, udoc.RV, udoc.CN, udoc.MD, udoc.FS, udoc.ES, udoc.EP)
 }
@@ -0,0 +1,151 @@
+package blocksign
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/util"
+)
+
+// Well-known keys of DocumentMetadata; these keys are indexed by digest.
+const (
+	MetadataKeyTags       = "tags"
+	MetadataKeyMimeType   = "mimetype"
+	MetadataKeyReference  = "reference"
+	MetadataKeyDepartment = "department"
+)
+
+// MetadataTagsSeparator separates tags in the value of MetadataKeyTags.
+const MetadataTagsSeparator = ","
+
+var (
+	MaxMetadataKeys        = 20
+	MaxMetadataKeyLength   = 32
+	MaxMetadataValueLength = 256
+	MaxMetadataTags        = 10
+)
+
+var reMetadataKey = regexp.MustCompile(`^[a-z][a-z0-9_\-\.]*$`)
+
+// DocumentMetadata is the bounded key/value attributes of document, like mime
+// type, external reference number, department and tags.
+type DocumentMetadata map[string]string
+
+// Keys returns the sorted keys.
+func (md DocumentMetadata) Keys() []string {
+	keys := make([]string, len(md))
+
+	var i int
+	for k := range md {
+		keys[i] = k
+		i++
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Bytes returns the key and value pairs in the order of keys; key and value
+// do not have control characters, so they are separated by zero byte.
+func (md DocumentMetadata) Bytes() []byte {
+	keys := md.Keys()
+
+	bs := make([][]byte, len(keys)*2)
+	for i := range keys {
+		bs[i*2] = append([]byte(keys[i]), 0)
+		bs[i*2+1] = append([]byte(md[keys[i]]), 0)
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (md DocumentMetadata) IsValid([]byte) error {
+	if len(md) > MaxMetadataKeys {
+		return errors.Errorf("too many metadata keys, %d > %d", len(md), MaxMetadataKeys)
+	}
+
+	for k, v := range md {
+		switch {
+		case len(k) > MaxMetadataKeyLength:
+			return errors.Errorf("metadata key too long, %q", k)
+		case !reMetadataKey.MatchString(k):
+			return errors.Errorf("invalid metadata key, %q", k)
+		case len(v) < 1:
+			return errors.Errorf("empty metadata value, %q", k)
+		case len(v) > MaxMetadataValueLength:
+			return errors.Errorf("metadata value too long, %q", k)
+		case !utf8.ValidString(v):
+			return errors.Errorf("metadata value is not valid utf-8, %q", k)
+		case strings.IndexFunc(v, unicode.IsControl) >= 0:
+			return errors.Errorf("metadata value has control character, %q", k)
+		}
+	}
+
+	if _, found := md[MetadataKeyTags]; !found {
+		return nil
+	}
+
+	tags := strings.Split(md[MetadataKeyTags], MetadataTagsSeparator)
+	if len(tags) > MaxMetadataTags {
+		return errors.Errorf("too many tags, %d > %d", len(tags), MaxMetadataTags)
+	}
+
+	founds := map[string]bool{}
+	for i := range tags {
+		t := strings.TrimSpace(tags[i])
+		switch {
+		case len(t) < 1:
+			return errors.Errorf("empty tag found")
+		case t != tags[i]:
+			return errors.Errorf("tag has leading or trailing spaces, %q", tags[i])
+		case founds[t]:
+			return errors.Errorf("duplicated tag found, %q", t)
+		}
+		founds[t] = true
+	}
+
+	return nil
+}
+
+// Tags returns tags in the value of MetadataKeyTags.
+func (md DocumentMetadata) Tags() []string {
+	v, found := md[MetadataKeyTags]
+	if !found || len(v) < 1 {
+		return nil
+	}
+
+	return strings.Split(v, MetadataTagsSeparator)
+}
+
+func (md DocumentMetadata) Equal(b DocumentMetadata) bool {
+	if len(md) != len(b) {
+		return false
+	}
+
+	for k := range md {
+		if v, found := b[k]; !found || v != md[k] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Copy returns the copy of DocumentMetadata; empty metadata returns nil.
+func (md DocumentMetadata) Copy() DocumentMetadata {
+	if len(md) < 1 {
+		return nil
+	}
+
+	n := DocumentMetadata{}
+	for k := range md {
+		n[k] = md[k]
+	}
+
+	return n
+}
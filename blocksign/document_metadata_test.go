@@ -0,0 +1,99 @@
+package blocksign
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/stretchr/testify/suite"
+)
+
+type testDocumentMetadata struct {
+	suite.Suite
+}
+
+func (t *testDocumentMetadata) TestValid() {
+	md := DocumentMetadata{
+		MetadataKeyMimeType:  "application/pdf",
+		MetadataKeyReference: "REF-0001",
+		MetadataKeyTags:      "hr,contract,2021",
+		"x-custom.value_1":   "문서",
+	}
+	t.NoError(md.IsValid(nil))
+	t.Equal([]string{"hr", "contract", "2021"}, md.Tags())
+
+	t.NoError(DocumentMetadata(nil).IsValid(nil))
+	t.Nil(DocumentMetadata(nil).Tags())
+}
+
+func (t *testDocumentMetadata) TestInvalid() {
+	cases := []struct {
+		md  DocumentMetadata
+		err string
+	}{
+		{DocumentMetadata{"Mimetype": "a"}, "invalid metadata key"},
+		{DocumentMetadata{"0key": "a"}, "invalid metadata key"},
+		{DocumentMetadata{strings.Repeat("k", MaxMetadataKeyLength+1): "a"}, "metadata key too long"},
+		{DocumentMetadata{"key": ""}, "empty metadata value"},
+		{DocumentMetadata{"key": strings.Repeat("v", MaxMetadataValueLength+1)}, "metadata value too long"},
+		{DocumentMetadata{"key": "a\x00b"}, "control character"},
+		{DocumentMetadata{"key": "\xff"}, "not valid utf-8"},
+		{DocumentMetadata{MetadataKeyTags: "hr,,contract"}, "empty tag found"},
+		{DocumentMetadata{MetadataKeyTags: "hr, contract"}, "leading or trailing spaces"},
+		{DocumentMetadata{MetadataKeyTags: "hr,hr"}, "duplicated tag found"},
+		{DocumentMetadata{MetadataKeyTags: strings.Repeat("a,", MaxMetadataTags) + "b"}, "too many tags"},
+	}
+
+	for i, c := range cases {
+		err := c.md.IsValid(nil)
+		if t.Error(err, "%d", i) {
+			t.Contains(err.Error(), c.err, "%d", i)
+		}
+	}
+
+	md := DocumentMetadata{}
+	for i := 0; i <= MaxMetadataKeys; i++ {
+		md[string(rune('a'+i))] = "v"
+	}
+	t.Contains(md.IsValid(nil).Error(), "too many metadata keys")
+}
+
+func (t *testDocumentMetadata) TestBytes() {
+	a := DocumentMetadata{}
+	a["b"] = "2"
+	a["a"] = "1"
+	a["c"] = "3"
+
+	b := DocumentMetadata{"c": "3", "a": "1", "b": "2"}
+
+	for i := 0; i < 10; i++ {
+		t.Equal(a.Bytes(), b.Bytes())
+	}
+
+	// NOTE same concatenation, different pairs
+	t.NotEqual(DocumentMetadata{"ab": "c"}.Bytes(), DocumentMetadata{"a": "bc"}.Bytes())
+}
+
+func (t *testDocumentMetadata) TestHash() {
+	doc := NewDocumentData(NewDocInfo(0, MustFileHash("ABCD")), MustAddress("creator"), "user0", "title", currency.NewBig(3), nil)
+
+	withMetadata := doc.WithMetadata(DocumentMetadata{MetadataKeyTags: "hr"})
+	t.False(doc.Hash().Equal(withMetadata.Hash()))
+	t.False(doc.Equal(withMetadata))
+
+	// NOTE empty metadata does not change hash
+	t.True(doc.Hash().Equal(doc.WithMetadata(DocumentMetadata{}).Hash()))
+}
+
+func (t *testDocumentMetadata) TestCopy() {
+	md := DocumentMetadata{"a": "1"}
+	doc := NewDocumentData(NewDocInfo(0, MustFileHash("ABCD")), MustAddress("creator"), "user0", "title", currency.NewBig(3), nil).
+		WithMetadata(md)
+
+	md["a"] = "2"
+	t.Equal("1", doc.Metadata()["a"])
+}
+
+func TestDocumentMetadata(t *testing.T) {
+	suite.Run(t, new(testDocumentMetadata))
+}
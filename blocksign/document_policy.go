@@ -0,0 +1,188 @@
+package blocksign
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/util"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/valuehash"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	DocumentPolicyType = hint.Type("mitum-blocksign-document-policy")
+	DocumentPolicyHint = hint.NewHint(DocumentPolicyType, "v0.0.1")
+)
+
+// DocumentPolicy is the network policy of documents. It is held in the state
+// of StateKeyDocumentPolicy and updated by DocumentPolicyUpdater, so every
+// node processes the document operations by the same policy. With
+// uniqueFileHash, the file hash, which is already registered by the other
+// document, is not allowed. fees is the DocumentFeePolicy by currency id.
+type DocumentPolicy struct {
+	uniqueFileHash bool
+	fees           map[currency.CurrencyID]DocumentFeePolicy
+}
+
+// DefaultDocumentPolicy is used before DocumentPolicy is stored.
+var DefaultDocumentPolicy = NewDocumentPolicy(false)
+
+func NewDocumentPolicy(uniqueFileHash bool) DocumentPolicy {
+	return DocumentPolicy{
+		uniqueFileHash: uniqueFileHash,
+		fees:           map[currency.CurrencyID]DocumentFeePolicy{},
+	}
+}
+
+// WithFeePolicy sets the DocumentFeePolicy of currency.
+func (po DocumentPolicy) WithFeePolicy(cid currency.CurrencyID, fee DocumentFeePolicy) DocumentPolicy {
+	fees := make(map[currency.CurrencyID]DocumentFeePolicy, len(po.fees)+1)
+	for k := range po.fees {
+		fees[k] = po.fees[k]
+	}
+	fees[cid] = fee
+
+	po.fees = fees
+
+	return po
+}
+
+func (DocumentPolicy) Hint() hint.Hint {
+	return DocumentPolicyHint
+}
+
+func (po DocumentPolicy) Hash() valuehash.Hash {
+	return po.GenerateHash()
+}
+
+func (po DocumentPolicy) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(po.Bytes())
+}
+
+func (po DocumentPolicy) Bytes() []byte {
+	cids := po.feeCurrencies()
+
+	bs := make([][]byte, len(cids)*2+1)
+	bs[0] = util.BoolToBytes(po.uniqueFileHash)
+	for i := range cids {
+		bs[i*2+1] = cids[i].Bytes()
+		bs[i*2+2] = po.fees[cids[i]].Bytes()
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (po DocumentPolicy) IsValid([]byte) error {
+	for cid := range po.fees {
+		if err := cid.IsValid(nil); err != nil {
+			return errors.Wrapf(err, "invalid currency id of document fee, %q", cid)
+		}
+
+		if err := po.fees[cid].IsValid(nil); err != nil {
+			return errors.Wrapf(err, "invalid document fee policy of %q", cid)
+		}
+	}
+
+	return nil
+}
+
+func (po DocumentPolicy) UniqueFileHash() bool {
+	return po.uniqueFileHash
+}
+
+// FeePolicy returns the DocumentFeePolicy of currency; if not set,
+// DefaultDocumentFeePolicy.
+func (po DocumentPolicy) FeePolicy(cid currency.CurrencyID) DocumentFeePolicy {
+	if fee, found := po.fees[cid]; found {
+		return fee
+	}
+
+	return DefaultDocumentFeePolicy
+}
+
+// FeePolicies returns the DocumentFeePolicy by currency id.
+func (po DocumentPolicy) FeePolicies() map[currency.CurrencyID]DocumentFeePolicy {
+	return po.fees
+}
+
+func (po DocumentPolicy) feeCurrencies() []currency.CurrencyID {
+	cids := make([]currency.CurrencyID, 0, len(po.fees))
+	for cid := range po.fees {
+		cids = append(cids, cid)
+	}
+
+	sort.Slice(cids, func(i, j int) bool {
+		return cids[i] < cids[j]
+	})
+
+	return cids
+}
+
+func (po *DocumentPolicy) unpack(uniqueFileHash bool, fees map[string]DocumentFeePolicy) {
+	po.uniqueFileHash = uniqueFileHash
+
+	po.fees = make(map[currency.CurrencyID]DocumentFeePolicy, len(fees))
+	for k := range fees {
+		po.fees[currency.CurrencyID(k)] = fees[k]
+	}
+}
+
+type DocumentPolicyJSONPacker struct {
+	jsonenc.HintedHead
+	UF bool                                      `json:"unique_filehash"`
+	FE map[currency.CurrencyID]DocumentFeePolicy `json:"fees"`
+}
+
+func (po DocumentPolicy) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocumentPolicyJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(po.Hint()),
+		UF:         po.uniqueFileHash,
+		FE:         po.fees,
+	})
+}
+
+type DocumentPolicyJSONUnpacker struct {
+	UF bool                         `json:"unique_filehash"`
+	FE map[string]DocumentFeePolicy `json:"fees"`
+}
+
+func (po *DocumentPolicy) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var upo DocumentPolicyJSONUnpacker
+	if err := enc.Unmarshal(b, &upo); err != nil {
+		return err
+	}
+
+	po.unpack(upo.UF, upo.FE)
+
+	return nil
+}
+
+func (po DocumentPolicy) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(po.Hint()),
+		bson.M{
+			"unique_filehash": po.uniqueFileHash,
+			"fees":            po.fees,
+		}),
+	)
+}
+
+type DocumentPolicyBSONUnpacker struct {
+	UF bool                         `bson:"unique_filehash"`
+	FE map[string]DocumentFeePolicy `bson:"fees"`
+}
+
+func (po *DocumentPolicy) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var upo DocumentPolicyBSONUnpacker
+	if err := enc.Unmarshal(b, &upo); err != nil {
+		return err
+	}
+
+	po.unpack(upo.UF, upo.FE)
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	DocumentPolicyUpdaterFactType = hint.Type("mitum-blocksign-document-policy-updater-operation-fact")
+	DocumentPolicyUpdaterFactHint = hint.NewHint(DocumentPolicyUpdaterFactType, "v0.0.1")
+	DocumentPolicyUpdaterType     = hint.Type("mitum-blocksign-document-policy-updater-operation")
+	DocumentPolicyUpdaterHint     = hint.NewHint(DocumentPolicyUpdaterType, "v0.0.1")
+)
+
+// DocumentPolicyUpdaterFact replaces DocumentPolicy; like
+// currency.CurrencyPolicyUpdater, it should be signed by the suffrage nodes.
+type DocumentPolicyUpdaterFact struct {
+	h      valuehash.Hash
+	token  []byte
+	policy DocumentPolicy
+}
+
+func NewDocumentPolicyUpdaterFact(token []byte, policy DocumentPolicy) DocumentPolicyUpdaterFact {
+	fact := DocumentPolicyUpdaterFact{
+		token:  token,
+		policy: policy,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (DocumentPolicyUpdaterFact) Hint() hint.Hint {
+	return DocumentPolicyUpdaterFactHint
+}
+
+func (fact DocumentPolicyUpdaterFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact DocumentPolicyUpdaterFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact DocumentPolicyUpdaterFact) Bytes() []byte {
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.policy.Bytes(),
+	)
+}
+
+func (fact DocumentPolicyUpdaterFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for DocumentPolicyUpdaterFact")
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.policy,
+	}, nil, false); err != nil {
+		return errors.Wrap(err, "invalid fact")
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact DocumentPolicyUpdaterFact) Token() []byte {
+	return fact.token
+}
+
+func (fact DocumentPolicyUpdaterFact) Policy() DocumentPolicy {
+	return fact.policy
+}
+
+type DocumentPolicyUpdater struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewDocumentPolicyUpdater(
+	fact DocumentPolicyUpdaterFact,
+	fs []operation.FactSign,
+	memo string,
+) (DocumentPolicyUpdater, error) {
+	if bo, err := operation.NewBaseOperationFromFact(DocumentPolicyUpdaterHint, fact, fs); err != nil {
+		return DocumentPolicyUpdater{}, err
+	} else {
+		op := DocumentPolicyUpdater{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (DocumentPolicyUpdater) Hint() hint.Hint {
+	return DocumentPolicyUpdaterHint
+}
+
+func (op DocumentPolicyUpdater) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op DocumentPolicyUpdater) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op DocumentPolicyUpdater) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
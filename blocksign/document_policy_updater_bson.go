@@ -0,0 +1,60 @@
+package blocksign // nolint: dupl
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base/operation"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (fact DocumentPolicyUpdaterFact) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(fact.Hint()),
+			bson.M{
+				"hash":   fact.h,
+				"token":  fact.token,
+				"policy": fact.policy,
+			}))
+}
+
+type DocumentPolicyUpdaterFactBSONUnpacker struct {
+	H  valuehash.Bytes `bson:"hash"`
+	TK []byte          `bson:"token"`
+	PO bson.Raw        `bson:"policy"`
+}
+
+func (fact *DocumentPolicyUpdaterFact) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ufact DocumentPolicyUpdaterFactBSONUnpacker
+	if err := bson.Unmarshal(b, &ufact); err != nil {
+		return err
+	}
+
+	return fact.unpack(enc, ufact.H, ufact.TK, ufact.PO)
+}
+
+func (op DocumentPolicyUpdater) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(
+			op.BaseOperation.BSONM(),
+			bson.M{"memo": op.Memo},
+		))
+}
+
+func (op *DocumentPolicyUpdater) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ubo operation.BaseOperation
+	if err := ubo.UnpackBSON(b, enc); err != nil {
+		return err
+	}
+
+	*op = DocumentPolicyUpdater{BaseOperation: ubo}
+
+	var um currency.MemoBSONUnpacker
+	if err := enc.Unmarshal(b, &um); err != nil {
+		return err
+	}
+	op.Memo = um.Memo
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (fact *DocumentPolicyUpdaterFact) unpack(
+	enc encoder.Encoder,
+	h valuehash.Hash,
+	tk []byte,
+	bpo []byte,
+) error {
+	hpo, err := enc.Decode(bpo)
+	if err != nil {
+		return err
+	}
+
+	po, ok := hpo.(DocumentPolicy)
+	if !ok {
+		return util.WrongTypeError.Errorf("expected DocumentPolicy, not %T", hpo)
+	}
+
+	fact.h = h
+	fact.token = tk
+	fact.policy = po
+
+	return nil
+}
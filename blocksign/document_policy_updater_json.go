@@ -0,0 +1,65 @@
+package blocksign
+
+import (
+	"encoding/json"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base/operation"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+type DocumentPolicyUpdaterFactJSONPacker struct {
+	jsonenc.HintedHead
+	H  valuehash.Hash `json:"hash"`
+	TK []byte         `json:"token"`
+	PO DocumentPolicy `json:"policy"`
+}
+
+func (fact DocumentPolicyUpdaterFact) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocumentPolicyUpdaterFactJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(fact.Hint()),
+		H:          fact.h,
+		TK:         fact.token,
+		PO:         fact.policy,
+	})
+}
+
+type DocumentPolicyUpdaterFactJSONUnpacker struct {
+	H  valuehash.Bytes `json:"hash"`
+	TK []byte          `json:"token"`
+	PO json.RawMessage `json:"policy"`
+}
+
+func (fact *DocumentPolicyUpdaterFact) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ufact DocumentPolicyUpdaterFactJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &ufact); err != nil {
+		return err
+	}
+
+	return fact.unpack(enc, ufact.H, ufact.TK, ufact.PO)
+}
+
+func (op DocumentPolicyUpdater) MarshalJSON() ([]byte, error) {
+	m := op.BaseOperation.JSONM()
+	m["memo"] = op.Memo
+
+	return jsonenc.Marshal(m)
+}
+
+func (op *DocumentPolicyUpdater) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ubo operation.BaseOperation
+	if err := ubo.UnpackJSON(b, enc); err != nil {
+		return err
+	}
+
+	*op = DocumentPolicyUpdater{BaseOperation: ubo}
+
+	var um currency.MemoJSONUnpacker
+	if err := enc.Unmarshal(b, &um); err != nil {
+		return err
+	}
+	op.Memo = um.Memo
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (DocumentPolicyUpdater) Process(
+	func(string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	// NOTE Process is nil func
+	return nil
+}
+
+type DocumentPolicyUpdaterProcessor struct {
+	DocumentPolicyUpdater
+	pubs      []key.Publickey
+	threshold base.Threshold
+	st        state.State
+}
+
+func NewDocumentPolicyUpdaterProcessor(
+	pubs []key.Publickey,
+	threshold base.Threshold,
+) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(DocumentPolicyUpdater); !ok {
+			return nil, errors.Errorf("not DocumentPolicyUpdater, %T", op)
+		} else {
+			return &DocumentPolicyUpdaterProcessor{
+				DocumentPolicyUpdater: i,
+				pubs:                  pubs,
+				threshold:             threshold,
+			}, nil
+		}
+	}
+}
+
+func (opp *DocumentPolicyUpdaterProcessor) PreProcess(
+	getState func(string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	if len(opp.pubs) < 1 {
+		return nil, operation.NewBaseReasonError("empty publickeys for operation signs")
+	} else if err := checkFactSignsByPubs(opp.pubs, opp.threshold, opp.Signs()); err != nil {
+		return nil, err
+	}
+
+	st, _, err := getState(StateKeyDocumentPolicy)
+	if err != nil {
+		return nil, err
+	}
+	opp.st = st
+
+	return opp, nil
+}
+
+func (opp *DocumentPolicyUpdaterProcessor) Process(
+	_ func(string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := opp.Fact().(DocumentPolicyUpdaterFact)
+
+	st, err := SetStateDocumentPolicyValue(opp.st, fact.Policy())
+	if err != nil {
+		return err
+	}
+
+	return setState(fact.Hash(), st)
+}
@@ -0,0 +1,178 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testDocumentPolicyUpdaterOperation struct {
+	baseTestOperationProcessor
+	suffrage []key.Privatekey
+}
+
+func (t *testDocumentPolicyUpdaterOperation) SetupSuite() {
+	t.suffrage = []key.Privatekey{
+		key.MustNewBTCPrivatekey(),
+		key.MustNewBTCPrivatekey(),
+	}
+}
+
+func (t *testDocumentPolicyUpdaterOperation) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	pubs := make([]key.Publickey, len(t.suffrage))
+	for i := range t.suffrage {
+		pubs[i] = t.suffrage[i].Publickey()
+	}
+
+	threshold, err := base.NewThreshold(uint(len(pubs)), 100)
+	t.NoError(err)
+
+	opr := NewOperationProcessor(cp)
+	_, err = opr.SetProcessor(DocumentPolicyUpdater{}, NewDocumentPolicyUpdaterProcessor(pubs, threshold))
+	t.NoError(err)
+	_, err = opr.SetProcessor(CreateDocuments{}, NewCreateDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return opr
+	}
+
+	return opr.New(pool)
+}
+
+func (t *testDocumentPolicyUpdaterOperation) newOperation(po DocumentPolicy, pks []key.Privatekey) DocumentPolicyUpdater {
+	token := util.UUID().Bytes()
+	fact := NewDocumentPolicyUpdaterFact(token, po)
+
+	var fs []operation.FactSign
+	for _, pk := range pks {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	op, err := NewDocumentPolicyUpdater(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+
+	return op
+}
+
+func (t *testDocumentPolicyUpdaterOperation) newCreateDocuments(sender base.Address, items []CreateDocumentsItem, pks []key.Privatekey) CreateDocuments {
+	token := util.UUID().Bytes()
+	fact := NewCreateDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range pks {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	op, err := NewCreateDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+
+	return op
+}
+
+func (t *testDocumentPolicyUpdaterOperation) TestNew() {
+	pool, _ := t.statepool()
+
+	opr := t.processor(nil, pool)
+
+	cid := currency.CurrencyID("SHOWME")
+	fee := NewDocumentFeePolicy(currency.NewBig(1024), currency.NewBig(1))
+
+	op := t.newOperation(NewDocumentPolicy(true).WithFeePolicy(cid, fee), t.suffrage)
+	t.NoError(opr.Process(op))
+
+	var ns state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentPolicy {
+			ns = stu.GetState()
+		}
+	}
+	t.NotNil(ns)
+
+	po, err := StateDocumentPolicyValue(ns)
+	t.NoError(err)
+	t.True(po.UniqueFileHash())
+	t.Equal(fee, po.FeePolicy(cid))
+	t.Equal(DefaultDocumentFeePolicy, po.FeePolicy(currency.CurrencyID("FINDME")))
+}
+
+func (t *testDocumentPolicyUpdaterOperation) TestNotEnoughSuffrageSigns() {
+	pool, _ := t.statepool()
+
+	opr := t.processor(nil, pool)
+
+	op := t.newOperation(NewDocumentPolicy(true), t.suffrage[:1])
+
+	err := opr.Process(op)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "not enough suffrage signs")
+}
+
+func (t *testDocumentPolicyUpdaterOperation) TestUnknownSigner() {
+	pool, _ := t.statepool()
+
+	opr := t.processor(nil, pool)
+
+	op := t.newOperation(NewDocumentPolicy(true), []key.Privatekey{key.MustNewBTCPrivatekey(), key.MustNewBTCPrivatekey()})
+
+	err := opr.Process(op)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "not enough suffrage signs")
+}
+
+func (t *testDocumentPolicyUpdaterOperation) TestNotAppliedInSameProposal() {
+	cid := currency.CurrencyID("SHOWME")
+
+	balance := []currency.Amount{
+		currency.NewAmount(currency.NewBig(33), cid),
+	}
+
+	sa0, st0 := t.newAccount(true, balance)
+
+	filehash := MustFileHash("ABCD")
+	other := DocInfo{idx: currency.NewBig(3), filehash: filehash}
+
+	pool, _ := t.statepool(st0, []state.State{
+		t.newStateFileHash(filehash, []DocInfo{other}),
+	})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(cid, currency.NewBig(99), sa0.Address, currency.NewFixedFeeer(sa0.Address, currency.NewBig(1)))))
+
+	opr := t.processor(cp, pool)
+
+	t.NoError(opr.Process(t.newOperation(NewDocumentPolicy(true), t.suffrage)))
+
+	// NOTE updated policy is applied from the next block
+	items := []CreateDocumentsItem{
+		NewCreateDocumentsItemSingleFile(filehash, currency.NewBig(1), "user0", "title01", currency.NewBig(555), []base.Address{}, []string{}, cid),
+	}
+	t.NoError(opr.Process(t.newCreateDocuments(sa0.Address, items, sa0.Privs())))
+}
+
+func TestDocumentPolicyUpdaterOperation(t *testing.T) {
+	suite.Run(t, new(testDocumentPolicyUpdaterOperation))
+}
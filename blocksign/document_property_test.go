@@ -0,0 +1,278 @@
+package blocksign
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+)
+
+var quickConfig = &quick.Config{MaxCount: 200}
+
+// quickDocInfos is the DocInfos of unique document ids.
+type quickDocInfos []DocInfo
+
+func (quickDocInfos) Generate(r *rand.Rand, size int) reflect.Value {
+	n := r.Intn(size + 1)
+	ids := r.Perm(n*2 + 1)
+
+	docs := make([]DocInfo, n)
+	for i := range docs {
+		docs[i] = MustNewDocInfo(int64(ids[i]), MustFileHash(fmt.Sprintf("FH%d", r.Intn(3))))
+	}
+
+	return reflect.ValueOf(quickDocInfos(docs))
+}
+
+func (docs quickDocInfos) shuffled(r *rand.Rand) []DocInfo {
+	s := make([]DocInfo, len(docs))
+	for i, j := range r.Perm(len(docs)) {
+		s[i] = docs[j]
+	}
+
+	return s
+}
+
+var quickSigncodeRunes = []rune("abcXYZ019-_.@ \t")
+
+type quickSigncode string
+
+func (quickSigncode) Generate(r *rand.Rand, size int) reflect.Value {
+	sc := make([]rune, r.Intn(size+1))
+	for i := range sc {
+		sc[i] = quickSigncodeRunes[r.Intn(len(quickSigncodeRunes))]
+	}
+
+	return reflect.ValueOf(quickSigncode(sc))
+}
+
+// quickDocSigns is the signers of unique addresses.
+type quickDocSigns []DocSign
+
+func (quickDocSigns) Generate(r *rand.Rand, size int) reflect.Value {
+	signers := make([]DocSign, r.Intn(size)+1)
+	for i := range signers {
+		signers[i] = NewDocSignWithStatus(
+			MustAddress(util.UUID().String()),
+			fmt.Sprintf("user%d", r.Intn(3)),
+			DocSignStatus(r.Intn(3)),
+			base.Height(r.Int63n(10)),
+		).WithWeight(uint(r.Intn(3)))
+	}
+
+	return reflect.ValueOf(quickDocSigns(signers))
+}
+
+func (signers quickDocSigns) document(creator base.Address) DocumentData {
+	info := MustNewDocInfo(0, MustFileHash("ABCD"))
+
+	return NewDocumentData(info, creator, "user0", "title", currency.NewBig(333), signers)
+}
+
+type testDocumentProperty struct {
+	suite.Suite
+	r *rand.Rand
+}
+
+func (t *testDocumentProperty) SetupTest() {
+	t.r = rand.New(rand.NewSource(rand.Int63())) // nolint:gosec
+}
+
+func (t *testDocumentProperty) TestInventoryEqual() {
+	f := func(a, b quickDocInfos) bool {
+		ia := NewDocumentInventory(a)
+		ib := NewDocumentInventory(b)
+
+		if !ia.Equal(ia) || ia.Equal(ib) != ib.Equal(ia) {
+			return false
+		}
+
+		// NOTE the order of documents does not matter
+		if !ia.Equal(NewDocumentInventory(a.shuffled(t.r))) {
+			return false
+		}
+
+		if len(a) > 0 {
+			if ia.Equal(NewDocumentInventory(a[:len(a)-1])) || NewDocumentInventory(a[1:]).Equal(ia) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+}
+
+func (t *testDocumentProperty) TestInventoryEqualNotSort() {
+	f := func(a quickDocInfos) bool {
+		docs := a.shuffled(t.r)
+		shuffled := make([]DocInfo, len(docs))
+		copy(shuffled, docs)
+
+		_ = NewDocumentInventory(docs).Equal(NewDocumentInventory(a))
+
+		for i := range docs {
+			if !docs[i].Equal(shuffled[i]) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+}
+
+func (t *testDocumentProperty) TestInventoryIsValid() {
+	f := func(a quickDocInfos) bool {
+		inv := NewDocumentInventory(a)
+		if err := inv.IsValid(nil); err != nil {
+			return false
+		}
+
+		if len(a) < 1 {
+			return true
+		}
+
+		docs := append(a.shuffled(t.r), MustNewDocInfo(a[0].Index().Int64(), MustFileHash("EFGH")))
+
+		return NewDocumentInventory(docs).IsValid(nil) != nil
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+}
+
+func (t *testDocumentProperty) TestEmptyEqual() {
+	info := MustNewDocInfo(0, MustFileHash("ABCD"))
+	t.False(info.Equal(DocInfo{}))
+	t.False(DocInfo{}.Equal(info))
+	t.True(DocInfo{}.Equal(DocInfo{}))
+
+	ds := NewDocSign(MustAddress(util.UUID().String()), "user0", false)
+	t.False(ds.Equal(DocSign{}))
+	t.False(DocSign{}.Equal(ds))
+	t.True(DocSign{}.Equal(DocSign{}))
+
+	inv := NewDocumentInventory([]DocInfo{info})
+	t.False(inv.Equal(DocumentInventory{}))
+	t.False(DocumentInventory{}.Equal(inv))
+	t.False(inv.Equal(NewDocumentInventory([]DocInfo{info, MustNewDocInfo(1, MustFileHash("ABCD"))})))
+	t.False(inv.Equal(NewDocumentInventory([]DocInfo{{filehash: MustFileHash("ABCD")}})))
+
+	doc := quickDocSigns([]DocSign{ds}).document(MustAddress(util.UUID().String()))
+	t.False(doc.Equal(DocumentData{}))
+	t.False(DocumentData{}.Equal(doc))
+}
+
+func (t *testDocumentProperty) TestSignCodeIsValidFormat() {
+	f := func(sc quickSigncode) bool {
+		err := SignCode(sc).IsValidFormat()
+
+		return (err == nil) == reSignCode.MatchString(string(sc))
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+
+	err := SignCode(strings.Repeat("a", MaxSignCodeLength+1)).IsValidFormat()
+	t.Contains(err.Error(), "signcode too long")
+}
+
+func (t *testDocumentProperty) TestDocSignIsValid() {
+	// NOTE the signcode of existing documents can have any format
+	f := func(sc quickSigncode) bool {
+		ds := NewDocSign(MustAddress(util.UUID().String()), string(sc), false)
+
+		return ds.IsValid(nil) == nil
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+
+	t.NoError(NewDocSign(MustAddress(util.UUID().String()), "", false).IsValid(nil))
+
+	err := NewDocSign(nil, "user0", false).IsValid(nil)
+	t.Contains(err.Error(), "empty address")
+
+	err = NewDocSignWithStatus(MustAddress(util.UUID().String()), "user0", DocSignStatus(9), base.NilHeight).IsValid(nil)
+	t.Contains(err.Error(), "unknown docsign status")
+}
+
+func (t *testDocumentProperty) TestDocumentDataEqual() {
+	f := func(a, b quickDocSigns) bool {
+		creator := MustAddress(util.UUID().String())
+		da := a.document(creator)
+		db := b.document(creator)
+
+		if !da.Equal(da) || da.Equal(db) != db.Equal(da) {
+			return false
+		}
+
+		// NOTE in parallel mode, the order of signers does not matter
+		shuffled := make([]DocSign, len(a))
+		for i, j := range t.r.Perm(len(a)) {
+			shuffled[i] = a[j]
+		}
+
+		ds := quickDocSigns(shuffled).document(creator)
+		if !da.Equal(ds) || !da.Hash().Equal(ds.Hash()) {
+			return false
+		}
+
+		if da.Equal(quickDocSigns(a[1:]).document(creator)) {
+			return false
+		}
+
+		// signcode of signer
+		nsigners := make([]DocSign, len(a))
+		copy(nsigners, a)
+		nsigners[0].signcode += "0"
+
+		return !da.Equal(quickDocSigns(nsigners).document(creator))
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+}
+
+func (t *testDocumentProperty) TestDocumentDataIsValid() {
+	f := func(a quickDocSigns) bool {
+		creator := MustAddress(util.UUID().String())
+		if err := a.document(creator).IsValid(nil); err != nil {
+			return false
+		}
+
+		i := t.r.Intn(len(a))
+
+		withCreator := append(quickDocSigns{NewDocSign(creator, "user9", false)}, a...)
+		if err := withCreator.document(creator).IsValid(nil); err == nil {
+			return false
+		}
+
+		duplicated := append(quickDocSigns{NewDocSign(a[i].Address(), "user9", false)}, a...)
+
+		return duplicated.document(creator).IsValid(nil) != nil
+	}
+
+	t.NoError(quick.Check(f, quickConfig))
+
+	signer := MustAddress(util.UUID().String())
+
+	err := quickDocSigns{NewDocSign(signer, "user1", false)}.document(signer).IsValid(nil)
+	t.Contains(err.Error(), "document creator can not be signer")
+
+	err = quickDocSigns{NewDocSign(signer, "user1", false), NewDocSign(signer, "user2", false)}.
+		document(MustAddress(util.UUID().String())).IsValid(nil)
+	t.Contains(err.Error(), "duplicated signer")
+
+	t.NoError(quickDocSigns{NewDocSign(signer, "user 1", false)}.document(MustAddress(util.UUID().String())).IsValid(nil))
+}
+
+func TestDocumentProperty(t *testing.T) {
+	suite.Run(t, new(testDocumentProperty))
+}
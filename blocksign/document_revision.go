@@ -0,0 +1,215 @@
+package blocksign
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/valuehash"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	DocRevisionType = hint.Type("mitum-blocksign-document-revision")
+	DocRevisionHint = hint.NewHint(DocRevisionType, "v0.0.1")
+)
+
+// DocRevision is the superseded revision of document; it keeps the file and
+// the signatures of signers before the document is revised.
+type DocRevision struct {
+	filehash FileHash
+	title    string
+	size     currency.Big
+	signers  []DocSign
+	height   base.Height // height at which revision is superseded
+}
+
+func NewDocRevision(filehash FileHash, title string, size currency.Big, signers []DocSign, height base.Height) DocRevision {
+	return DocRevision{
+		filehash: filehash,
+		title:    title,
+		size:     size,
+		signers:  signers,
+		height:   height,
+	}
+}
+
+func (rv DocRevision) Hint() hint.Hint {
+	return DocRevisionHint
+}
+
+func (rv DocRevision) Bytes() []byte {
+	bs := make([][]byte, len(rv.signers)+4)
+	bs[0] = rv.filehash.Bytes()
+	bs[1] = []byte(rv.title)
+	bs[2] = rv.size.Bytes()
+	bs[3] = rv.height.Bytes()
+	for i := range rv.signers {
+		bs[i+4] = rv.signers[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (rv DocRevision) Hash() valuehash.Hash {
+	return valuehash.NewSHA256(rv.Bytes())
+}
+
+func (rv DocRevision) IsValid([]byte) error {
+	// NOTE revision keeps the FileHash of the existing documents
+	if err := rv.filehash.IsValidLegacy(); err != nil {
+		return err
+	}
+
+	for i := range rv.signers {
+		if err := rv.signers[i].IsValid(nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (rv DocRevision) FileHash() FileHash {
+	return rv.filehash
+}
+
+func (rv DocRevision) Title() string {
+	return rv.title
+}
+
+func (rv DocRevision) Size() currency.Big {
+	return rv.size
+}
+
+func (rv DocRevision) Signers() []DocSign {
+	return rv.signers
+}
+
+func (rv DocRevision) Height() base.Height {
+	return rv.height
+}
+
+func (rv DocRevision) Equal(b DocRevision) bool {
+	switch {
+	case !rv.filehash.Equal(b.filehash),
+		rv.title != b.title,
+		!equalBig(rv.size, b.size),
+		rv.height != b.height,
+		len(rv.signers) != len(b.signers):
+		return false
+	}
+
+	for i := range rv.signers {
+		if !rv.signers[i].Equal(b.signers[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type DocRevisionJSONPacker struct {
+	jsonenc.HintedHead
+	FH FileHash     `json:"filehash"`
+	TL string       `json:"title"`
+	SZ currency.Big `json:"size"`
+	SG []DocSign    `json:"signers"`
+	HT base.Height  `json:"height"`
+}
+
+func (rv DocRevision) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(DocRevisionJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(rv.Hint()),
+		FH:         rv.filehash,
+		TL:         rv.title,
+		SZ:         rv.size,
+		SG:         rv.signers,
+		HT:         rv.height,
+	})
+}
+
+type DocRevisionJSONUnpacker struct {
+	FH string          `json:"filehash"`
+	TL string          `json:"title"`
+	SZ currency.Big    `json:"size"`
+	SG json.RawMessage `json:"signers"`
+	HT base.Height     `json:"height"`
+}
+
+func (rv *DocRevision) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var urv DocRevisionJSONUnpacker
+	if err := enc.Unmarshal(b, &urv); err != nil {
+		return err
+	}
+
+	return rv.unpack(enc, urv.FH, urv.TL, urv.SZ, urv.SG, urv.HT)
+}
+
+func (rv DocRevision) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(bsonenc.MergeBSONM(
+		bsonenc.NewHintedDoc(rv.Hint()),
+		bson.M{
+			"filehash": rv.filehash,
+			"title":    rv.title,
+			"size":     rv.size,
+			"signers":  rv.signers,
+			"height":   rv.height,
+		}),
+	)
+}
+
+type DocRevisionBSONUnpacker struct {
+	FH string       `bson:"filehash"`
+	TL string       `bson:"title"`
+	SZ currency.Big `bson:"size"`
+	SG bson.Raw     `bson:"signers"`
+	HT base.Height  `bson:"height"`
+}
+
+func (rv *DocRevision) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var urv DocRevisionBSONUnpacker
+	if err := enc.Unmarshal(b, &urv); err != nil {
+		return err
+	}
+
+	return rv.unpack(enc, urv.FH, urv.TL, urv.SZ, urv.SG, urv.HT)
+}
+
+func (rv *DocRevision) unpack(
+	enc encoder.Encoder,
+	fh string,
+	tl string,
+	sz currency.Big,
+	bsg []byte,
+	ht base.Height,
+) error {
+	hits, err := enc.DecodeSlice(bsg)
+	if err != nil {
+		return err
+	}
+
+	signers := make([]DocSign, len(hits))
+	for i := range hits {
+		s, ok := hits[i].(DocSign)
+		if !ok {
+			return errors.Errorf("not DocSign : %T", hits[i])
+		}
+
+		signers[i] = s
+	}
+
+	rv.filehash = FileHash(fh)
+	rv.title = tl
+	rv.size = sz
+	rv.signers = signers
+	rv.height = ht
+
+	return nil
+}
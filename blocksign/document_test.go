@@ -1,6 +1,7 @@
 package blocksign
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"testing"
 
@@ -9,10 +10,13 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"golang.org/x/xerrors"
 
+	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/util"
 	"github.com/spikeekips/mitum/util/encoder"
 	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
 	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
 )
 
 type testDocumentData struct {
@@ -48,6 +52,452 @@ func (t *testDocumentData) TestNew() {
 	t.Equal(a.Signers(), sDocSigns)
 }
 
+func (t *testDocumentData) TestSetSignerStatus() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	a := MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{NewDocSign(aSigner, "user1", false)})
+
+	b, err := a.SetSignerStatus(aSigner, DocSignRejected, base.Height(33))
+	t.NoError(err)
+
+	ds, found := b.Signer(aSigner)
+	t.True(found)
+	t.Equal(DocSignRejected, ds.Status())
+	t.Equal(base.Height(33), ds.Height())
+	t.True(ds.Rejected())
+	t.False(ds.Signed())
+
+	// original document data is not changed
+	ods, found := a.Signer(aSigner)
+	t.True(found)
+	t.Equal(DocSignPending, ods.Status())
+	t.Equal(base.NilHeight, ods.Height())
+
+	_, err = a.SetSignerStatus(aCreator, DocSignSigned, base.Height(33))
+	t.Contains(err.Error(), "signer not found")
+}
+
+func (t *testDocumentData) TestStatus() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner0 := MustAddress(util.UUID().String())
+	aSigner1 := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	newDoc := func(s0, s1 DocSignStatus) DocumentData {
+		return MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{
+			NewDocSignWithStatus(aSigner0, "user1", s0, base.Height(3)),
+			NewDocSignWithStatus(aSigner1, "user2", s1, base.Height(3)),
+		})
+	}
+
+	t.Equal(DocumentDraft, newDoc(DocSignPending, DocSignPending).Status())
+	t.Equal(DocumentPartiallySigned, newDoc(DocSignSigned, DocSignPending).Status())
+	t.Equal(DocumentFullySigned, newDoc(DocSignSigned, DocSignSigned).Status())
+	t.Equal(DocumentRejected, newDoc(DocSignSigned, DocSignRejected).Status())
+	t.Equal(DocumentRejected, newDoc(DocSignRejected, DocSignPending).Status())
+
+	for _, st := range []DocumentStatus{
+		DocumentDraft, DocumentPartiallySigned, DocumentFullySigned, DocumentRejected, DocumentExpired,
+	} {
+		ust, err := ParseDocumentStatus(st.String())
+		t.NoError(err)
+		t.Equal(st, ust)
+	}
+
+	_, err := ParseDocumentStatus("showme")
+	t.Contains(err.Error(), "unknown document status")
+}
+
+func (t *testDocumentData) TestStatusAt() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner0 := MustAddress(util.UUID().String())
+	aSigner1 := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	newDoc := func(s0, s1 DocSignStatus) DocumentData {
+		return MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{
+			NewDocSignWithStatus(aSigner0, "user1", s0, base.Height(3)),
+			NewDocSignWithStatus(aSigner1, "user2", s1, base.Height(3)),
+		})
+	}
+
+	doc := newDoc(DocSignPending, DocSignPending)
+	t.Equal(base.NilHeight, doc.Expiry())
+	t.False(doc.IsExpired(base.Height(100)))
+	t.Equal(DocumentDraft, doc.StatusAt(base.Height(100)))
+
+	expiry := base.Height(10)
+	t.Equal(DocumentDraft, doc.WithExpiry(expiry).StatusAt(expiry))
+	t.Equal(DocumentExpired, doc.WithExpiry(expiry).StatusAt(expiry+1))
+	t.Equal(DocumentExpired, newDoc(DocSignSigned, DocSignPending).WithExpiry(expiry).StatusAt(expiry+1))
+	t.Equal(DocumentFullySigned, newDoc(DocSignSigned, DocSignSigned).WithExpiry(expiry).StatusAt(expiry+1))
+	t.Equal(DocumentRejected, newDoc(DocSignRejected, DocSignPending).WithExpiry(expiry).StatusAt(expiry+1))
+}
+
+func (t *testDocumentData) TestStatusByThreshold() {
+	aCreator := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	newDoc := func(sts ...DocSignStatus) DocumentData {
+		signers := make([]DocSign, len(sts))
+		for i := range sts {
+			signers[i] = NewDocSignWithStatus(MustAddress(util.UUID().String()), "user", sts[i], base.Height(3)).WithWeight(1)
+		}
+
+		return MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), signers).WithThreshold(3)
+	}
+
+	p, s, r := DocSignPending, DocSignSigned, DocSignRejected
+
+	t.Equal(DocumentDraft, newDoc(p, p, p, p, p).Status())
+	t.Equal(DocumentPartiallySigned, newDoc(s, s, p, p, p).Status())
+	t.Equal(DocumentFullySigned, newDoc(s, s, s, p, p).Status())
+	t.Equal(DocumentFullySigned, newDoc(s, s, s, r, r).Status())
+	t.Equal(DocumentPartiallySigned, newDoc(s, r, r, p, p).Status())
+	t.Equal(DocumentRejected, newDoc(s, r, r, r, p).Status())
+
+	// NOTE in sequential mode, no next signer after threshold is passed
+	_, found := newDoc(s, s, s, p, p).WithSigningMode(SigningSequential).NextSigner()
+	t.False(found)
+	_, found = newDoc(s, s, p, p, p).WithSigningMode(SigningSequential).NextSigner()
+	t.True(found)
+}
+
+func (t *testDocumentData) TestNextSigner() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner0 := MustAddress(util.UUID().String())
+	aSigner1 := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	newDoc := func(s0, s1 DocSignStatus) DocumentData {
+		return MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{
+			NewDocSignWithStatus(aSigner0, "user1", s0, base.Height(3)),
+			NewDocSignWithStatus(aSigner1, "user2", s1, base.Height(3)),
+		})
+	}
+
+	_, found := newDoc(DocSignPending, DocSignPending).NextSigner()
+	t.False(found)
+
+	doc := newDoc(DocSignPending, DocSignPending).WithSigningMode(SigningSequential)
+	next, found := doc.NextSigner()
+	t.True(found)
+	t.True(next.Address().Equal(aSigner0))
+
+	// NOTE Bytes() and Equal() keep the order of signers
+	_ = doc.Bytes()
+	t.True(doc.Equal(doc))
+	next, _ = doc.NextSigner()
+	t.True(next.Address().Equal(aSigner0))
+
+	next, found = newDoc(DocSignSigned, DocSignPending).WithSigningMode(SigningSequential).NextSigner()
+	t.True(found)
+	t.True(next.Address().Equal(aSigner1))
+
+	_, found = newDoc(DocSignSigned, DocSignSigned).WithSigningMode(SigningSequential).NextSigner()
+	t.False(found)
+
+	for _, mode := range []SigningMode{SigningParallel, SigningSequential} {
+		umode, err := ParseSigningMode(mode.String())
+		t.NoError(err)
+		t.Equal(mode, umode)
+	}
+
+	_, err := ParseSigningMode("showme")
+	t.Contains(err.Error(), "unknown signing mode")
+}
+
+func (t *testDocumentData) TestDocSignWithoutStatus() {
+	enc := jsonenc.NewEncoder()
+	encs := encoder.NewEncoders()
+	t.NoError(encs.AddEncoder(enc))
+	t.NoError(encs.TestAddHinter(currency.Address("")))
+	t.NoError(encs.TestAddHinter(DocSign{}))
+
+	a := MustAddress(util.UUID().String())
+	b, err := jsonenc.Marshal(map[string]interface{}{
+		"_hint":    DocSignLegacyHint,
+		"address":  a,
+		"signcode": "user0",
+		"signed":   true,
+	})
+	t.NoError(err)
+
+	hinter, err := enc.Decode(b)
+	t.NoError(err)
+
+	ds, ok := hinter.(DocSign)
+	t.True(ok)
+	t.True(ds.Address().Equal(a))
+	t.Equal(DocSignSigned, ds.Status())
+	t.Equal(base.NilHeight, ds.Height())
+}
+
+func (t *testDocumentData) TestRevise() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	a := MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{
+		NewDocSignWithStatus(aSigner, "user1", DocSignSigned, base.Height(1)).WithWeight(2),
+	})
+
+	b := a.Revise(FileHash("EFGH"), "title2", currency.NewBig(444), base.Height(3))
+	t.NoError(b.IsValid(nil))
+
+	// original is not changed
+	t.True(a.FileHash().Equal(FileHash("ABCD")))
+	t.Empty(a.Revisions())
+	t.True(a.Signers()[0].Signed())
+
+	t.True(b.FileHash().Equal(FileHash("EFGH")))
+	t.True(b.Info().Index().Equal(a.Info().Index()))
+	t.Equal("title2", b.Title())
+	t.True(b.Size().Equal(currency.NewBig(444)))
+	t.Equal(DocSignPending, b.Signers()[0].Status())
+	t.Equal(uint(2), b.Signers()[0].Weight())
+	t.False(a.Hash().Equal(b.Hash()))
+
+	t.Equal(1, len(b.Revisions()))
+	rv := b.Revisions()[0]
+	t.True(rv.FileHash().Equal(FileHash("ABCD")))
+	t.Equal("title", rv.Title())
+	t.Equal(base.Height(3), rv.Height())
+	t.True(rv.Signers()[0].Signed())
+
+	c := b.Revise(FileHash("IJKL"), "title3", currency.NewBig(555), base.Height(5))
+	t.Equal(2, len(c.Revisions()))
+	t.True(c.Revisions()[0].Equal(rv))
+	t.True(c.Revisions()[1].FileHash().Equal(FileHash("EFGH")))
+}
+
+func (t *testDocumentData) TestCancel() {
+	aCreator := MustAddress(util.UUID().String())
+	aSigner := MustAddress(util.UUID().String())
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	a := MustNewDocumentData(info, aCreator, "user0", "title", currency.NewBig(333), []DocSign{
+		NewDocSign(aSigner, "user1", true),
+	})
+	t.False(a.IsCanceled())
+	t.Equal(base.NilHeight, a.Canceled())
+	t.Equal(DocumentFullySigned, a.Status())
+
+	b := a.Cancel(base.Height(3))
+	t.True(b.IsCanceled())
+	t.Equal(base.Height(3), b.Canceled())
+	t.Equal(DocumentCanceled, b.Status())
+	t.Equal(DocumentCanceled, b.WithExpiry(base.Height(1)).StatusAt(base.Height(5)))
+	t.False(a.Equal(b))
+	t.False(a.Hash().Equal(b.Hash()))
+
+	st, err := ParseDocumentStatus(DocumentCanceled.String())
+	t.NoError(err)
+	t.Equal(DocumentCanceled, st)
+}
+
+func (t *testDocumentData) newDocumentData() (DocumentData, []DocSign) {
+	signers := []DocSign{
+		NewDocSign(MustAddress(util.UUID().String()), "user1", false),
+		NewDocSign(MustAddress(util.UUID().String()), "user2", true),
+		NewDocSign(MustAddress(util.UUID().String()), "user3", false),
+	}
+
+	info := DocInfo{idx: currency.NewBig(0), filehash: FileHash("ABCD")}
+	doc := MustNewDocumentData(
+		info, MustAddress(util.UUID().String()), "user0", "title", currency.NewBig(333), signers,
+	)
+
+	return doc, signers
+}
+
+func (t *testDocumentData) TestBytesNotMutateSigners() {
+	doc, signers := t.newDocumentData()
+
+	ordered := make([]DocSign, len(signers))
+	copy(ordered, signers)
+
+	for _, ht := range []hint.Hint{DocumentDataHint, DocumentDataLegacyHint} {
+		doc.hint = ht
+		b := doc.Bytes()
+
+		for i := range signers {
+			t.True(ordered[i].Equal(signers[i]))
+			t.True(ordered[i].Equal(doc.Signers()[i]))
+		}
+
+		// NOTE in parallel mode, the order of signers does not matter
+		reversed := []DocSign{signers[2], signers[1], signers[0]}
+		t.Equal(b, doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), doc.Size(), reversed).Bytes())
+	}
+
+	sequential := doc.WithSigningMode(SigningSequential)
+	sequential.hint = DocumentDataHint
+	reversed := []DocSign{signers[2], signers[1], signers[0]}
+	t.NotEqual(
+		sequential.Bytes(),
+		sequential.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), doc.Size(), reversed).Bytes(),
+	)
+}
+
+func (t *testDocumentData) TestBytesCoversFields() {
+	doc, signers := t.newDocumentData()
+	t.Equal(DocumentDataHint, doc.Hint())
+
+	h := doc.Hash()
+
+	// size
+	t.False(h.Equal(doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), currency.NewBig(334), signers).Hash()))
+
+	// signcode of signer
+	nsigners := make([]DocSign, len(signers))
+	copy(nsigners, signers)
+	nsigners[0] = NewDocSign(signers[0].Address(), "user9", false)
+	t.False(h.Equal(doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), doc.Size(), nsigners).Hash()))
+
+	// signcode of creator
+	creator := NewDocSign(doc.Creator(), "user9", true)
+	t.False(h.Equal(doc.WithData(doc.Info(), creator, doc.SignCode(), doc.Title(), doc.Size(), signers).Hash()))
+
+	// the neighboring fields are not confused
+	a := NewDocSign(signers[0].Address(), "user", false)
+	b := a
+	b.signcode = "use"
+	t.NotEqual(a.Bytes(), b.Bytes())
+
+	// NOTE the legacy encoding keeps the hash of existing documents, which
+	// does not have the size and signcode of signer
+	doc.hint = DocumentDataLegacyHint
+	doc.creator.hint = DocSignLegacyHint
+	for i := range signers {
+		signers[i].hint = DocSignLegacyHint
+		nsigners[i].hint = DocSignLegacyHint
+	}
+	doc = doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), doc.Size(), signers)
+
+	lh := doc.Hash()
+	t.True(lh.Equal(doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), currency.NewBig(334), signers).Hash()))
+	t.True(lh.Equal(doc.WithData(doc.Info(), doc.creator, doc.SignCode(), doc.Title(), doc.Size(), nsigners).Hash()))
+	t.False(lh.Equal(h))
+}
+
+func (t *testDocumentData) TestLegacyBytes() {
+	legacy := func(ds DocSign) DocSign {
+		ds.hint = DocSignLegacyHint
+
+		return ds
+	}
+
+	ds0 := legacy(NewDocSign(MustAddress("signer0"), "user1", false))
+	ds1 := legacy(NewDocSign(MustAddress("signer1"), "user2", true))
+
+	doc := NewDocumentData(
+		MustNewDocInfo(3, FileHash("ABCD")), MustAddress("creator0"), "user0", "title", currency.NewBig(333),
+		[]DocSign{ds1, ds0},
+	)
+	doc.hint = DocumentDataLegacyHint
+	doc.creator = legacy(doc.creator)
+
+	// NOTE the bytes and hashes are generated by the encoding before the
+	// canonical encoding
+	t.Equal("7369676e6572303a6d63612d76302e302e3100", hex.EncodeToString(ds0.Bytes()))
+	t.Equal("3HC755XU7kLfubTBArz56ozGhXx3Kp57JBvNet9jAgdC", ds0.Hash().String())
+	t.Equal("7369676e6572313a6d63612d76302e302e3101", hex.EncodeToString(ds1.Bytes()))
+	t.Equal("4hRgjJ2aXmuhcwVGEwTTc76BtKbyW9jNaMaPx3XrWJc1", ds1.Hash().String())
+	t.Equal(
+		"034142434463726561746f72303a6d63612d76302e302e31017469746c65"+
+			"7369676e6572303a6d63612d76302e302e3100"+
+			"7369676e6572313a6d63612d76302e302e3101",
+		hex.EncodeToString(doc.Bytes()),
+	)
+	t.Equal("4nE8VgPFeAQuDNd51Am7U18px76aDTdHcNGPKz3iopS6", doc.Hash().String())
+
+	// the signing status of legacy docsign is upgraded by SetStatus
+	ndoc, err := doc.SetSignerStatus(ds0.Address(), DocSignRejected, base.Height(33))
+	t.NoError(err)
+
+	ds, _ := ndoc.Signer(ds0.Address())
+	t.Equal(DocSignHint, ds.Hint())
+	t.False(doc.Hash().Equal(ndoc.Hash()))
+}
+
+func (t *testDocumentData) TestDecodeLegacy() {
+	for _, enc := range []encoder.Encoder{jsonenc.NewEncoder(), bsonenc.NewEncoder()} {
+		encs := encoder.NewEncoders()
+		t.NoError(encs.AddEncoder(enc))
+		t.NoError(encs.TestAddHinter(currency.Address("")))
+		t.NoError(encs.TestAddHinter(DocInfo{}))
+		t.NoError(encs.TestAddHinter(DocSign{}))
+		t.NoError(encs.TestAddHinter(DocumentData{}))
+
+		doc, signers := t.newDocumentData()
+		doc.hint = DocumentDataLegacyHint
+		doc.creator.hint = DocSignLegacyHint
+		for i := range signers {
+			signers[i].hint = DocSignLegacyHint
+		}
+
+		b, err := enc.Marshal(doc)
+		t.NoError(err)
+
+		udoc, err := DecodeDocumentData(b, enc)
+		t.NoError(err)
+
+		t.Equal(DocumentDataLegacyHint, udoc.Hint())
+		t.Equal(DocSignLegacyHint, udoc.Signers()[0].Hint())
+		t.Equal(doc.legacyBytes(), udoc.Bytes())
+		t.True(doc.Hash().Equal(udoc.Hash()))
+		t.Equal(signers[0].legacyBytes(), udoc.Signers()[0].Bytes())
+		t.True(doc.Equal(udoc))
+	}
+}
+
+func (t *testDocumentData) TestEqualHint() {
+	doc, _ := t.newDocumentData()
+
+	ldoc := doc
+	ldoc.hint = DocumentDataLegacyHint
+
+	t.True(doc.Equal(doc))
+	t.False(doc.Equal(ldoc))
+	t.False(ldoc.Equal(doc))
+
+	ds := doc.Signers()[0]
+	lds := ds
+	lds.hint = DocSignLegacyHint
+
+	t.False(ds.Equal(lds))
+}
+
+func (t *testDocumentData) TestDocSignIsEmpty() {
+	t.True(DocSign{}.IsEmpty())
+	t.True(NewDocSign(nil, "user0", false).IsEmpty())
+	t.True(NewDocSign(currency.Address(""), "user0", false).IsEmpty())
+	t.False(NewDocSign(MustAddress(util.UUID().String()), "user0", false).IsEmpty())
+}
+
+func (t *testDocumentData) TestSignCodeCommitment() {
+	sc := SignCode("user0")
+	t.False(sc.IsCommitment())
+
+	c := sc.Commitment()
+	t.True(c.IsCommitment())
+	t.NoError(c.IsValid(nil))
+	t.Equal(c, c.Commitment())
+
+	t.True(c.Verify("user0"))
+	t.False(c.Verify("user1"))
+	t.False(c.Verify(c.String()))
+
+	// NOTE plain signcode is compared as it is
+	t.True(sc.Verify("user0"))
+	t.False(sc.Verify(c.String()))
+
+	t.False(SignCode(SignCodeCommitmentPrefix + "abcd").IsCommitment())
+}
+
 func TestDocumentData(t *testing.T) {
 	suite.Run(t, new(testDocumentData))
 }
@@ -70,7 +520,15 @@ func testDocumentDataEncode(enc encoder.Encoder) suite.TestingSuite {
 		aSigncode0 := "user0"
 		aSigncode1 := "user1"
 
-		sDocSigns := []DocSign{MustNewDocSign(aSigner, aSigncode1, true)}
+		rPkey := key.MustNewBTCPrivatekey()
+		rKey, _ := currency.NewKey(rPkey.Publickey(), 100)
+		rKeys, _ := currency.NewKeys([]currency.Key{rKey}, 100)
+		aRejecter, _ := currency.NewAddressFromKeys(rKeys)
+
+		sDocSigns := []DocSign{
+			MustNewDocSign(aSigner, aSigncode1, true),
+			NewDocSignWithStatus(aRejecter, "user2", DocSignRejected, base.Height(3)).WithWeight(2),
+		}
 		title := "title"
 		size := currency.NewBig(333)
 
@@ -79,7 +537,15 @@ func testDocumentDataEncode(enc encoder.Encoder) suite.TestingSuite {
 			filehash: fh,
 		}
 
-		a := MustNewDocumentData(info, aCreator, aSigncode0, title, size, sDocSigns)
+		a := MustNewDocumentData(info, aCreator, aSigncode0, title, size, sDocSigns).
+			WithExpiry(base.Height(9)).
+			WithSigningMode(SigningSequential).
+			WithThreshold(2).
+			Revise(FileHash("EFGH"), "title2", currency.NewBig(444), base.Height(5)).
+			Cancel(base.Height(7)).
+			WithMetadata(DocumentMetadata{MetadataKeyReference: "REF-0001", MetadataKeyTags: "hr"}).
+			WithEscrow(currency.NewAmount(currency.NewBig(12), currency.CurrencyID("SHOWME"))).
+			WithEscrowPayer(MustAddress(util.UUID().String()))
 
 		t.NoError(a.IsValid(nil))
 
@@ -129,9 +595,23 @@ func testDocumentDataEncode(enc encoder.Encoder) suite.TestingSuite {
 		t.True(ca.FileHash().Equal(cb.FileHash()))
 		t.True(ca.Creator().Equal(cb.Creator()))
 		signers := ca.Signers()
+		t.Equal(len(signers), len(cb.Signers()))
 		for i := range signers {
 			t.True(signers[i].Equal(cb.Signers()[i]))
 		}
+		t.Equal(ca.Expiry(), cb.Expiry())
+		t.Equal(ca.SigningMode(), cb.SigningMode())
+		t.Equal(ca.Threshold(), cb.Threshold())
+		t.Equal(ca.Canceled(), cb.Canceled())
+		t.True(ca.Metadata().Equal(cb.Metadata()))
+		t.True(cb.HasEscrow())
+		t.True(ca.Escrow().Equal(cb.Escrow()))
+		t.True(ca.EscrowPayer().Equal(cb.EscrowPayer()))
+		t.Equal(len(ca.Revisions()), len(cb.Revisions()))
+		for i := range ca.Revisions() {
+			t.True(ca.Revisions()[i].Equal(cb.Revisions()[i]))
+		}
+		t.True(ca.Hash().Equal(cb.Hash()))
 	}
 
 	return t
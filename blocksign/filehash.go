@@ -0,0 +1,216 @@
+package blocksign
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
+)
+
+// FileHashAlgorithm is the hash algorithm of FileHash.
+type FileHashAlgorithm string
+
+const (
+	FileHashSHA256   FileHashAlgorithm = "sha256"
+	FileHashSHA3256  FileHashAlgorithm = "sha3-256"
+	FileHashBLAKE2b  FileHashAlgorithm = "blake2b"
+	FileHashAlgoNone FileHashAlgorithm = ""
+)
+
+// FileHashAlgorithmSeparator separates algorithm and hex encoded digest of
+// FileHash, like "sha256:<digest>".
+const FileHashAlgorithmSeparator = ":"
+
+// MaxLegacyFileHashLength limits the length of plain FileHash without
+// algorithm.
+var MaxLegacyFileHashLength = 128
+
+// Size returns the digest size in bytes.
+func (algo FileHashAlgorithm) Size() int {
+	switch algo {
+	case FileHashSHA256, FileHashSHA3256, FileHashBLAKE2b:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// New returns new hash.Hash of algorithm.
+func (algo FileHashAlgorithm) New() (hash.Hash, error) {
+	switch algo {
+	case FileHashSHA256:
+		return sha256.New(), nil
+	case FileHashSHA3256:
+		return sha3.New256(), nil
+	case FileHashBLAKE2b:
+		return blake2b.New256(nil)
+	default:
+		return nil, errors.Errorf("unknown filehash algorithm, %q", algo)
+	}
+}
+
+func (algo FileHashAlgorithm) String() string {
+	return string(algo)
+}
+
+func (algo FileHashAlgorithm) IsValid([]byte) error {
+	if algo.Size() < 1 {
+		return errors.Errorf("unknown filehash algorithm, %q", algo)
+	}
+
+	return nil
+}
+
+// FileHash is the hash of document file, "<algorithm>:<hex encoded digest>";
+// the algorithm should be one of the known algorithms and FileHash should be
+// lower case. Any other FileHash is the plain string of the previous versions;
+// it is accepted only in the existing blocks by IsValidLegacy. FileHash is
+// encoded as string in JSON and BSON both.
+type FileHash string
+
+// NewFileHash makes FileHash from digest.
+func NewFileHash(algo FileHashAlgorithm, digest []byte) (FileHash, error) {
+	if err := algo.IsValid(nil); err != nil {
+		return FileHash(""), err
+	}
+
+	fh := FileHash(algo.String() + FileHashAlgorithmSeparator + hex.EncodeToString(digest))
+
+	return fh, fh.IsValid(nil)
+}
+
+// NewFileHashFromReader hashes all of reader and returns FileHash with the
+// read size.
+func NewFileHashFromReader(algo FileHashAlgorithm, r io.Reader) (FileHash, int64, error) {
+	h, err := algo.New()
+	if err != nil {
+		return FileHash(""), 0, err
+	}
+
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return FileHash(""), 0, errors.Wrap(err, "failed to read file")
+	}
+
+	fh, err := NewFileHash(algo, h.Sum(nil))
+
+	return fh, n, err
+}
+
+// ParseFileHash parses string and validates it as FileHash of the current
+// items; the known algorithm and digest are converted to lower case.
+func ParseFileHash(s string) (FileHash, error) {
+	fh := normalizeFileHash(s)
+
+	return fh, fh.IsValid(nil)
+}
+
+// ParseLegacyFileHash parses string like ParseFileHash, but the plain FileHash
+// of the previous versions is also allowed.
+func ParseLegacyFileHash(s string) (FileHash, error) {
+	fh := normalizeFileHash(s)
+
+	return fh, fh.IsValidLegacy()
+}
+
+func normalizeFileHash(s string) FileHash {
+	fh := FileHash(strings.TrimSpace(s))
+	if lfh := FileHash(strings.ToLower(fh.String())); lfh.IsValid(nil) == nil {
+		return lfh
+	}
+
+	return fh
+}
+
+func (fh FileHash) Bytes() []byte {
+	return []byte(fh)
+}
+
+func (fh FileHash) String() string {
+	return string(fh)
+}
+
+// Algorithm returns the algorithm of FileHash regardless of case; plain
+// FileHash returns FileHashAlgoNone.
+func (fh FileHash) Algorithm() FileHashAlgorithm {
+	i := strings.Index(string(fh), FileHashAlgorithmSeparator)
+	if i < 0 {
+		return FileHashAlgoNone
+	}
+
+	if algo := FileHashAlgorithm(strings.ToLower(string(fh[:i]))); algo.Size() > 0 {
+		return algo
+	}
+
+	return FileHashAlgoNone
+}
+
+// Digest returns the hex encoded digest; plain FileHash returns itself.
+func (fh FileHash) Digest() string {
+	algo := fh.Algorithm()
+	if algo == FileHashAlgoNone {
+		return string(fh)
+	}
+
+	return string(fh[len(algo)+len(FileHashAlgorithmSeparator):])
+}
+
+// IsLegacy returns true if FileHash does not start with the known algorithm.
+func (fh FileHash) IsLegacy() bool {
+	return fh.Algorithm() == FileHashAlgoNone
+}
+
+// IsValid checks FileHash of the current items; only the lower case FileHash
+// with the known algorithm and the digest of correct length is valid.
+func (fh FileHash) IsValid([]byte) error {
+	if len(fh) < 1 {
+		return errors.Errorf("empty fileHash")
+	}
+
+	algo := fh.Algorithm()
+	if algo == FileHashAlgoNone {
+		return errors.Errorf("fileHash should be <algorithm>:<digest> with the known algorithm, %q", fh)
+	}
+
+	if p := string(fh[:len(algo)]); p != algo.String() {
+		return errors.Errorf("algorithm of fileHash should be lower case, %q", p)
+	}
+
+	d := fh.Digest()
+	if len(d) != algo.Size()*2 {
+		return errors.Errorf("wrong digest length of %s fileHash, %d != %d", algo, len(d), algo.Size()*2)
+	}
+
+	if strings.ToLower(d) != d {
+		return errors.Errorf("digest of fileHash should be lower case hex, %q", d)
+	}
+
+	if _, err := hex.DecodeString(d); err != nil {
+		return errors.Wrapf(err, "invalid digest of %s fileHash", algo)
+	}
+
+	return nil
+}
+
+// IsValidLegacy checks FileHash of the existing blocks; the plain FileHash of
+// the previous versions is also valid.
+func (fh FileHash) IsValidLegacy() error {
+	if len(fh) < 1 {
+		return errors.Errorf("empty fileHash")
+	}
+
+	if len(fh) > MaxLegacyFileHashLength {
+		return errors.Errorf("fileHash too long, %d > %d", len(fh), MaxLegacyFileHashLength)
+	}
+
+	return nil
+}
+
+func (fh FileHash) Equal(b FileHash) bool {
+	return fh == b
+}
@@ -0,0 +1,129 @@
+package blocksign
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/stretchr/testify/suite"
+
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type testFileHash struct {
+	suite.Suite
+}
+
+func (t *testFileHash) TestNew() {
+	d := sha256.Sum256([]byte("showme"))
+
+	fh, err := NewFileHash(FileHashSHA256, d[:])
+	t.NoError(err)
+	t.Equal(FileHashSHA256, fh.Algorithm())
+	t.False(fh.IsLegacy())
+	t.True(strings.HasPrefix(fh.String(), "sha256:"))
+	t.Equal(64, len(fh.Digest()))
+
+	_, err = NewFileHash(FileHashAlgorithm("md5"), d[:])
+	t.Contains(err.Error(), "unknown filehash algorithm")
+
+	_, err = NewFileHash(FileHashBLAKE2b, d[:16])
+	t.Contains(err.Error(), "wrong digest length")
+}
+
+func (t *testFileHash) TestLegacy() {
+	fh := FileHash("ABCD")
+	t.Contains(fh.IsValid(nil).Error(), "known algorithm")
+	t.NoError(fh.IsValidLegacy())
+	t.True(fh.IsLegacy())
+	t.Equal(FileHashAlgoNone, fh.Algorithm())
+	t.Equal("ABCD", fh.Digest())
+
+	t.Contains(FileHash("").IsValid(nil).Error(), "empty fileHash")
+	t.Contains(FileHash("").IsValidLegacy().Error(), "empty fileHash")
+	t.NoError(FileHash("AB CD").IsValidLegacy())
+	t.Contains(FileHash(strings.Repeat("A", MaxLegacyFileHashLength+1)).IsValidLegacy().Error(), "fileHash too long")
+}
+
+func (t *testFileHash) TestInvalidDigest() {
+	t.Contains(FileHash("sha3-256:"+strings.Repeat("z", 64)).IsValid(nil).Error(), "invalid digest")
+	t.Contains(FileHash("sha3-256:"+strings.Repeat("A", 64)).IsValid(nil).Error(), "lower case hex")
+	t.Contains(FileHash("sha256:abcd").IsValid(nil).Error(), "wrong digest length")
+}
+
+func (t *testFileHash) TestUpperCaseAlgorithm() {
+	fh := FileHash("SHA256:" + strings.Repeat("ab", 32))
+	t.Equal(FileHashSHA256, fh.Algorithm())
+	t.False(fh.IsLegacy())
+	t.Equal(strings.Repeat("ab", 32), fh.Digest())
+	t.Contains(fh.IsValid(nil).Error(), "algorithm of fileHash should be lower case")
+}
+
+func (t *testFileHash) TestUnknownAlgorithm() {
+	for _, s := range []string{"md5:" + strings.Repeat("a", 32), "foo:bar", ":ABCD"} {
+		fh := FileHash(s)
+		t.Contains(fh.IsValid(nil).Error(), "known algorithm")
+		t.NoError(fh.IsValidLegacy())
+		t.True(fh.IsLegacy())
+		t.Equal(FileHashAlgoNone, fh.Algorithm())
+		t.Equal(s, fh.Digest())
+	}
+
+	_, err := ParseFileHash("Foo:Bar")
+	t.Contains(err.Error(), "known algorithm")
+}
+
+func (t *testFileHash) TestParse() {
+	fh, err := ParseFileHash(" SHA256:" + strings.Repeat("AB", 32) + " ")
+	t.NoError(err)
+	t.Equal(FileHash("sha256:"+strings.Repeat("ab", 32)), fh)
+
+	_, err = ParseFileHash("ABCD")
+	t.Contains(err.Error(), "known algorithm")
+
+	_, err = ParseFileHash("blake2b:abcd")
+	t.Error(err)
+}
+
+func (t *testFileHash) TestParseLegacy() {
+	fh, err := ParseLegacyFileHash(" SHA256:" + strings.Repeat("AB", 32) + " ")
+	t.NoError(err)
+	t.Equal(FileHash("sha256:"+strings.Repeat("ab", 32)), fh)
+
+	// NOTE plain FileHash is not converted
+	for _, s := range []string{"ABCD", "Foo:Bar", "SHA256:ABCD"} {
+		fh, err := ParseLegacyFileHash(s)
+		t.NoError(err)
+		t.Equal(FileHash(s), fh)
+	}
+}
+
+func (t *testFileHash) TestEncode() {
+	d := sha256.Sum256([]byte("findme"))
+	typed, err := NewFileHash(FileHashSHA3256, d[:])
+	t.NoError(err)
+
+	for _, fh := range []FileHash{FileHash("ABCD"), typed} {
+		di := DocInfo{idx: currency.NewBig(3), filehash: fh}
+
+		b, err := jsonenc.Marshal(di)
+		t.NoError(err)
+
+		var ujdi DocInfo
+		t.NoError(ujdi.UnpackJSON(b, jsonenc.NewEncoder()))
+		t.True(di.Equal(ujdi))
+
+		b, err = bsonenc.Marshal(di)
+		t.NoError(err)
+
+		var ubdi DocInfo
+		t.NoError(bsonenc.Unmarshal(b, &ubdi))
+		t.True(di.Equal(ubdi))
+	}
+}
+
+func TestFileHash(t *testing.T) {
+	suite.Run(t, new(testFileHash))
+}
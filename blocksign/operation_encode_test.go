@@ -54,13 +54,35 @@ func (t *baseTestEncode) SetupSuite() {
 	t.encs.AddHinter(CreateDocuments{})
 	t.encs.AddHinter(SignDocumentsFact{})
 	t.encs.AddHinter(SignDocuments{})
+	t.encs.AddHinter(RejectDocumentsFact{})
+	t.encs.AddHinter(RejectDocuments{})
+	t.encs.AddHinter(RevokeSignDocumentsFact{})
+	t.encs.AddHinter(RevokeSignDocuments{})
+	t.encs.AddHinter(TransferDocumentsFact{})
+	t.encs.AddHinter(TransferDocuments{})
+	t.encs.AddHinter(UpdateDocumentSignersFact{})
+	t.encs.AddHinter(UpdateDocumentSigners{})
+	t.encs.AddHinter(ReviseDocumentsFact{})
+	t.encs.AddHinter(ReviseDocuments{})
+	t.encs.AddHinter(CancelDocumentsFact{})
+	t.encs.AddHinter(CancelDocuments{})
 	t.encs.AddHinter(DocumentData{})
 	t.encs.AddHinter(DocInfo{})
+	t.encs.AddHinter(DocId{})
 	t.encs.AddHinter(DocSign{})
+	t.encs.AddHinter(DocRevision{})
+	t.encs.AddHinter(DocFile{})
 	t.encs.AddHinter(key.BTCPublickeyHinter)
 	t.encs.AddHinter(CreateDocumentsItemSingleFile{})
 	t.encs.AddHinter(CreateDocumentsItemSingleFileHinter)
+	t.encs.AddHinter(CreateDocumentsItemMultiFilesHinter)
 	t.encs.AddHinter(SignItemSingleDocumentHinter)
+	t.encs.AddHinter(RejectItemSingleDocumentHinter)
+	t.encs.AddHinter(RevokeSignItemSingleDocumentHinter)
+	t.encs.AddHinter(TransferItemSingleDocumentHinter)
+	t.encs.AddHinter(UpdateSignersItemSingleDocumentHinter)
+	t.encs.AddHinter(ReviseItemSingleDocumentHinter)
+	t.encs.AddHinter(CancelItemSingleDocumentHinter)
 	t.encs.AddHinter(currency.CreateAccountsItemMultiAmountsHinter)
 	t.encs.AddHinter(currency.CreateAccountsItemSingleAmountHinter)
 	t.encs.AddHinter(currency.TransfersItemMultiAmountsHinter)
@@ -26,6 +26,14 @@ const (
 	DuplicationTypeCurrency DuplicationType = "currency"
 )
 
+// stateKeysLocker is the document processor, which changes the states found
+// in PreProcess, like the pending document inventories of signers and the
+// balances of escrow payers; the states are locked in proposal like document
+// states.
+type stateKeysLocker interface {
+	lockedKeys() []string
+}
+
 type OperationProcessor struct {
 	sync.RWMutex
 	*logging.Logging
@@ -36,6 +44,10 @@ type OperationProcessor struct {
 	amountPool           map[string]currency.AmountState
 	duplicated           map[string]DuplicationType
 	duplicatedNewAddress map[string]struct{}
+	duplicatedDocument   map[string]struct{}
+	lastDocumentId       state.State  // last document id state, assigned in proposal
+	setLastDocumentId    currency.Big // last document id, which is already set in pool
+	documentPolicy       *DocumentPolicy
 }
 
 func NewOperationProcessor(cp *currency.CurrencyPool) *OperationProcessor {
@@ -60,6 +72,7 @@ func (opr *OperationProcessor) New(pool *storage.Statepool) prprocessor.Operatio
 		amountPool:           map[string]currency.AmountState{},
 		duplicated:           map[string]DuplicationType{},
 		duplicatedNewAddress: map[string]struct{}{},
+		duplicatedDocument:   map[string]struct{}{},
 	}
 }
 
@@ -73,10 +86,44 @@ func (opr *OperationProcessor) SetProcessor(
 	return opr, nil
 }
 
+func (opr *OperationProcessor) getState(key string) (state.State, bool, error) {
+	if IsStateLastDocumentIdKey(key) {
+		opr.RLock()
+		st := opr.lastDocumentId
+		opr.RUnlock()
+
+		if st != nil {
+			return st, true, nil
+		}
+	}
+
+	return opr.pool.Get(key)
+}
+
 func (opr *OperationProcessor) setState(op valuehash.Hash, sts ...state.State) error {
 	opr.Lock()
 	defer opr.Unlock()
 
+	// NOTE operations are processed concurrently, so the last document id
+	// state is set only when it is greater than the already set one.
+	nsts := make([]state.State, 0, len(sts))
+	for i := range sts {
+		if IsStateLastDocumentIdKey(sts[i].Key()) {
+			id, err := StateLastDocumentIdValue(sts[i])
+			if err != nil {
+				return err
+			}
+
+			if opr.setLastDocumentId.Int != nil && id.Index().Compare(opr.setLastDocumentId) <= 0 {
+				continue
+			}
+			opr.setLastDocumentId = id.Index()
+		}
+
+		nsts = append(nsts, sts[i])
+	}
+	sts = nsts
+
 	for i := range sts {
 		if t, ok := sts[i].(currency.AmountState); ok {
 			if t.Fee().OverZero() {
@@ -104,15 +151,47 @@ func (opr *OperationProcessor) PreProcess(op state.Processor) (state.Processor,
 		sp = i
 	}
 
-	pop, err := sp.(state.PreProcessor).PreProcess(opr.pool.Get, opr.setState)
+	policy, err := opr.loadDocumentPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE the height of pool is recorded in document data
+	switch t := sp.(type) {
+	case *CreateDocumentsProcessor:
+		t.height = opr.pool.Height()
+		t.policy = policy
+	case *SignDocumentsProcessor:
+		t.height = opr.pool.Height()
+		t.policy = policy
+	case *RejectDocumentsProcessor:
+		t.height = opr.pool.Height()
+	case *RevokeSignDocumentsProcessor:
+		t.height = opr.pool.Height()
+	case *UpdateDocumentSignersProcessor:
+		t.height = opr.pool.Height()
+	case *ReviseDocumentsProcessor:
+		t.height = opr.pool.Height()
+		t.policy = policy
+	case *CancelDocumentsProcessor:
+		t.height = opr.pool.Height()
+	}
+
+	pop, err := sp.(state.PreProcessor).PreProcess(opr.getState, opr.setState)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := opr.checkDuplication(op); err != nil {
+	if err := opr.checkDuplication(op, pop); err != nil {
 		return nil, operation.NewBaseReasonError("duplication found: %w", err)
 	}
 
+	if t, ok := pop.(*CreateDocumentsProcessor); ok {
+		opr.Lock()
+		opr.lastDocumentId = t.nlids
+		opr.Unlock()
+	}
+
 	return pop, nil
 }
 
@@ -123,10 +202,30 @@ func (opr *OperationProcessor) Process(op state.Processor) error {
 		*currency.KeyUpdaterProcessor,
 		*currency.CurrencyRegisterProcessor,
 		*currency.CurrencyPolicyUpdaterProcessor,
+		*DocumentPolicyUpdaterProcessor,
 		*CreateDocumentsProcessor,
-		*SignDocumentsProcessor:
+		*SignDocumentsProcessor,
+		*RejectDocumentsProcessor,
+		*RevokeSignDocumentsProcessor,
+		*TransferDocumentsProcessor,
+		*UpdateDocumentSignersProcessor,
+		*ReviseDocumentsProcessor,
+		*CancelDocumentsProcessor:
 		return opr.process(op)
-	case currency.Transfers, currency.CreateAccounts, currency.KeyUpdater, currency.CurrencyRegister, currency.CurrencyPolicyUpdater, CreateDocuments, SignDocuments:
+	case currency.Transfers,
+		currency.CreateAccounts,
+		currency.KeyUpdater,
+		currency.CurrencyRegister,
+		currency.CurrencyPolicyUpdater,
+		DocumentPolicyUpdater,
+		CreateDocuments,
+		SignDocuments,
+		RejectDocuments,
+		RevokeSignDocuments,
+		TransferDocuments,
+		UpdateDocumentSigners,
+		ReviseDocuments,
+		CancelDocuments:
 		pr, err := opr.PreProcess(op)
 		if err != nil {
 			return err
@@ -151,6 +250,18 @@ func (opr *OperationProcessor) process(op state.Processor) error {
 		sp = t
 	case *SignDocumentsProcessor:
 		sp = t
+	case *RejectDocumentsProcessor:
+		sp = t
+	case *RevokeSignDocumentsProcessor:
+		sp = t
+	case *TransferDocumentsProcessor:
+		sp = t
+	case *UpdateDocumentSignersProcessor:
+		sp = t
+	case *ReviseDocumentsProcessor:
+		sp = t
+	case *CancelDocumentsProcessor:
+		sp = t
 	default:
 		return op.Process(opr.pool.Get, opr.pool.Set)
 	}
@@ -158,13 +269,32 @@ func (opr *OperationProcessor) process(op state.Processor) error {
 	return sp.Process(opr.pool.Get, opr.setState)
 }
 
-func (opr *OperationProcessor) checkDuplication(op state.Processor) error {
+// loadDocumentPolicy loads DocumentPolicy at the first PreProcess in proposal,
+// before any operation is processed; DocumentPolicyUpdater is applied from the
+// next block.
+func (opr *OperationProcessor) loadDocumentPolicy() (DocumentPolicy, error) {
+	opr.Lock()
+	defer opr.Unlock()
+
+	if opr.documentPolicy == nil {
+		po, err := LoadDocumentPolicy(opr.pool.Get)
+		if err != nil {
+			return DocumentPolicy{}, err
+		}
+		opr.documentPolicy = &po
+	}
+
+	return *opr.documentPolicy, nil
+}
+
+func (opr *OperationProcessor) checkDuplication(op, pop state.Processor) error {
 	opr.Lock()
 	defer opr.Unlock()
 
 	var did string
 	var didtype DuplicationType
 	var newAddresses []base.Address
+	var documentKeys []string
 
 	switch t := op.(type) {
 	case currency.Transfers:
@@ -188,16 +318,86 @@ func (opr *OperationProcessor) checkDuplication(op state.Processor) error {
 	case currency.CurrencyPolicyUpdater:
 		did = t.Fact().(currency.CurrencyPolicyUpdaterFact).Currency().String()
 		didtype = DuplicationTypeCurrency
+	case DocumentPolicyUpdater:
+		documentKeys = []string{StateKeyDocumentPolicy}
 	case CreateDocuments:
-		did = t.Fact().(CreateDocumentsFact).Sender().String()
+		fact := t.Fact().(CreateDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, fileHashKeys(fact.Items()[i].Files())...)
+			if fact.Items()[i].IsDocumentIdOmitted() {
+				continue
+			}
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		documentKeys = append(documentKeys, StateKeyDocuments(fact.Sender()))
+		did = fact.Sender().String()
 		didtype = DuplicationTypeSender
 	case SignDocuments:
-		did = t.Fact().(SignDocumentsFact).Sender().String()
+		fact := t.Fact().(SignDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case RejectDocuments:
+		fact := t.Fact().(RejectDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case RevokeSignDocuments:
+		fact := t.Fact().(RevokeSignDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case TransferDocuments:
+		fact := t.Fact().(TransferDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys,
+				StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())),
+				StateKeyDocuments(fact.Items()[i].Receiver()),
+			)
+		}
+		documentKeys = append(documentKeys, StateKeyDocuments(fact.Sender()))
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case UpdateDocumentSigners:
+		fact := t.Fact().(UpdateDocumentSignersFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case ReviseDocuments:
+		fact := t.Fact().(ReviseDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys,
+				StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())),
+				StateKeyFileHash(fact.Items()[i].FileHash()),
+			)
+		}
+		documentKeys = append(documentKeys, StateKeyDocuments(fact.Sender()))
+		did = fact.Sender().String()
+		didtype = DuplicationTypeSender
+	case CancelDocuments:
+		fact := t.Fact().(CancelDocumentsFact)
+		for i := range fact.Items() {
+			documentKeys = append(documentKeys, StateKeyDocumentData(DocId(fact.Items()[i].DocumentId())))
+		}
+		documentKeys = append(documentKeys, StateKeyDocuments(fact.Sender()))
+		did = fact.Sender().String()
 		didtype = DuplicationTypeSender
 	default:
 		return nil
 	}
 
+	if l, ok := pop.(stateKeysLocker); ok {
+		documentKeys = append(documentKeys, l.lockedKeys()...)
+	}
+
 	if len(did) > 0 {
 		if _, found := opr.duplicated[did]; found {
 			switch didtype {
@@ -219,6 +419,12 @@ func (opr *OperationProcessor) checkDuplication(op state.Processor) error {
 		}
 	}
 
+	if len(documentKeys) > 0 {
+		if err := opr.checkDocumentDuplication(documentKeys); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -236,6 +442,33 @@ func (opr *OperationProcessor) checkNewAddressDuplication(as []base.Address) err
 	return nil
 }
 
+// checkDocumentDuplication prevents the document states, which are updated by
+// document operations, from being overwritten by another operation in the
+// same proposal.
+func (opr *OperationProcessor) checkDocumentDuplication(keys []string) error {
+	for i := range keys {
+		if _, found := opr.duplicatedDocument[keys[i]]; found {
+			return errors.Errorf("document state, %q already processed", keys[i])
+		}
+	}
+
+	for i := range keys {
+		opr.duplicatedDocument[keys[i]] = struct{}{}
+	}
+
+	return nil
+}
+
+// fileHashKeys returns the keys of file hash index states of files.
+func fileHashKeys(files []DocFile) []string {
+	keys := make([]string, len(files))
+	for i := range files {
+		keys[i] = StateKeyFileHash(files[i].FileHash())
+	}
+
+	return keys
+}
+
 func (opr *OperationProcessor) Close() error {
 	opr.RLock()
 	defer opr.RUnlock()
@@ -274,8 +507,15 @@ func (opr *OperationProcessor) getNewProcessor(op state.Processor) (state.Proces
 		currency.KeyUpdater,
 		currency.CurrencyRegister,
 		currency.CurrencyPolicyUpdater,
+		DocumentPolicyUpdater,
 		CreateDocuments,
-		SignDocuments:
+		SignDocuments,
+		RejectDocuments,
+		RevokeSignDocuments,
+		TransferDocuments,
+		UpdateDocumentSigners,
+		ReviseDocuments,
+		CancelDocuments:
 		return nil, false, errors.Errorf("%T needs SetProcessor", t)
 	default:
 		return op, false, nil
@@ -0,0 +1,187 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	RejectDocumentsFactType = hint.Type("mitum-blocksign-reject-documents-operation-fact")
+	RejectDocumentsFactHint = hint.NewHint(RejectDocumentsFactType, "v0.0.1")
+	RejectDocumentsType     = hint.Type("mitum-blocksign-reject-documents-operation")
+	RejectDocumentsHint     = hint.NewHint(RejectDocumentsType, "v0.0.1")
+)
+
+var MaxRejectDocumentsItems uint = 10
+
+type RejectDocumentsFact struct {
+	h      valuehash.Hash
+	token  []byte
+	sender base.Address
+	items  []SignDocumentItem
+}
+
+func NewRejectDocumentsFact(token []byte, sender base.Address, items []SignDocumentItem) RejectDocumentsFact {
+	fact := RejectDocumentsFact{
+		token:  token,
+		sender: sender,
+		items:  items,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact RejectDocumentsFact) Hint() hint.Hint {
+	return RejectDocumentsFactHint
+}
+
+func (fact RejectDocumentsFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact RejectDocumentsFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact RejectDocumentsFact) Bytes() []byte {
+	is := make([][]byte, len(fact.items))
+	for i := range fact.items {
+		is[i] = fact.items[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		util.ConcatBytesSlice(is...),
+	)
+}
+
+func (fact RejectDocumentsFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for RejectDocumentsFact")
+	} else if n := len(fact.items); n < 1 {
+		return errors.Errorf("empty items")
+	} else if n > int(MaxRejectDocumentsItems) {
+		return errors.Errorf("items, %d over max, %d", n, MaxRejectDocumentsItems)
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.sender,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	// check duplicated document
+	foundDocId := map[string]bool{}
+	for i := range fact.items {
+		if err := fact.items[i].IsValid(nil); err != nil {
+			return err
+		}
+		k := fact.items[i].DocumentId().String()
+		if _, found := foundDocId[k]; found {
+			return errors.Errorf("duplicated document found, %s", k)
+		}
+		foundDocId[k] = true
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact RejectDocumentsFact) Token() []byte {
+	return fact.token
+}
+
+func (fact RejectDocumentsFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact RejectDocumentsFact) Items() []SignDocumentItem {
+	return fact.items
+}
+
+func (fact RejectDocumentsFact) Addresses() ([]base.Address, error) {
+	as := make([]base.Address, 1)
+
+	as[0] = fact.Sender()
+
+	return as, nil
+}
+
+func (fact RejectDocumentsFact) Rebuild() RejectDocumentsFact {
+	items := make([]SignDocumentItem, len(fact.items))
+	for i := range fact.items {
+		it := fact.items[i]
+		items[i] = it.Rebuild()
+	}
+
+	fact.items = items
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+type RejectDocuments struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewRejectDocuments(fact RejectDocumentsFact, fs []operation.FactSign, memo string) (RejectDocuments, error) {
+	if bo, err := operation.NewBaseOperationFromFact(RejectDocumentsHint, fact, fs); err != nil {
+		return RejectDocuments{}, err
+	} else {
+		op := RejectDocuments{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (op RejectDocuments) Hint() hint.Hint {
+	return RejectDocumentsHint
+}
+
+func (op RejectDocuments) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op RejectDocuments) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op RejectDocuments) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
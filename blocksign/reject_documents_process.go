@@ -0,0 +1,66 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op RejectDocuments) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+// checkRejectSigner allows signer to reject document unless signer already
+// rejected it.
+func checkRejectSigner(_ DocumentData, ds DocSign, _ base.Height) error {
+	if ds.Rejected() {
+		return errors.Errorf("document already rejected by sender, %v", ds.Address())
+	}
+
+	return nil
+}
+
+type RejectDocumentsProcessor struct {
+	signerStatusProcessor
+	RejectDocuments
+}
+
+func NewRejectDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(RejectDocuments); !ok {
+			return nil, errors.Errorf("not RejectDocuments, %T", op)
+		} else {
+			return &RejectDocumentsProcessor{
+				signerStatusProcessor: signerStatusProcessor{cp: cp},
+				RejectDocuments:       i,
+			}, nil
+		}
+	}
+}
+
+func (opp *RejectDocumentsProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(RejectDocumentsFact)
+
+	if err := opp.preProcess(
+		opp.Hash(), fact.sender, fact.items, opp.Signs(), DocSignRejected, checkRejectSigner, getState, setState,
+	); err != nil {
+		return nil, err
+	}
+
+	return opp, nil
+}
+
+func (opp *RejectDocumentsProcessor) Process(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	return opp.process(opp.Fact().Hash(), getState, setState)
+}
@@ -0,0 +1,258 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testRejectDocumentsOperations struct {
+	baseTestOperationProcessor
+	cid       currency.CurrencyID
+	docid     currency.Big
+	fh        FileHash
+	fee       currency.Big
+	signcode0 string
+	title     string
+	size      currency.Big
+	signcode1 string
+}
+
+func (t *testRejectDocumentsOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.fee = currency.NewBig(3)
+	t.signcode0 = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+	t.signcode1 = "user1"
+}
+
+func (t *testRejectDocumentsOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(RejectDocuments{}, NewRejectDocumentsProcessor(cp))
+	t.NoError(err)
+
+	copr, err = copr.(*OperationProcessor).
+		SetProcessor(SignDocuments{}, NewSignDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testRejectDocumentsOperations) newRejectDocument(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []SignDocumentItem,
+) RejectDocuments {
+	token := util.UUID().Bytes()
+	fact := NewRejectDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	tfd, err := NewRejectDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(tfd.IsValid(nil))
+
+	return tfd
+}
+
+func (t *testRejectDocumentsOperations) newTestDocumentData(ca base.Address, ga base.Address, status DocSignStatus) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	return NewDocumentData(info, ca, t.signcode0, t.title, t.size, []DocSign{
+		NewDocSignWithStatus(ga, t.signcode1, status, base.NilHeight),
+	})
+}
+
+func (t *testRejectDocumentsOperations) updatedDocumentData(pool *storage.Statepool) DocumentData {
+	var dds state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentData(DocId(t.docid)) {
+			dds = stu.GetState()
+		}
+	}
+	t.NotNil(dds)
+
+	dd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	return dd
+}
+
+func (t *testRejectDocumentsOperations) TestNormalCase() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignPending)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRejectDocument(sa.Address, sa.Privs(), items)))
+
+	ndd := t.updatedDocumentData(pool)
+
+	ds, found := ndd.Signer(sa.Address)
+	t.True(found)
+	t.True(ds.Rejected())
+	t.False(ds.Signed())
+	t.Equal(pool.Height(), ds.Height())
+}
+
+func (t *testRejectDocumentsOperations) TestPendingDocuments() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignPending)
+
+	pst := t.newStatePendingDocuments(sa.Address, currency.ZeroBig, []DocInfo{dd.Info()})
+
+	pool, _ := t.statepool(sta, stb, t.newStateDocument(ca.Address, dd), []state.State{pst})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRejectDocument(sa.Address, sa.Privs(), items)))
+
+	// NOTE rejected document is removed from pending document inventory
+	inv, found := t.updatedPendingDocuments(pool, sa.Address)
+	t.True(found)
+	t.True(inv.IsEmpty())
+}
+
+func (t *testRejectDocumentsOperations) TestRejectSignedDocument() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignSigned)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRejectDocument(sa.Address, sa.Privs(), items)))
+
+	ds, found := t.updatedDocumentData(pool).Signer(sa.Address)
+	t.True(found)
+	t.Equal(DocSignRejected, ds.Status())
+}
+
+func (t *testRejectDocumentsOperations) TestAlreadyRejected() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignRejected)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	err := opr.Process(t.newRejectDocument(sa.Address, sa.Privs(), items))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document already rejected")
+}
+
+func (t *testRejectDocumentsOperations) TestSenderNotExistInSignersList() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender
+	ca, stb := t.newAccount(true, balance) // creator, owner
+	ga, stc := t.newAccount(true, balance) // signer
+
+	dd := t.newTestDocumentData(ca.Address, ga.Address, DocSignPending)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, stc, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	err := opr.Process(t.newRejectDocument(sa.Address, sa.Privs(), items))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "sender not found in document Signers")
+}
+
+func (t *testRejectDocumentsOperations) TestSignRejectedDocument() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignRejected)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	token := util.UUID().Bytes()
+	fact := NewSignDocumentsFact(token, sa.Address, []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docid, ca.Address, t.signcode1, t.cid)})
+	sig, err := operation.NewFactSignature(sa.Privs()[0], fact, nil)
+	t.NoError(err)
+	op, err := NewSignDocuments(fact, []operation.FactSign{operation.NewBaseFactSign(sa.Privs()[0].Publickey(), sig)}, "")
+	t.NoError(err)
+
+	t.NoError(opr.Process(op))
+
+	ds, found := t.updatedDocumentData(pool).Signer(sa.Address)
+	t.True(found)
+	t.Equal(DocSignSigned, ds.Status())
+	t.Equal(pool.Height(), ds.Height())
+}
+
+func TestRejectDocumentsOperations(t *testing.T) {
+	suite.Run(t, new(testRejectDocumentsOperations))
+}
@@ -0,0 +1,37 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	RejectItemSingleDocumentType   = hint.Type("mitum-blocksign-reject-item-single-document")
+	RejectItemSingleDocumentHint   = hint.NewHint(RejectItemSingleDocumentType, "v0.0.1")
+	RejectItemSingleDocumentHinter = BaseSignDocumentsItem{hint: RejectItemSingleDocumentHint}
+)
+
+type RejectDocumentsItemSingleFile struct {
+	BaseSignDocumentsItem
+}
+
+func NewRejectDocumentsItemSingleFile(docId currency.Big, owner base.Address, cid currency.CurrencyID) RejectDocumentsItemSingleFile {
+	return RejectDocumentsItemSingleFile{
+		BaseSignDocumentsItem: NewBaseSignDocumentsItem(RejectItemSingleDocumentHint, docId, owner, "", cid),
+	}
+}
+
+func (it RejectDocumentsItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseSignDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it RejectDocumentsItemSingleFile) Rebuild() SignDocumentItem {
+	it.BaseSignDocumentsItem = it.BaseSignDocumentsItem.Rebuild().(BaseSignDocumentsItem)
+
+	return it
+}
@@ -0,0 +1,156 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type testRejectDocumentsItemSingleFile struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+}
+
+func (t *testRejectDocumentsItemSingleFile) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+}
+
+func (t *testRejectDocumentsItemSingleFile) TestNew() {
+	s := MustAddress(util.UUID().String())
+	g := MustAddress(util.UUID().String())
+
+	token := util.UUID().Bytes()
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docId, s, t.cid)}
+	fact := NewRejectDocumentsFact(token, g, items)
+
+	var fs []operation.FactSign
+
+	for _, pk := range []key.Privatekey{
+		key.MustNewBTCPrivatekey(),
+		key.MustNewBTCPrivatekey(),
+		key.MustNewBTCPrivatekey(),
+	} {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	tf, err := NewRejectDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(tf.IsValid(nil))
+
+	t.Implements((*base.Fact)(nil), tf.Fact())
+	t.Implements((*operation.Operation)(nil), tf)
+}
+
+func (t *testRejectDocumentsItemSingleFile) TestZeroBig() {
+	s := MustAddress(util.UUID().String())
+	g := MustAddress(util.UUID().String())
+
+	token := util.UUID().Bytes()
+	cid := currency.CurrencyID("")
+	items := []SignDocumentItem{NewRejectDocumentsItemSingleFile(t.docId, s, cid)}
+
+	err := items[0].IsValid(nil)
+	t.Contains(err.Error(), "invalid length of currency id")
+
+	fact := NewRejectDocumentsFact(token, g, items)
+
+	pk := key.MustNewBTCPrivatekey()
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	tfd, err := NewRejectDocuments(fact, fs, "")
+	t.NoError(err)
+
+	err = tfd.IsValid(nil)
+	t.Contains(err.Error(), "invalid length of currency id")
+}
+
+func TestRejectDocumentsItemSingleFile(t *testing.T) {
+	suite.Run(t, new(testRejectDocumentsItemSingleFile))
+}
+
+func testRejectDocumentsItemSingleFileEncode(enc encoder.Encoder) suite.TestingSuite {
+	t := new(baseTestOperationEncode)
+
+	docId0 := currency.NewBig(0)
+	docId1 := currency.NewBig(1)
+	t.enc = enc
+	t.newObject = func() interface{} {
+		s := MustAddress(util.UUID().String())
+		g := MustAddress(util.UUID().String())
+
+		token := util.UUID().Bytes()
+		items := []SignDocumentItem{
+			NewRejectDocumentsItemSingleFile(docId0, s, currency.CurrencyID("SHOWME")),
+			NewRejectDocumentsItemSingleFile(docId1, s, currency.CurrencyID("FINDME")),
+		}
+		fact := NewRejectDocumentsFact(token, g, items)
+
+		var fs []operation.FactSign
+
+		for _, pk := range []key.Privatekey{
+			key.MustNewBTCPrivatekey(),
+			key.MustNewBTCPrivatekey(),
+			key.MustNewBTCPrivatekey(),
+		} {
+			sig, err := operation.NewFactSignature(pk, fact, nil)
+			t.NoError(err)
+
+			fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+		}
+
+		tfd, err := NewRejectDocuments(fact, fs, util.UUID().String())
+		t.NoError(err)
+
+		return tfd
+	}
+
+	t.compare = func(a, b interface{}) {
+		ta := a.(RejectDocuments)
+		tb := b.(RejectDocuments)
+
+		t.Equal(ta.Memo, tb.Memo)
+
+		fact := ta.Fact().(RejectDocumentsFact)
+		ufact := tb.Fact().(RejectDocumentsFact)
+
+		t.True(fact.sender.Equal(ufact.sender))
+		t.Equal(len(fact.Items()), len(ufact.Items()))
+
+		for i := range fact.Items() {
+			a := fact.Items()[i]
+			b := ufact.Items()[i]
+			t.True(a.DocumentId().Equal(b.DocumentId()))
+			t.True(a.Owner().Equal(b.Owner()))
+			t.Equal(a.Currency(), (b.Currency()))
+			t.True(a.Hint().Equal(b.Hint()))
+		}
+
+	}
+
+	return t
+}
+
+func TestRejectDocumentsItemSingleFileEncodeJSON(t *testing.T) {
+	suite.Run(t, testRejectDocumentsItemSingleFileEncode(jsonenc.NewEncoder()))
+}
+
+func TestRejectDocumentsItemSingleFileEncodeBSON(t *testing.T) {
+	suite.Run(t, testRejectDocumentsItemSingleFileEncode(bsonenc.NewEncoder()))
+}
@@ -0,0 +1,208 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	ReviseDocumentsFactType = hint.Type("mitum-blocksign-revise-documents-operation-fact")
+	ReviseDocumentsFactHint = hint.NewHint(ReviseDocumentsFactType, "v0.0.1")
+	ReviseDocumentsType     = hint.Type("mitum-blocksign-revise-documents-operation")
+	ReviseDocumentsHint     = hint.NewHint(ReviseDocumentsType, "v0.0.1")
+)
+
+var MaxReviseDocumentsItems uint = 10
+
+type ReviseDocumentsItem interface {
+	hint.Hinter
+	isvalid.IsValider
+	Bytes() []byte
+	DocumentId() currency.Big
+	Owner() base.Address
+	FileHash() FileHash
+	Title() string
+	Size() currency.Big
+	Currency() currency.CurrencyID
+	Rebuild() ReviseDocumentsItem
+}
+
+type ReviseDocumentsFact struct {
+	h      valuehash.Hash
+	token  []byte
+	sender base.Address
+	items  []ReviseDocumentsItem
+}
+
+func NewReviseDocumentsFact(token []byte, sender base.Address, items []ReviseDocumentsItem) ReviseDocumentsFact {
+	fact := ReviseDocumentsFact{
+		token:  token,
+		sender: sender,
+		items:  items,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact ReviseDocumentsFact) Hint() hint.Hint {
+	return ReviseDocumentsFactHint
+}
+
+func (fact ReviseDocumentsFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact ReviseDocumentsFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact ReviseDocumentsFact) Bytes() []byte {
+	is := make([][]byte, len(fact.items))
+	for i := range fact.items {
+		is[i] = fact.items[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		util.ConcatBytesSlice(is...),
+	)
+}
+
+func (fact ReviseDocumentsFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for ReviseDocumentsFact")
+	} else if n := len(fact.items); n < 1 {
+		return errors.Errorf("empty items")
+	} else if n > int(MaxReviseDocumentsItems) {
+		return errors.Errorf("items, %d over max, %d", n, MaxReviseDocumentsItems)
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.sender,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	// check duplicated document and filehash
+	foundDocId := map[string]bool{}
+	foundFileHash := map[string]bool{}
+	for i := range fact.items {
+		if err := fact.items[i].IsValid(nil); err != nil {
+			return err
+		}
+
+		if !fact.items[i].Owner().Equal(fact.sender) {
+			return errors.Errorf("sender is not owner of document, %s", fact.items[i].DocumentId())
+		}
+
+		k := fact.items[i].DocumentId().String()
+		if _, found := foundDocId[k]; found {
+			return errors.Errorf("duplicated document found, %s", k)
+		}
+		foundDocId[k] = true
+
+		fh := fact.items[i].FileHash().String()
+		if _, found := foundFileHash[fh]; found {
+			return errors.Errorf("duplicated filehash found, %s", fh)
+		}
+		foundFileHash[fh] = true
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact ReviseDocumentsFact) Token() []byte {
+	return fact.token
+}
+
+func (fact ReviseDocumentsFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact ReviseDocumentsFact) Items() []ReviseDocumentsItem {
+	return fact.items
+}
+
+func (fact ReviseDocumentsFact) Addresses() ([]base.Address, error) {
+	return []base.Address{fact.Sender()}, nil
+}
+
+func (fact ReviseDocumentsFact) Rebuild() ReviseDocumentsFact {
+	items := make([]ReviseDocumentsItem, len(fact.items))
+	for i := range fact.items {
+		it := fact.items[i]
+		items[i] = it.Rebuild()
+	}
+
+	fact.items = items
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+type ReviseDocuments struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewReviseDocuments(fact ReviseDocumentsFact, fs []operation.FactSign, memo string) (ReviseDocuments, error) {
+	if bo, err := operation.NewBaseOperationFromFact(ReviseDocumentsHint, fact, fs); err != nil {
+		return ReviseDocuments{}, err
+	} else {
+		op := ReviseDocuments{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (op ReviseDocuments) Hint() hint.Hint {
+	return ReviseDocumentsHint
+}
+
+func (op ReviseDocuments) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op ReviseDocuments) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op ReviseDocuments) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
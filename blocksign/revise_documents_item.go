@@ -0,0 +1,111 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+type BaseReviseDocumentsItem struct {
+	hint     hint.Hint
+	id       currency.Big
+	owner    base.Address
+	fileHash FileHash // filehash of new revision
+	title    string
+	size     currency.Big
+	cid      currency.CurrencyID
+}
+
+func NewBaseReviseDocumentsItem(ht hint.Hint,
+	id currency.Big,
+	owner base.Address,
+	filehash FileHash,
+	title string,
+	size currency.Big,
+	cid currency.CurrencyID,
+) BaseReviseDocumentsItem {
+	return BaseReviseDocumentsItem{
+		hint:     ht,
+		id:       id,
+		owner:    owner,
+		fileHash: filehash,
+		title:    title,
+		size:     size,
+		cid:      cid,
+	}
+}
+
+func (it BaseReviseDocumentsItem) Hint() hint.Hint {
+	return it.hint
+}
+
+func (it BaseReviseDocumentsItem) Bytes() []byte {
+	bs := make([][]byte, 6)
+	bs[0] = it.id.Bytes()
+	bs[1] = it.owner.Bytes()
+	bs[2] = it.fileHash.Bytes()
+	bs[3] = []byte(it.title)
+	bs[4] = it.size.Bytes()
+	bs[5] = it.cid.Bytes()
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (it BaseReviseDocumentsItem) IsValid([]byte) error {
+	if err := it.id.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.owner.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.fileHash.IsValid(nil); err != nil {
+		return err
+	}
+
+	if len(it.title) < 1 {
+		return errors.Errorf("empty title")
+	}
+
+	if !it.size.OverZero() {
+		return errors.Errorf("size should be over zero")
+	}
+
+	if err := it.cid.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it BaseReviseDocumentsItem) DocumentId() currency.Big {
+	return it.id
+}
+
+func (it BaseReviseDocumentsItem) Owner() base.Address {
+	return it.owner
+}
+
+// FileHash return BaseReviseDocumentsItem's filehash of new revision.
+func (it BaseReviseDocumentsItem) FileHash() FileHash {
+	return it.fileHash
+}
+
+func (it BaseReviseDocumentsItem) Title() string {
+	return it.title
+}
+
+func (it BaseReviseDocumentsItem) Size() currency.Big {
+	return it.size
+}
+
+func (it BaseReviseDocumentsItem) Currency() currency.CurrencyID {
+	return it.cid
+}
+
+func (it BaseReviseDocumentsItem) Rebuild() ReviseDocumentsItem {
+	return it
+}
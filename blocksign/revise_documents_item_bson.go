@@ -0,0 +1,45 @@
+package blocksign // nolint:dupl
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (it BaseReviseDocumentsItem) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()),
+			bson.M{
+				"documentid": it.id,
+				"owner":      it.owner,
+				"filehash":   it.fileHash,
+				"title":      it.title,
+				"size":       it.size,
+				"currency":   it.cid,
+			}),
+	)
+}
+
+type ReviseDocumentsItemBSONUnpacker struct {
+	DI currency.Big        `bson:"documentid"`
+	OW base.AddressDecoder `bson:"owner"`
+	FH string              `bson:"filehash"`
+	TL string              `bson:"title"`
+	SZ currency.Big        `bson:"size"`
+	CI string              `bson:"currency"`
+}
+
+func (it *BaseReviseDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ht bsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var urd ReviseDocumentsItemBSONUnpacker
+	if err := bson.Unmarshal(b, &urd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, urd.DI, urd.OW, urd.FH, urd.TL, urd.SZ, urd.CI)
+}
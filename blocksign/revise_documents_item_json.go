@@ -0,0 +1,52 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type ReviseDocumentsItemJSONPacker struct {
+	jsonenc.HintedHead
+	DI currency.Big        `json:"documentid"`
+	OW base.Address        `json:"owner"`
+	FH FileHash            `json:"filehash"`
+	TL string              `json:"title"`
+	SZ currency.Big        `json:"size"`
+	CI currency.CurrencyID `json:"currency"`
+}
+
+func (it BaseReviseDocumentsItem) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(ReviseDocumentsItemJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(it.Hint()),
+		DI:         it.id,
+		OW:         it.owner,
+		FH:         it.fileHash,
+		TL:         it.title,
+		SZ:         it.size,
+		CI:         it.cid,
+	})
+}
+
+type ReviseDocumentsItemJSONUnpacker struct {
+	DI currency.Big        `json:"documentid"`
+	OW base.AddressDecoder `json:"owner"`
+	FH string              `json:"filehash"`
+	TL string              `json:"title"`
+	SZ currency.Big        `json:"size"`
+	CI string              `json:"currency"`
+}
+
+func (it *BaseReviseDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ht jsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var urd ReviseDocumentsItemJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &urd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, urd.DI, urd.OW, urd.FH, urd.TL, urd.SZ, urd.CI)
+}
@@ -0,0 +1,287 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op ReviseDocuments) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+type ReviseDocumentsItemProcessor struct {
+	cp      *currency.CurrencyPool
+	policy  DocumentPolicy
+	sender  base.Address
+	height  base.Height
+	h       valuehash.Hash
+	item    ReviseDocumentsItem
+	docInfo DocInfo        // revised document info
+	signers []base.Address // signers of document, who have to sign the new revision
+	nds     state.State    // document data state (key = document id)
+	nfhs    state.State    // file hash index state of new revision
+	fhinv   DocumentInventory
+}
+
+func (opp *ReviseDocumentsItemProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) error {
+	if err := opp.item.IsValid(nil); err != nil {
+		return err
+	}
+
+	if !opp.item.Owner().Equal(opp.sender) {
+		return errors.Errorf("sender is not owner of document, %v", opp.item.DocumentId())
+	}
+
+	// check existence of document data state with documentid
+	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.item.DocumentId()))); {
+	case err != nil:
+		return err
+	case !found:
+		return errors.Errorf("document not registered with documentid, %q", opp.item.DocumentId())
+	default:
+		opp.nds = st
+	}
+
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return err
+	}
+
+	if !dd.Creator().Equal(opp.item.Owner()) {
+		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
+	}
+
+	if dd.IsCanceled() {
+		return errors.Errorf("document canceled at height, %v", dd.Canceled())
+	}
+
+	if dd.IsExpired(opp.height) {
+		return errors.Errorf("document expired at height, %v", dd.Expiry())
+	}
+
+	if dd.FileHash().Equal(opp.item.FileHash()) {
+		return errors.Errorf("filehash is same with current revision, %v", opp.item.FileHash())
+	}
+
+	opp.docInfo = DocInfo{idx: opp.item.DocumentId(), filehash: opp.item.FileHash()}
+
+	opp.signers = make([]base.Address, len(dd.Signers()))
+	for i := range dd.Signers() {
+		opp.signers[i] = dd.Signers()[i].Address()
+	}
+
+	// check file hash index
+	if st, fhinv, err := loadFileHashIndex(opp.item.FileHash(), opp.item.DocumentId(), opp.policy.UniqueFileHash(), getState); err != nil {
+		return err
+	} else {
+		opp.nfhs = st
+		opp.fhinv = fhinv
+	}
+
+	return nil
+}
+
+func (opp *ReviseDocumentsItemProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) ([]state.State, error) {
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return nil, err
+	}
+
+	dd = dd.Revise(opp.item.FileHash(), opp.item.Title(), opp.item.Size(), opp.height)
+
+	sts := make([]state.State, 2)
+	if dst, err := SetStateDocumentDataValue(opp.nds, dd); err != nil {
+		return nil, err
+	} else {
+		sts[0] = dst
+	}
+
+	if fhs, err := appendFileHashIndex(opp.nfhs, opp.fhinv, opp.docInfo); err != nil {
+		return nil, err
+	} else {
+		sts[1] = fhs
+	}
+
+	return sts, nil
+}
+
+type ReviseDocumentsProcessor struct {
+	cp     *currency.CurrencyPool
+	height base.Height    // height of block, which documents are revised in
+	policy DocumentPolicy // document policy of block
+	ReviseDocuments
+	docs     *documentPages                               // sender document inventory pages
+	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
+	ns       []*ReviseDocumentsItemProcessor              // ItemProcessor
+	required map[currency.CurrencyID][2]currency.Big      // Fee
+	pending  *documentPages                               // pending document inventory pages of signers
+}
+
+func NewReviseDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(ReviseDocuments); !ok {
+			return nil, errors.Errorf("not ReviseDocuments, %T", op)
+		} else {
+			return &ReviseDocumentsProcessor{
+				cp:              cp,
+				ReviseDocuments: i,
+			}, nil
+		}
+	}
+}
+
+func (opp *ReviseDocumentsProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(ReviseDocumentsFact)
+
+	// check sender account state existence
+	if err := checkExistsState(currency.StateKeyAccount(fact.sender), getState); err != nil {
+		return nil, err
+	}
+
+	// check existence of documents in sender document inventory
+	docs := newOwnerDocumentPages()
+	if err := docs.migrate(fact.sender, getState); err != nil {
+		return nil, err
+	}
+
+	for i := range fact.items {
+		if err := docs.load(fact.sender, fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+
+		if !docs.exists(fact.sender, fact.items[i].DocumentId()) {
+			return nil, operation.NewBaseReasonError(
+				"document not found in sender document inventory, %v", fact.items[i].DocumentId())
+		}
+	}
+
+	if required, err := opp.calculateItemsFee(); err != nil {
+		return nil, operation.NewBaseReasonError("failed to calculate fee: %w", err)
+	} else if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
+		return nil, err
+	} else {
+		opp.required = required
+		opp.sb = sb
+	}
+
+	ns := make([]*ReviseDocumentsItemProcessor, len(fact.items))
+	for i := range fact.items {
+		c := &ReviseDocumentsItemProcessor{
+			cp:     opp.cp,
+			policy: opp.policy,
+			sender: fact.sender,
+			height: opp.height,
+			h:      opp.Hash(),
+			item:   fact.items[i],
+		}
+		if err := c.PreProcess(getState, setState); err != nil {
+			return nil, operation.NewBaseReasonErrorFromError(err)
+		}
+
+		ns[i] = c
+	}
+
+	// check fact sign
+	if err := checkFactSignsByState(fact.sender, opp.Signs(), getState); err != nil {
+		return nil, operation.NewBaseReasonError("invalid signing: %w", err)
+	}
+
+	pending := newPendingDocumentPages()
+	for i := range ns {
+		for j := range ns[i].signers {
+			if err := pending.load(ns[i].signers[j], ns[i].docInfo.Index(), getState); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	opp.docs = docs
+	opp.ns = ns
+	opp.pending = pending
+
+	return opp, nil
+}
+
+func (opp *ReviseDocumentsProcessor) Process( // nolint:dupl
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := opp.Fact().(ReviseDocumentsFact)
+
+	var sts []state.State // nolint:prealloc
+
+	// replace doc info in sender document inventory with the revised one
+	for i := range opp.ns {
+		s, err := opp.ns[i].Process(getState, setState)
+		if err != nil {
+			return operation.NewBaseReasonError("failed to process revise document item: %w", err)
+		}
+		sts = append(sts, s...)
+
+		if err := opp.docs.remove(fact.sender, opp.ns[i].docInfo.Index()); err != nil {
+			return err
+		}
+
+		if err := opp.docs.append(fact.sender, opp.ns[i].docInfo); err != nil {
+			return err
+		}
+
+		// NOTE signing of signers is reset by revision, so signers have to
+		// sign the new revision again
+		for j := range opp.ns[i].signers {
+			if err := opp.pending.set(opp.ns[i].signers[j], opp.ns[i].docInfo); err != nil {
+				return err
+			}
+		}
+	}
+
+	if dts, err := opp.docs.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, dts...)
+	}
+
+	if pts, err := opp.pending.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, pts...)
+	}
+
+	for k := range opp.required {
+		rq := opp.required[k]
+		sts = append(sts, opp.sb[k].Sub(rq[0]).AddFee(rq[1]))
+	}
+
+	return setState(fact.Hash(), sts...)
+}
+
+func (opp *ReviseDocumentsProcessor) lockedKeys() []string {
+	return opp.pending.keys()
+}
+
+func (opp *ReviseDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
+	fact := opp.Fact().(ReviseDocumentsFact)
+
+	cids := make([]currency.CurrencyID, len(fact.items))
+	for i := range fact.items {
+		cids[i] = fact.items[i].Currency()
+	}
+
+	return CalculateFlatItemsFee(opp.cp, cids)
+}
@@ -0,0 +1,269 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testReviseDocumentsOperations struct {
+	baseTestOperationProcessor
+	cid      currency.CurrencyID
+	docid    currency.Big
+	fh       FileHash
+	nfh      FileHash
+	fee      currency.Big
+	signcode string
+	title    string
+	size     currency.Big
+}
+
+func (t *testReviseDocumentsOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.nfh = MustFileHash("EFGH")
+	t.fee = currency.NewBig(3)
+	t.signcode = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+}
+
+func (t *testReviseDocumentsOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(ReviseDocuments{}, NewReviseDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testReviseDocumentsOperations) newReviseDocuments(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []ReviseDocumentsItem,
+) ReviseDocuments {
+	token := util.UUID().Bytes()
+	fact := NewReviseDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	op, err := NewReviseDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+
+	return op
+}
+
+func (t *testReviseDocumentsOperations) newTestDocumentData(ca base.Address, signers []DocSign) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	return NewDocumentData(info, ca, t.signcode, t.title, t.size, signers)
+}
+
+func (t *testReviseDocumentsOperations) newTestBalance() []currency.Amount {
+	return []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+}
+
+func (t *testReviseDocumentsOperations) process(
+	ca *account, dd DocumentData, item ReviseDocumentsItem, sts ...[]state.State,
+) (*storage.Statepool, error) {
+	sts = append(sts, t.newStateDocument(ca.Address, dd))
+	pool, _ := t.statepool(sts...)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	return pool, opr.Process(t.newReviseDocuments(ca.Address, ca.Privs(), []ReviseDocumentsItem{item}))
+}
+
+func (t *testReviseDocumentsOperations) TestNormalCase() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+	ra, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(sa.Address, "user1", true),
+		NewDocSignWithStatus(ra.Address, "user2", DocSignRejected, base.Height(1)),
+	})
+
+	item := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.nfh, "title02", currency.NewBig(777), t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc)
+	t.NoError(err)
+
+	var dds, dinvs, sb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case StateKeyDocumentsPage(ca.Address, currency.ZeroBig):
+			dinvs = stu.GetState()
+		case currency.StateKeyBalance(ca.Address, t.cid):
+			sb = stu.GetState()
+		}
+	}
+	t.NotNil(dds)
+	t.NotNil(dinvs)
+	t.NotNil(sb)
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	t.True(ndd.FileHash().Equal(t.nfh))
+	t.Equal("title02", ndd.Title())
+	t.True(ndd.Size().Equal(currency.NewBig(777)))
+	t.Equal(DocumentDraft, ndd.Status())
+	for _, s := range ndd.Signers() {
+		t.Equal(DocSignPending, s.Status())
+	}
+
+	t.Equal(1, len(ndd.Revisions()))
+	rv := ndd.Revisions()[0]
+	t.True(rv.FileHash().Equal(t.fh))
+	t.Equal(t.title, rv.Title())
+	t.Equal(2, len(rv.Signers()))
+	t.True(rv.Signers()[0].Signed())
+
+	dinv, err := StateDocumentsValue(dinvs)
+	t.NoError(err)
+	t.Equal(1, len(dinv.Documents()))
+	t.True(dinv.Documents()[0].FileHash().Equal(t.nfh))
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+}
+
+func (t *testReviseDocumentsOperations) TestPendingDocuments() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance) // signed signer
+	ga, stc := t.newAccount(true, balance) // pending signer
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(sa.Address, "user1", true),
+		NewDocSign(ga.Address, "user2", false),
+	})
+
+	psts := []state.State{t.newStatePendingDocuments(ga.Address, currency.ZeroBig, []DocInfo{dd.Info()})}
+
+	item := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.nfh, "title02", currency.NewBig(777), t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc, psts)
+	t.NoError(err)
+
+	// NOTE all the signers have to sign the new revision
+	for _, a := range []base.Address{sa.Address, ga.Address} {
+		inv, found := t.updatedPendingDocuments(pool, a)
+		t.True(found)
+		t.Equal(1, len(inv.Documents()))
+		t.True(inv.Documents()[0].Index().Equal(t.docid))
+		t.True(inv.Documents()[0].FileHash().Equal(t.nfh))
+	}
+}
+
+func (t *testReviseDocumentsOperations) TestSameFileHash() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)})
+
+	item := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.fh, "title02", currency.NewBig(777), t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "filehash is same with current revision")
+}
+
+func (t *testReviseDocumentsOperations) TestExpiredDocument() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)}).
+		WithExpiry(base.Height(-1))
+
+	item := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.nfh, "title02", currency.NewBig(777), t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document expired at height")
+}
+
+func (t *testReviseDocumentsOperations) TestNotOwnedDocument() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	oa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(oa.Address, nil)
+
+	item := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.nfh, "title02", currency.NewBig(777), t.cid)
+
+	sts := [][]state.State{sta, stb, t.newStateDocument(oa.Address, dd)}
+	pool, _ := t.statepool(sts...)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	err := t.processor(cp, pool).Process(t.newReviseDocuments(ca.Address, ca.Privs(), []ReviseDocumentsItem{item}))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document not found in sender document inventory")
+}
+
+func (t *testReviseDocumentsOperations) TestSameDocumentInProposal() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	sa, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(sa.Address, "user1", false)})
+
+	pool, _ := t.statepool(sta, stb, t.newStateDocument(ca.Address, dd))
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	item0 := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, t.nfh, "title02", currency.NewBig(777), t.cid)
+	t.NoError(opr.Process(t.newReviseDocuments(ca.Address, ca.Privs(), []ReviseDocumentsItem{item0})))
+
+	item1 := NewReviseDocumentsItemSingleFile(t.docid, ca.Address, MustFileHash("IJKL"), "title03", currency.NewBig(777), t.cid)
+	err := opr.Process(t.newReviseDocuments(ca.Address, ca.Privs(), []ReviseDocumentsItem{item1}))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "violates only one sender")
+}
+
+func TestReviseDocumentsOperations(t *testing.T) {
+	suite.Run(t, new(testReviseDocumentsOperations))
+}
@@ -0,0 +1,44 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	ReviseItemSingleDocumentType   = hint.Type("mitum-blocksign-revise-item-single-document")
+	ReviseItemSingleDocumentHint   = hint.NewHint(ReviseItemSingleDocumentType, "v0.0.1")
+	ReviseItemSingleDocumentHinter = BaseReviseDocumentsItem{hint: ReviseItemSingleDocumentHint}
+)
+
+type ReviseDocumentsItemSingleFile struct {
+	BaseReviseDocumentsItem
+}
+
+func NewReviseDocumentsItemSingleFile(
+	docId currency.Big,
+	owner base.Address,
+	filehash FileHash,
+	title string,
+	size currency.Big,
+	cid currency.CurrencyID,
+) ReviseDocumentsItemSingleFile {
+	return ReviseDocumentsItemSingleFile{
+		BaseReviseDocumentsItem: NewBaseReviseDocumentsItem(ReviseItemSingleDocumentHint, docId, owner, filehash, title, size, cid),
+	}
+}
+
+func (it ReviseDocumentsItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseReviseDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it ReviseDocumentsItemSingleFile) Rebuild() ReviseDocumentsItem {
+	it.BaseReviseDocumentsItem = it.BaseReviseDocumentsItem.Rebuild().(BaseReviseDocumentsItem)
+
+	return it
+}
@@ -0,0 +1,129 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type testReviseDocumentsItemSingleFile struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+	fh    FileHash
+}
+
+func (t *testReviseDocumentsItemSingleFile) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+	t.fh = MustFileHash("EFGH")
+}
+
+func (t *testReviseDocumentsItemSingleFile) TestEmptyTitle() {
+	s := MustAddress(util.UUID().String())
+
+	item := NewReviseDocumentsItemSingleFile(t.docId, s, t.fh, "", currency.NewBig(3), t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "empty title")
+}
+
+func (t *testReviseDocumentsItemSingleFile) TestZeroSize() {
+	s := MustAddress(util.UUID().String())
+
+	item := NewReviseDocumentsItemSingleFile(t.docId, s, t.fh, "title02", currency.ZeroBig, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "size should be over zero")
+}
+
+func (t *testReviseDocumentsItemSingleFile) TestEmptyFileHash() {
+	s := MustAddress(util.UUID().String())
+
+	item := NewReviseDocumentsItemSingleFile(t.docId, s, FileHash(""), "title02", currency.NewBig(3), t.cid)
+
+	t.Error(item.IsValid(nil))
+}
+
+func TestReviseDocumentsItemSingleFile(t *testing.T) {
+	suite.Run(t, new(testReviseDocumentsItemSingleFile))
+}
+
+func testReviseDocumentsItemSingleFileEncode(enc encoder.Encoder) suite.TestingSuite {
+	t := new(baseTestOperationEncode)
+
+	docId0 := currency.NewBig(0)
+	docId1 := currency.NewBig(1)
+	t.enc = enc
+	t.newObject = func() interface{} {
+		s := MustAddress(util.UUID().String())
+
+		token := util.UUID().Bytes()
+		items := []ReviseDocumentsItem{
+			NewReviseDocumentsItemSingleFile(
+				docId0, s, MustFileHash("EFGH"), "title02", currency.NewBig(777), currency.CurrencyID("SHOWME")),
+			NewReviseDocumentsItemSingleFile(
+				docId1, s, MustFileHash("IJKL"), "title03", currency.NewBig(888), currency.CurrencyID("FINDME")),
+		}
+		fact := NewReviseDocumentsFact(token, s, items)
+
+		var fs []operation.FactSign
+
+		for _, pk := range []key.Privatekey{
+			key.MustNewBTCPrivatekey(),
+			key.MustNewBTCPrivatekey(),
+		} {
+			sig, err := operation.NewFactSignature(pk, fact, nil)
+			t.NoError(err)
+
+			fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+		}
+
+		op, err := NewReviseDocuments(fact, fs, util.UUID().String())
+		t.NoError(err)
+
+		return op
+	}
+
+	t.compare = func(a, b interface{}) {
+		ta := a.(ReviseDocuments)
+		tb := b.(ReviseDocuments)
+
+		t.Equal(ta.Memo, tb.Memo)
+
+		fact := ta.Fact().(ReviseDocumentsFact)
+		ufact := tb.Fact().(ReviseDocumentsFact)
+
+		t.True(fact.sender.Equal(ufact.sender))
+		t.Equal(len(fact.Items()), len(ufact.Items()))
+
+		for i := range fact.Items() {
+			a := fact.Items()[i]
+			b := ufact.Items()[i]
+			t.True(a.DocumentId().Equal(b.DocumentId()))
+			t.True(a.Owner().Equal(b.Owner()))
+			t.True(a.FileHash().Equal(b.FileHash()))
+			t.Equal(a.Title(), b.Title())
+			t.True(a.Size().Equal(b.Size()))
+			t.Equal(a.Currency(), (b.Currency()))
+			t.Equal(a.Bytes(), b.Bytes())
+		}
+	}
+
+	return t
+}
+
+func TestReviseDocumentsItemSingleFileEncodeJSON(t *testing.T) {
+	suite.Run(t, testReviseDocumentsItemSingleFileEncode(jsonenc.NewEncoder()))
+}
+
+func TestReviseDocumentsItemSingleFileEncodeBSON(t *testing.T) {
+	suite.Run(t, testReviseDocumentsItemSingleFileEncode(bsonenc.NewEncoder()))
+}
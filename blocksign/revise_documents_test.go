@@ -0,0 +1,98 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+type testReviseDocuments struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+	fh    FileHash
+	size  currency.Big
+}
+
+func (t *testReviseDocuments) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+	t.fh = MustFileHash("EFGH")
+	t.size = currency.NewBig(777)
+}
+
+func (t *testReviseDocuments) newOperation(sender base.Address, items []ReviseDocumentsItem) ReviseDocuments {
+	token := util.UUID().Bytes()
+	fact := NewReviseDocumentsFact(token, sender, items)
+
+	pk := key.MustNewBTCPrivatekey()
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	op, err := NewReviseDocuments(fact, fs, "")
+	t.NoError(err)
+
+	return op
+}
+
+func (t *testReviseDocuments) TestNew() {
+	s := MustAddress(util.UUID().String())
+
+	items := []ReviseDocumentsItem{NewReviseDocumentsItemSingleFile(t.docId, s, t.fh, "title02", t.size, t.cid)}
+	op := t.newOperation(s, items)
+
+	t.NoError(op.IsValid(nil))
+
+	t.Implements((*base.Fact)(nil), op.Fact())
+	t.Implements((*operation.Operation)(nil), op)
+
+	as, err := op.Fact().(ReviseDocumentsFact).Addresses()
+	t.NoError(err)
+	t.Equal(1, len(as))
+}
+
+func (t *testReviseDocuments) TestDuplicatedDocuments() {
+	s := MustAddress(util.UUID().String())
+
+	items := []ReviseDocumentsItem{
+		NewReviseDocumentsItemSingleFile(t.docId, s, t.fh, "title02", t.size, t.cid),
+		NewReviseDocumentsItemSingleFile(t.docId, s, MustFileHash("IJKL"), "title03", t.size, t.cid),
+	}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "duplicated document found")
+}
+
+func (t *testReviseDocuments) TestDuplicatedFileHash() {
+	s := MustAddress(util.UUID().String())
+
+	items := []ReviseDocumentsItem{
+		NewReviseDocumentsItemSingleFile(t.docId, s, t.fh, "title02", t.size, t.cid),
+		NewReviseDocumentsItemSingleFile(currency.NewBig(1), s, t.fh, "title03", t.size, t.cid),
+	}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "duplicated filehash found")
+}
+
+func (t *testReviseDocuments) TestSenderNotOwner() {
+	s := MustAddress(util.UUID().String())
+	o := MustAddress(util.UUID().String())
+
+	items := []ReviseDocumentsItem{NewReviseDocumentsItemSingleFile(t.docId, o, t.fh, "title02", t.size, t.cid)}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "sender is not owner of document")
+}
+
+func TestReviseDocuments(t *testing.T) {
+	suite.Run(t, new(testReviseDocuments))
+}
@@ -0,0 +1,187 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	RevokeSignDocumentsFactType = hint.Type("mitum-blocksign-revoke-sign-documents-operation-fact")
+	RevokeSignDocumentsFactHint = hint.NewHint(RevokeSignDocumentsFactType, "v0.0.1")
+	RevokeSignDocumentsType     = hint.Type("mitum-blocksign-revoke-sign-documents-operation")
+	RevokeSignDocumentsHint     = hint.NewHint(RevokeSignDocumentsType, "v0.0.1")
+)
+
+var MaxRevokeSignDocumentsItems uint = 10
+
+type RevokeSignDocumentsFact struct {
+	h      valuehash.Hash
+	token  []byte
+	sender base.Address
+	items  []SignDocumentItem
+}
+
+func NewRevokeSignDocumentsFact(token []byte, sender base.Address, items []SignDocumentItem) RevokeSignDocumentsFact {
+	fact := RevokeSignDocumentsFact{
+		token:  token,
+		sender: sender,
+		items:  items,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact RevokeSignDocumentsFact) Hint() hint.Hint {
+	return RevokeSignDocumentsFactHint
+}
+
+func (fact RevokeSignDocumentsFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact RevokeSignDocumentsFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact RevokeSignDocumentsFact) Bytes() []byte {
+	is := make([][]byte, len(fact.items))
+	for i := range fact.items {
+		is[i] = fact.items[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		util.ConcatBytesSlice(is...),
+	)
+}
+
+func (fact RevokeSignDocumentsFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for RevokeSignDocumentsFact")
+	} else if n := len(fact.items); n < 1 {
+		return errors.Errorf("empty items")
+	} else if n > int(MaxRevokeSignDocumentsItems) {
+		return errors.Errorf("items, %d over max, %d", n, MaxRevokeSignDocumentsItems)
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.sender,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	// check duplicated document
+	foundDocId := map[string]bool{}
+	for i := range fact.items {
+		if err := fact.items[i].IsValid(nil); err != nil {
+			return err
+		}
+		k := fact.items[i].DocumentId().String()
+		if _, found := foundDocId[k]; found {
+			return errors.Errorf("duplicated document found, %s", k)
+		}
+		foundDocId[k] = true
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact RevokeSignDocumentsFact) Token() []byte {
+	return fact.token
+}
+
+func (fact RevokeSignDocumentsFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact RevokeSignDocumentsFact) Items() []SignDocumentItem {
+	return fact.items
+}
+
+func (fact RevokeSignDocumentsFact) Addresses() ([]base.Address, error) {
+	as := make([]base.Address, 1)
+
+	as[0] = fact.Sender()
+
+	return as, nil
+}
+
+func (fact RevokeSignDocumentsFact) Rebuild() RevokeSignDocumentsFact {
+	items := make([]SignDocumentItem, len(fact.items))
+	for i := range fact.items {
+		it := fact.items[i]
+		items[i] = it.Rebuild()
+	}
+
+	fact.items = items
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+type RevokeSignDocuments struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewRevokeSignDocuments(fact RevokeSignDocumentsFact, fs []operation.FactSign, memo string) (RevokeSignDocuments, error) {
+	if bo, err := operation.NewBaseOperationFromFact(RevokeSignDocumentsHint, fact, fs); err != nil {
+		return RevokeSignDocuments{}, err
+	} else {
+		op := RevokeSignDocuments{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (op RevokeSignDocuments) Hint() hint.Hint {
+	return RevokeSignDocumentsHint
+}
+
+func (op RevokeSignDocuments) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op RevokeSignDocuments) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op RevokeSignDocuments) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
@@ -0,0 +1,73 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op RevokeSignDocuments) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+// checkRevokeSigner allows signer to revoke the signature of document, which
+// is still being signed; the signature of fully signed or expired document can
+// not be revoked, because the escrow of sponsored document is already settled
+// and the expired document can not be signed again.
+func checkRevokeSigner(dd DocumentData, ds DocSign, height base.Height) error {
+	if !ds.Signed() {
+		return errors.Errorf("document not signed by sender, %v", ds.Address())
+	}
+
+	switch st := dd.StatusAt(height); st {
+	case DocumentFullySigned, DocumentExpired:
+		return errors.Errorf("signature of %s document can not be revoked", st)
+	default:
+		return nil
+	}
+}
+
+type RevokeSignDocumentsProcessor struct {
+	signerStatusProcessor
+	RevokeSignDocuments
+}
+
+func NewRevokeSignDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(RevokeSignDocuments); !ok {
+			return nil, errors.Errorf("not RevokeSignDocuments, %T", op)
+		} else {
+			return &RevokeSignDocumentsProcessor{
+				signerStatusProcessor: signerStatusProcessor{cp: cp},
+				RevokeSignDocuments:   i,
+			}, nil
+		}
+	}
+}
+
+func (opp *RevokeSignDocumentsProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(RevokeSignDocumentsFact)
+
+	if err := opp.preProcess(
+		opp.Hash(), fact.sender, fact.items, opp.Signs(), DocSignPending, checkRevokeSigner, getState, setState,
+	); err != nil {
+		return nil, err
+	}
+
+	return opp, nil
+}
+
+func (opp *RevokeSignDocumentsProcessor) Process(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	return opp.process(opp.Fact().Hash(), getState, setState)
+}
@@ -0,0 +1,241 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testRevokeSignDocumentsOperations struct {
+	baseTestOperationProcessor
+	cid       currency.CurrencyID
+	docid     currency.Big
+	fh        FileHash
+	fee       currency.Big
+	signcode0 string
+	title     string
+	size      currency.Big
+	signcode1 string
+}
+
+func (t *testRevokeSignDocumentsOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.fee = currency.NewBig(3)
+	t.signcode0 = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+	t.signcode1 = "user1"
+}
+
+func (t *testRevokeSignDocumentsOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(RevokeSignDocuments{}, NewRevokeSignDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testRevokeSignDocumentsOperations) newRevokeSignDocument(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []SignDocumentItem,
+) RevokeSignDocuments {
+	token := util.UUID().Bytes()
+	fact := NewRevokeSignDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	tfd, err := NewRevokeSignDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(tfd.IsValid(nil))
+
+	return tfd
+}
+
+func (t *testRevokeSignDocumentsOperations) newTestDocumentData(ca base.Address, ga base.Address, status DocSignStatus) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	// NOTE the other signer does not sign yet, so document is not fully signed
+	return NewDocumentData(info, ca, t.signcode0, t.title, t.size, []DocSign{
+		NewDocSignWithStatus(ga, t.signcode1, status, base.Height(1)),
+		NewDocSign(NewTestAddress(), "user2", false),
+	})
+}
+
+func (t *testRevokeSignDocumentsOperations) TestNormalCase() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignSigned)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRevokeSignDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items)))
+
+	var dds, sb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case currency.StateKeyBalance(sa.Address, t.cid):
+			sb = stu.GetState()
+		}
+	}
+	t.NotNil(dds)
+	t.NotNil(sb)
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	ds, found := ndd.Signer(sa.Address)
+	t.True(found)
+	t.Equal(DocSignPending, ds.Status())
+	t.Equal(pool.Height(), ds.Height())
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+}
+
+func (t *testRevokeSignDocumentsOperations) TestPendingDocuments() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignSigned)
+
+	other := MustNewDocInfo(1, MustFileHash("EFGH"))
+	pst := t.newStatePendingDocuments(sa.Address, currency.ZeroBig, []DocInfo{other})
+
+	pool, _ := t.statepool(sta, stb, t.newStateDocument(ca.Address, dd), []state.State{pst})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRevokeSignDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items)))
+
+	// NOTE revoked signer has to sign document again
+	inv, found := t.updatedPendingDocuments(pool, sa.Address)
+	t.True(found)
+	t.Equal(2, len(inv.Documents()))
+	t.True(inv.Exists(t.docid))
+	t.True(inv.Exists(other.Index()))
+}
+
+func (t *testRevokeSignDocumentsOperations) TestNotSigned() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	for _, status := range []DocSignStatus{DocSignPending, DocSignRejected} {
+		dd := t.newTestDocumentData(ca.Address, sa.Address, status)
+
+		sts := t.newStateDocument(ca.Address, dd)
+		pool, _ := t.statepool(sta, stb, sts)
+
+		cp := currency.NewCurrencyPool()
+		t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+		opr := t.processor(cp, pool)
+
+		items := []SignDocumentItem{NewRevokeSignDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+		err := opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items))
+
+		var oper operation.ReasonError
+		t.True(xerrors.As(err, &oper))
+		t.Contains(err.Error(), "document not signed by sender")
+	}
+}
+
+func (t *testRevokeSignDocumentsOperations) TestFullySignedOrExpired() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	pool, _ := t.statepool(sta, stb)
+
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	fullySigned := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, []DocSign{
+		NewDocSignWithStatus(sa.Address, t.signcode1, DocSignSigned, base.Height(1)),
+	})
+	expired := t.newTestDocumentData(ca.Address, sa.Address, DocSignSigned).WithExpiry(pool.Height() - 1)
+
+	for _, dd := range []DocumentData{fullySigned, expired} {
+		sts := t.newStateDocument(ca.Address, dd)
+		pool, _ = t.statepool(sta, stb, sts)
+
+		cp := currency.NewCurrencyPool()
+		t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+		opr := t.processor(cp, pool)
+
+		items := []SignDocumentItem{NewRevokeSignDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+		err := opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items))
+
+		var oper operation.ReasonError
+		t.True(xerrors.As(err, &oper))
+		t.Contains(err.Error(), "can not be revoked")
+		t.Contains(err.Error(), dd.StatusAt(pool.Height()).String())
+	}
+}
+
+func (t *testRevokeSignDocumentsOperations) TestSameDocumentInProposal() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address, DocSignSigned)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewRevokeSignDocumentsItemSingleFile(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items)))
+
+	err := opr.Process(t.newRevokeSignDocument(sa.Address, sa.Privs(), items))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "violates only one sender")
+}
+
+func TestRevokeSignDocumentsOperations(t *testing.T) {
+	suite.Run(t, new(testRevokeSignDocumentsOperations))
+}
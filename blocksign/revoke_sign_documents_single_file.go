@@ -0,0 +1,37 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	RevokeSignItemSingleDocumentType   = hint.Type("mitum-blocksign-revoke-sign-item-single-document")
+	RevokeSignItemSingleDocumentHint   = hint.NewHint(RevokeSignItemSingleDocumentType, "v0.0.1")
+	RevokeSignItemSingleDocumentHinter = BaseSignDocumentsItem{hint: RevokeSignItemSingleDocumentHint}
+)
+
+type RevokeSignDocumentsItemSingleFile struct {
+	BaseSignDocumentsItem
+}
+
+func NewRevokeSignDocumentsItemSingleFile(docId currency.Big, owner base.Address, cid currency.CurrencyID) RevokeSignDocumentsItemSingleFile {
+	return RevokeSignDocumentsItemSingleFile{
+		BaseSignDocumentsItem: NewBaseSignDocumentsItem(RevokeSignItemSingleDocumentHint, docId, owner, "", cid),
+	}
+}
+
+func (it RevokeSignDocumentsItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseSignDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it RevokeSignDocumentsItemSingleFile) Rebuild() SignDocumentItem {
+	it.BaseSignDocumentsItem = it.BaseSignDocumentsItem.Rebuild().(BaseSignDocumentsItem)
+
+	return it
+}
@@ -26,6 +26,7 @@ type SignDocumentItem interface {
 	Bytes() []byte
 	DocumentId() currency.Big
 	Owner() base.Address
+	Signcode() string
 	Currency() currency.CurrencyID
 	Rebuild() SignDocumentItem
 }
@@ -8,18 +8,26 @@ import (
 )
 
 type BaseSignDocumentsItem struct {
-	hint  hint.Hint
-	id    currency.Big
-	owner base.Address
-	cid   currency.CurrencyID
+	hint     hint.Hint
+	id       currency.Big
+	owner    base.Address
+	signcode string // signer signcode
+	cid      currency.CurrencyID
 }
 
-func NewBaseSignDocumentsItem(ht hint.Hint, id currency.Big, owner base.Address, cid currency.CurrencyID) BaseSignDocumentsItem {
+func NewBaseSignDocumentsItem(
+	ht hint.Hint,
+	id currency.Big,
+	owner base.Address,
+	signcode string,
+	cid currency.CurrencyID,
+) BaseSignDocumentsItem {
 	return BaseSignDocumentsItem{
-		hint:  ht,
-		id:    id,
-		owner: owner,
-		cid:   cid,
+		hint:     ht,
+		id:       id,
+		owner:    owner,
+		signcode: signcode,
+		cid:      cid,
 	}
 }
 
@@ -28,12 +36,31 @@ func (it BaseSignDocumentsItem) Hint() hint.Hint {
 }
 
 func (it BaseSignDocumentsItem) Bytes() []byte {
-	bs := make([][]byte, 3)
-	bs[0] = it.id.Bytes()
-	bs[1] = it.owner.Bytes()
-	bs[2] = it.cid.Bytes()
+	if it.isLegacy() {
+		return it.legacyBytes()
+	}
+
+	return concatCanonicalBytes(
+		it.hint.Bytes(),
+		it.id.Bytes(),
+		it.owner.Bytes(),
+		it.cid.Bytes(),
+		[]byte(it.signcode),
+	)
+}
 
-	return util.ConcatBytesSlice(bs...)
+// isLegacy returns true when item has the hint before the signer signcode.
+func (it BaseSignDocumentsItem) isLegacy() bool {
+	return it.hint.Equal(SignItemSingleDocumentLegacyHint)
+}
+
+// legacyBytes is the encoding of SignItemSingleDocumentLegacyHint.
+func (it BaseSignDocumentsItem) legacyBytes() []byte {
+	return util.ConcatBytesSlice(
+		it.id.Bytes(),
+		it.owner.Bytes(),
+		it.cid.Bytes(),
+	)
 }
 
 func (it BaseSignDocumentsItem) IsValid([]byte) error {
@@ -62,6 +89,10 @@ func (it BaseSignDocumentsItem) Owner() base.Address {
 	return it.owner
 }
 
+func (it BaseSignDocumentsItem) Signcode() string {
+	return it.signcode
+}
+
 // FileData return BaseCreateDocumentsItem's fileData.
 func (it BaseSignDocumentsItem) Currency() currency.CurrencyID {
 	return it.cid
@@ -13,6 +13,7 @@ func (it BaseSignDocumentsItem) MarshalBSON() ([]byte, error) {
 			bson.M{
 				"documentid": it.id,
 				"owner":      it.owner,
+				"signcode":   it.signcode,
 				"currency":   it.cid,
 			}),
 	)
@@ -21,6 +22,7 @@ func (it BaseSignDocumentsItem) MarshalBSON() ([]byte, error) {
 type SignDocumentsItemBSONUnpacker struct {
 	DI currency.Big        `bson:"documentid"`
 	OW base.AddressDecoder `bson:"owner"`
+	SC string              `bson:"signcode"`
 	CI string              `bson:"currency"`
 }
 
@@ -35,5 +37,5 @@ func (it *BaseSignDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) erro
 		return err
 	}
 
-	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.CI)
+	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.SC, ucd.CI)
 }
@@ -12,6 +12,7 @@ func (it *BaseSignDocumentsItem) unpack(
 	ht hint.Hint,
 	di currency.Big,
 	ow base.AddressDecoder,
+	sc string,
 	scid string,
 
 ) error {
@@ -24,6 +25,7 @@ func (it *BaseSignDocumentsItem) unpack(
 		return err
 	}
 	it.owner = a
+	it.signcode = sc
 	it.cid = currency.CurrencyID(scid)
 
 	return nil
@@ -10,6 +10,7 @@ type SignDocumentsItemJSONPacker struct {
 	jsonenc.HintedHead
 	DI currency.Big        `json:"documentid"`
 	OW base.Address        `json:"owner"`
+	SC string              `json:"signcode"`
 	CI currency.CurrencyID `json:"currency"`
 }
 
@@ -18,6 +19,7 @@ func (it BaseSignDocumentsItem) MarshalJSON() ([]byte, error) {
 		HintedHead: jsonenc.NewHintedHead(it.Hint()),
 		DI:         it.id,
 		OW:         it.owner,
+		SC:         it.signcode,
 		CI:         it.cid,
 	})
 }
@@ -25,6 +27,7 @@ func (it BaseSignDocumentsItem) MarshalJSON() ([]byte, error) {
 type SignDocumentsItemJSONUnpacker struct {
 	DI currency.Big        `json:"documentid"`
 	OW base.AddressDecoder `json:"owner"`
+	SC string              `json:"signcode"`
 	CI string              `json:"currency"`
 }
 
@@ -39,5 +42,5 @@ func (it *BaseSignDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) erro
 		return err
 	}
 
-	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.CI)
+	return it.unpack(enc, ht.H, ucd.DI, ucd.OW, ucd.SC, ucd.CI)
 }
@@ -19,12 +19,16 @@ func (op SignDocuments) Process(
 type SignDocumentsItemProcessor struct {
 	cp     *currency.CurrencyPool
 	sender base.Address
+	height base.Height
 	h      valuehash.Hash
 	item   SignDocumentItem
-	nds    state.State       // new document data state (key = document filehash)
-	dinv   DocumentInventory // document inventory
-	ndinvs state.State       // document inventory state (key = owner address)
-
+	nds    state.State // new document data state (key = document filehash)
+	// NOTE escrowFee is the signing fee, paid from the escrow of document;
+	// refund is the rest of escrow, refunded to escrow payer when document is
+	// fully signed.
+	escrowFee currency.Big
+	refund    currency.Big
+	ncb       currency.AmountState // escrow payer balance state of escrow currency
 }
 
 func (opp *SignDocumentsItemProcessor) PreProcess(
@@ -32,6 +36,11 @@ func (opp *SignDocumentsItemProcessor) PreProcess(
 	_ func(valuehash.Hash, ...state.State) error,
 ) error {
 
+	// NOTE legacy item is only for reading chain history
+	if opp.item.Hint().Equal(SignItemSingleDocumentLegacyHint) {
+		return errors.Errorf("legacy item hint, %v is not allowed for new operation", opp.item.Hint())
+	}
+
 	if err := opp.item.IsValid(nil); err != nil {
 		return err
 	}
@@ -43,22 +52,8 @@ func (opp *SignDocumentsItemProcessor) PreProcess(
 		return errors.Errorf("owner does not exist, %q", opp.item.Owner())
 	}
 
-	// check existence of document inventory state with owner address
-	switch st, found, err := getState(StateKeyDocuments(opp.item.Owner())); {
-	case err != nil:
-		return err
-	case !found:
-		return errors.Errorf("Owner has no document inventory, %v", opp.item.Owner())
-	default:
-		dinv, err := StateDocumentsValue(st)
-		if err != nil {
-			return err
-		}
-		opp.dinv = dinv
-		opp.ndinvs = st
-	}
-
-	docinfo, err := opp.dinv.Get(opp.item.DocumentId())
+	// get document info from document inventory of owner
+	docinfo, err := loadOwnerDocument(opp.item.Owner(), opp.item.DocumentId(), getState)
 	if err != nil {
 		return err
 	}
@@ -82,18 +77,36 @@ func (opp *SignDocumentsItemProcessor) PreProcess(
 		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
 	}
 
-	if len(dd.Signers()) < 1 {
-		return errors.Errorf("sender not found in document Signers, %v", opp.sender)
+	if dd.IsCanceled() {
+		return errors.Errorf("document canceled at height, %v", dd.Canceled())
 	}
+
+	if dd.IsExpired(opp.height) {
+		return errors.Errorf("document expired at height, %v", dd.Expiry())
+	}
+
 	// check signer exist in document data signers
-	for i := range dd.Signers() {
-		if dd.Signers()[i].Address().Equal(opp.sender) {
-			dd.Signers()[i].SetSigned()
-			break
-		}
-		if i == (len(dd.Signers()) - 1) {
-			return errors.Errorf("sender not found in document Signers, %v", opp.sender)
-		}
+	switch ds, found := dd.Signer(opp.sender); {
+	case !found:
+		return errors.Errorf("sender not found in document Signers, %v", opp.sender)
+	case !SignCode(ds.Signcode()).Verify(opp.item.Signcode()):
+		return errors.Errorf("signcode not matched with document signer, %v", opp.sender)
+	case ds.Signed():
+		return errors.Errorf("document already signed by sender, %v", opp.sender)
+	}
+
+	// check sender is the next signer in sequential mode
+	if next, found := dd.NextSigner(); found && !next.Address().Equal(opp.sender) {
+		return errors.Errorf("sender is not the next signer, %v; next signer is %v", opp.sender, next.Address())
+	}
+
+	dd, err = dd.SetSignerStatus(opp.sender, DocSignSigned, opp.height)
+	if err != nil {
+		return err
+	}
+
+	if dd, err = opp.payFromEscrow(dd, getState); err != nil {
+		return err
 	}
 
 	// update document data state
@@ -117,12 +130,55 @@ func (opp *SignDocumentsItemProcessor) Process(
 	return sts, nil
 }
 
+func (opp *SignDocumentsItemProcessor) payFromEscrow(
+	dd DocumentData,
+	getState func(key string) (state.State, bool, error),
+) (DocumentData, error) {
+	opp.refund = currency.ZeroBig
+	if opp.escrowFee.Int == nil {
+		opp.escrowFee = currency.ZeroBig
+	}
+
+	if !dd.HasEscrow() {
+		return dd, nil
+	}
+
+	escrow, payer := dd.Escrow(), dd.EscrowPayer()
+	if opp.escrowFee.OverZero() {
+		if escrow.Big().Compare(opp.escrowFee) < 0 {
+			return dd, errors.Errorf("insufficient escrow of document; %v < %v", escrow.Big(), opp.escrowFee)
+		}
+
+		dd = dd.WithEscrow(escrow.WithBig(escrow.Big().Sub(opp.escrowFee)))
+	}
+
+	if dd.HasEscrow() && dd.StatusAt(opp.height) == DocumentFullySigned {
+		opp.refund = dd.Escrow().Big()
+		dd = dd.WithEscrow(currency.Amount{})
+	}
+
+	if !opp.escrowFee.OverZero() && !opp.refund.OverZero() {
+		return dd, nil
+	}
+
+	st, err := loadEscrowBalance(payer, escrow.Currency(), getState)
+	if err != nil {
+		return dd, err
+	}
+	opp.ncb = st
+
+	return dd, nil
+}
+
 type SignDocumentsProcessor struct {
-	cp *currency.CurrencyPool
+	cp     *currency.CurrencyPool
+	height base.Height    // height of block, which documents are signed in
+	policy DocumentPolicy // document policy of block
 	SignDocuments
 	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
 	ns       []*SignDocumentsItemProcessor                // ItemProcessor
 	required map[currency.CurrencyID][2]currency.Big      // Fee
+	pending  *documentPages                               // pending document inventory pages of sender
 }
 
 func NewSignDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
@@ -149,9 +205,17 @@ func (opp *SignDocumentsProcessor) PreProcess(
 		return nil, err
 	}
 
-	if required, err := opp.calculateItemsFee(); err != nil {
+	docs, err := loadSignItemsDocument(fact.items, getState)
+	if err != nil {
+		return nil, operation.NewBaseReasonErrorFromError(err)
+	}
+
+	required, escrowFees, err := CalculateSignDocumentsRequired(opp.cp, opp.policy, fact.items, docs)
+	if err != nil {
 		return nil, operation.NewBaseReasonError("failed to calculate fee: %w", err)
-	} else if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
+	}
+
+	if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
 		return nil, err
 	} else {
 		opp.required = required
@@ -161,7 +225,14 @@ func (opp *SignDocumentsProcessor) PreProcess(
 	ns := make([]*SignDocumentsItemProcessor, len(fact.items))
 	for i := range fact.items {
 
-		c := &SignDocumentsItemProcessor{cp: opp.cp, sender: fact.sender, h: opp.Hash(), item: fact.items[i]}
+		c := &SignDocumentsItemProcessor{
+			cp:        opp.cp,
+			sender:    fact.sender,
+			height:    opp.height,
+			h:         opp.Hash(),
+			item:      fact.items[i],
+			escrowFee: escrowFees[i],
+		}
 		if err := c.PreProcess(getState, setState); err != nil {
 			return nil, operation.NewBaseReasonErrorFromError(err)
 		}
@@ -174,7 +245,15 @@ func (opp *SignDocumentsProcessor) PreProcess(
 		return nil, operation.NewBaseReasonError("invalid signing: %w", err)
 	}
 
+	pending := newPendingDocumentPages()
+	for i := range fact.items {
+		if err := pending.load(fact.sender, fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+	}
+
 	opp.ns = ns
+	opp.pending = pending
 
 	return opp, nil
 }
@@ -187,27 +266,150 @@ func (opp *SignDocumentsProcessor) Process( // nolint:dupl
 
 	var sts []state.State // nolint:prealloc
 
+	eb := newEscrowBalances(opp.sb, opp.required)
+
 	for i := range opp.ns {
 		if s, err := opp.ns[i].Process(getState, setState); err != nil {
 			return operation.NewBaseReasonError("failed to process create document item: %w", err)
 		} else {
 			sts = append(sts, s...)
 		}
+
+		if c := opp.ns[i]; c.escrowFee.OverZero() || c.refund.OverZero() {
+			eb.add(c.ncb, c.refund, c.escrowFee)
+		}
+
+		// signed document is removed from pending document inventory of sender
+		if id := opp.ns[i].item.DocumentId(); opp.pending.exists(fact.sender, id) {
+			if err := opp.pending.remove(fact.sender, id); err != nil {
+				return err
+			}
+		}
 	}
 
-	for k := range opp.required {
-		rq := opp.required[k]
-		sts = append(sts, opp.sb[k].Sub(rq[0]).AddFee(rq[1]))
+	sts = append(sts, eb.states()...)
+
+	if pts, err := opp.pending.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, pts...)
 	}
 
 	return setState(fact.Hash(), sts...)
 }
 
-func (opp *SignDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
-	fact := opp.Fact().(SignDocumentsFact)
-	items := make([]SignDocumentItem, len(fact.items))
-	for i := range fact.items {
-		items[i] = fact.items[i]
+func (opp *SignDocumentsProcessor) lockedKeys() []string {
+	keys := opp.pending.keys()
+	for i := range opp.ns {
+		if c := opp.ns[i]; c.escrowFee.OverZero() || c.refund.OverZero() {
+			keys = append(keys, c.ncb.Key())
+		}
+	}
+
+	return keys
+}
+
+// loadSignItemsDocument loads the documents to be signed; unknown document is
+// empty and has zero size, it will be checked by SignDocumentsItemProcessor.
+func loadSignItemsDocument(
+	items []SignDocumentItem,
+	getState func(key string) (state.State, bool, error),
+) ([]DocumentData, error) {
+	docs := make([]DocumentData, len(items))
+	for i := range items {
+		docs[i] = DocumentData{size: currency.ZeroBig}
+
+		switch st, found, err := getState(StateKeyDocumentData(DocId(items[i].DocumentId()))); {
+		case err != nil:
+			return nil, err
+		case !found:
+			continue
+		default:
+			dd, err := StateDocumentDataValue(st)
+			if err != nil {
+				return nil, err
+			}
+			docs[i] = dd
+		}
+	}
+
+	return docs, nil
+}
+
+// CalculateSignDocumentsRequired returns the amounts, which are required to
+// sender balance, with the fee of each item, which is paid from the escrow of
+// document; the fee of item, which is paid by sender, is empty. docs are the
+// documents in the order of items.
+func CalculateSignDocumentsRequired(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	items []SignDocumentItem,
+	docs []DocumentData,
+) (map[currency.CurrencyID][2]currency.Big, []currency.Big, error) {
+	if len(items) != len(docs) {
+		return nil, nil, errors.Errorf("documents not matched with items, %d != %d", len(docs), len(items))
+	}
+
+	fees := make([]currency.Big, len(items))
+
+	var paid []SignDocumentItem // nolint:prealloc
+	var sizes []currency.Big    // nolint:prealloc
+	for i := range items {
+		dd := docs[i]
+		if dd.HasEscrow() && dd.Escrow().Currency() == items[i].Currency() {
+			fee, err := signItemFee(cp, po, items[i], dd.Size())
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// NOTE if escrow is not enough, sender pays the fee
+			if dd.Escrow().Big().Compare(fee) >= 0 {
+				fees[i] = fee
+
+				continue
+			}
+		}
+
+		paid = append(paid, items[i])
+		sizes = append(sizes, dd.Size())
+	}
+
+	required, err := CalculateSignItemsFee(cp, po, paid, sizes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return required, fees, nil
+}
+
+func signItemFee(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	it SignDocumentItem,
+	size currency.Big,
+) (currency.Big, error) {
+	if cp == nil {
+		return currency.ZeroBig, nil
+	}
+
+	feeer, found := cp.Feeer(it.Currency())
+	if !found {
+		return currency.ZeroBig, errors.Errorf("unknown currency id found, %q", it.Currency())
+	}
+
+	return po.FeePolicy(it.Currency()).SizeFee(feeer, size)
+}
+
+// CalculateSignItemsFee calculates the fee of sign items; sizes are the sizes
+// of documents in the order of items.
+func CalculateSignItemsFee(
+	cp *currency.CurrencyPool,
+	po DocumentPolicy,
+	items []SignDocumentItem,
+	sizes []currency.Big,
+) (map[currency.CurrencyID][2]currency.Big, error) {
+	if len(items) != len(sizes) {
+		return nil, errors.Errorf("sizes not matched with items, %d != %d", len(sizes), len(items))
 	}
 
 	required := map[currency.CurrencyID][2]currency.Big{}
@@ -220,17 +422,8 @@ func (opp *SignDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID]
 		if k, found := required[it.Currency()]; found {
 			rq = k
 		}
-		if opp.cp == nil {
-			required[it.Currency()] = rq
-
-			continue
-		}
 
-		feeer, found := opp.cp.Feeer(it.Currency())
-		if !found {
-			return nil, errors.Errorf("unknown currency id found, %q", it.Currency())
-		}
-		switch k, err := feeer.Fee(currency.ZeroBig); {
+		switch k, err := signItemFee(cp, po, it, sizes[i]); {
 		case err != nil:
 			return nil, err
 		case !k.OverZero():
@@ -238,7 +431,6 @@ func (opp *SignDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID]
 		default:
 			required[it.Currency()] = [2]currency.Big{rq[0].Add(k), rq[1].Add(k)}
 		}
-
 	}
 
 	return required, nil
@@ -30,7 +30,7 @@ type testSignDocumentsOperations struct {
 func (t *testSignDocumentsOperations) SetupSuite() {
 	t.cid = currency.CurrencyID("SHOWME")
 	t.docid = currency.NewBig(0)
-	t.fh = FileHash("ABCD")
+	t.fh = MustFileHash("ABCD")
 	t.fee = currency.NewBig(3)
 	t.signcode0 = "user0"
 	t.title = "title01"
@@ -56,7 +56,7 @@ func (t *testSignDocumentsOperations) newSignDocumentsItem(
 	cid currency.CurrencyID,
 ) SignDocumentItem {
 
-	return NewSignDocumentsItemSingleFile(docid, owner, cid)
+	return NewSignDocumentsItemSingleFile(docid, owner, t.signcode1, cid)
 }
 
 func (t *testSignDocumentsOperations) newSignDocument(
@@ -90,7 +90,8 @@ func (t *testSignDocumentsOperations) newTestDocumentData(ca base.Address, ga ba
 	if ga == nil {
 		doc = NewDocumentData(info, ca, t.signcode0, t.title, t.size, []DocSign{})
 	} else {
-		doc = NewDocumentData(info, ca, t.signcode0, t.title, t.size, []DocSign{{address: ga, signed: false}})
+		signcode := SignCode(t.signcode1).Commitment().String()
+		doc = NewDocumentData(info, ca, t.signcode0, t.title, t.size, []DocSign{{address: ga, signcode: signcode, status: DocSignPending}})
 	}
 	return doc
 }
@@ -158,6 +159,238 @@ func (t *testSignDocumentsOperations) TestNormalCase() {
 	t.True(ndd.Creator().Equal(ca.Address))
 	t.True(ndd.Signers()[0].Address().Equal(sa.Address))
 	t.True(ndd.Signers()[0].Signed() == true)
+	t.Equal(pool.Height(), ndd.Signers()[0].Height())
+}
+
+func (t *testSignDocumentsOperations) TestLegacyItem() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance)
+	ca, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), t.newTestFixedFeeer(ca.Address))))
+
+	opr := t.processor(cp, pool)
+
+	item := NewSignDocumentsItemSingleFile(t.docid, ca.Address, "", t.cid)
+	item.hint = SignItemSingleDocumentLegacyHint
+
+	// NOTE legacy item is only for reading chain history
+	err := opr.Process(t.newSignDocument(sa.Address, sa.Privs(), []SignDocumentItem{item}))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "legacy item hint")
+}
+
+func (t *testSignDocumentsOperations) TestFeeBySize() {
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(t.cid, NewDocumentFeePolicy(currency.NewBig(100), currency.NewBig(99))))
+
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance)
+	ca, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(ca.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+
+	var sb state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == currency.StateKeyBalance(sa.Address, t.cid) {
+			sb = stu.GetState()
+		}
+	}
+
+	// NOTE size 555 is 6 units of 100; signer fee is not charged in signing
+	t.Equal(currency.NewBig(6), sb.(currency.AmountState).Fee())
+}
+
+func (t *testSignDocumentsOperations) processSponsored(
+	escrow currency.Big, signers int,
+) (*storage.Statepool, *account, *account, error) {
+	policy := t.newStateDocumentPolicy(NewDocumentPolicy(false).WithFeePolicy(t.cid, NewDocumentFeePolicy(currency.NewBig(100), currency.ZeroBig)))
+
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, nil) // sender, signer without balance
+	ca, stb := t.newAccount(true, balance)
+
+	docsigns := []DocSign{NewDocSign(sa.Address, t.signcode1, false)}
+	for i := 1; i < signers; i++ {
+		docsigns = append(docsigns, NewDocSign(MustAddress(util.UUID().String()), t.signcode1, false))
+	}
+
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	dd := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, docsigns).
+		WithEscrow(currency.NewAmount(escrow, t.cid))
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts, []state.State{policy})
+
+	feeer := currency.NewRatioFeeer(ca.Address, 1, currency.NewBig(1), currency.UnlimitedMaxFeeAmount)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+
+	return pool, sa, ca, opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items))
+}
+
+func (t *testSignDocumentsOperations) sponsoredStates(
+	pool *storage.Statepool, sa, ca *account,
+) (DocumentData, state.State, state.State) {
+	var dds, sb, cb state.State
+	for _, stu := range pool.Updates() {
+		switch stu.Key() {
+		case StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case currency.StateKeyBalance(sa.Address, t.cid):
+			sb = stu.GetState()
+		case currency.StateKeyBalance(ca.Address, t.cid):
+			cb = stu.GetState()
+		}
+	}
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	return ndd, sb, cb
+}
+
+func (t *testSignDocumentsOperations) TestSponsored() {
+	// NOTE size 555 is 6 units of 100
+	pool, sa, ca, err := t.processSponsored(currency.NewBig(12), 2)
+	t.NoError(err)
+
+	ndd, sb, cb := t.sponsoredStates(pool, sa, ca)
+	t.Nil(sb)
+	t.NotNil(cb)
+
+	t.True(ndd.Signers()[0].Signed())
+	t.True(ndd.Escrow().Big().Equal(currency.NewBig(6)))
+
+	// NOTE fee is paid from escrow, so creator balance is not changed
+	t.Equal(currency.NewBig(6), cb.(currency.AmountState).Fee())
+	cba, _ := currency.StateBalanceValue(cb)
+	t.True(cba.Big().Equal(currency.NewBig(33)))
+}
+
+func (t *testSignDocumentsOperations) TestSponsoredRefund() {
+	pool, sa, ca, err := t.processSponsored(currency.NewBig(12), 1)
+	t.NoError(err)
+
+	ndd, sb, cb := t.sponsoredStates(pool, sa, ca)
+	t.Nil(sb)
+
+	t.Equal(DocumentFullySigned, ndd.Status())
+	t.False(ndd.HasEscrow())
+
+	// NOTE the rest of escrow is refunded when document is fully signed
+	t.Equal(currency.NewBig(6), cb.(currency.AmountState).Fee())
+	cba, _ := currency.StateBalanceValue(cb)
+	t.True(cba.Big().Equal(currency.NewBig(33 + 6)))
+}
+
+func (t *testSignDocumentsOperations) TestSponsoredRefundToPayer() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, nil)     // sender, signer without balance
+	ca, stb := t.newAccount(true, balance) // owner, document transferred to
+	pa, stc := t.newAccount(true, balance) // escrow payer, previous owner
+
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	dd := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, []DocSign{NewDocSign(sa.Address, t.signcode1, false)}).
+		WithEscrow(currency.NewAmount(currency.NewBig(12), t.cid)).
+		WithEscrowPayer(pa.Address)
+
+	pool, _ := t.statepool(sta, stb, stc, t.newStateDocument(ca.Address, dd))
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), t.newTestFixedFeeer(ca.Address))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+
+	ndd, sb, cb := t.sponsoredStates(pool, sa, ca)
+	t.Nil(sb)
+	t.Nil(cb)
+	t.False(ndd.HasEscrow())
+
+	var pb state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == currency.StateKeyBalance(pa.Address, t.cid) {
+			pb = stu.GetState()
+		}
+	}
+
+	// NOTE the rest of escrow is refunded to payer, not to the current owner
+	t.NotNil(pb)
+	t.Equal(t.fee, pb.(currency.AmountState).Fee())
+	pba, _ := currency.StateBalanceValue(pb)
+	t.True(pba.Big().Equal(balance[0].Big().Add(currency.NewBig(12)).Sub(t.fee)))
+}
+
+func (t *testSignDocumentsOperations) TestSponsoredSamePayerInProposal() {
+	balance := t.newTestBalance()
+	sa0, sta0 := t.newAccount(true, nil)
+	sa1, sta1 := t.newAccount(true, nil)
+	ca, stb := t.newAccount(true, balance)
+
+	escrow := currency.NewAmount(currency.NewBig(12), t.cid)
+	signers := []DocSign{NewDocSign(sa0.Address, t.signcode1, false), NewDocSign(sa1.Address, t.signcode1, false)}
+	dd0 := NewDocumentData(DocInfo{idx: currency.NewBig(0), filehash: t.fh}, ca.Address, t.signcode0, t.title, t.size, signers).
+		WithEscrow(escrow).WithEscrowPayer(ca.Address)
+	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, signers).
+		WithEscrow(escrow).WithEscrowPayer(ca.Address)
+
+	pool, _ := t.statepool(sta0, sta1, stb, []state.State{
+		t.newStateDocumentData(dd0),
+		t.newStateDocumentData(dd1),
+		t.newStateLegacyDocuments(ca.Address, []DocInfo{dd0.Info(), dd1.Info()}),
+	})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), t.newTestFixedFeeer(ca.Address))))
+
+	opr := t.processor(cp, pool)
+
+	items0 := []SignDocumentItem{t.newSignDocumentsItem(dd0.Info().Index(), ca.Address, t.cid)}
+	_, err := opr.PreProcess(t.newSignDocument(sa0.Address, sa0.Privs(), items0))
+	t.NoError(err)
+
+	// NOTE the balance of escrow payer is locked by the previous operation
+	items1 := []SignDocumentItem{t.newSignDocumentsItem(dd1.Info().Index(), ca.Address, t.cid)}
+	_, err = opr.PreProcess(t.newSignDocument(sa1.Address, sa1.Privs(), items1))
+	t.Error(err)
+	t.Contains(err.Error(), "already processed")
+}
+
+func (t *testSignDocumentsOperations) TestSponsoredInsufficientEscrow() {
+	// NOTE escrow is not enough, signer without balance can not pay fee
+	_, _, _, err := t.processSponsored(currency.NewBig(5), 1)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "does not exist")
 }
 
 func (t *testSignDocumentsOperations) TestSenderNotExist() {
@@ -238,6 +471,242 @@ func (t *testSignDocumentsOperations) TestSenderNotExistInSignersList() {
 	t.Contains(err.Error(), "sender not found in document Signers")
 }
 
+func (t *testSignDocumentsOperations) TestAlreadySigned() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	dd := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, []DocSign{NewDocSign(sa.Address, t.signcode1, true)})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	tfd := t.newSignDocument(sa.Address, sa.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document already signed")
+}
+
+func (t *testSignDocumentsOperations) TestWrongSigncode() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docid, ca.Address, "wrong-signcode", t.cid)}
+	tfd := t.newSignDocument(sa.Address, sa.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "signcode not matched")
+}
+
+func (t *testSignDocumentsOperations) TestCommitmentAsSigncode() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), t.newTestFixedFeeer(ca.Address))))
+
+	opr := t.processor(cp, pool)
+
+	// NOTE the commitment in document is public, so it can not be used as
+	// signcode
+	ds, _ := dd.Signer(sa.Address)
+	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docid, ca.Address, ds.Signcode(), t.cid)}
+	err := opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "signcode not matched")
+}
+
+func (t *testSignDocumentsOperations) TestPlainSigncode() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	// NOTE existing document has the plain signcode of signer
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	dd := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, []DocSign{NewDocSign(sa.Address, t.signcode1, false)})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), t.newTestFixedFeeer(ca.Address))))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+}
+
+func (t *testSignDocumentsOperations) TestSequentialSigning() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, second signer
+	ga, stg := t.newAccount(true, balance) // first signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+	dd := NewDocumentData(info, ca.Address, t.signcode0, t.title, t.size, []DocSign{
+		NewDocSign(ga.Address, t.signcode1, false),
+		NewDocSign(sa.Address, t.signcode1, false),
+	}).WithSigningMode(SigningSequential)
+
+	sts := t.newStateDocument(ca.Address, dd)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+
+	{ // second signer can not sign before first signer
+		pool, _ := t.statepool(sta, stg, stb, sts)
+		opr := t.processor(cp, pool)
+
+		err := opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items))
+
+		var oper operation.ReasonError
+		t.True(xerrors.As(err, &oper))
+		t.Contains(err.Error(), "sender is not the next signer")
+	}
+
+	{ // first signer can sign
+		pool, _ := t.statepool(sta, stg, stb, sts)
+		opr := t.processor(cp, pool)
+
+		t.NoError(opr.Process(t.newSignDocument(ga.Address, ga.Privs(), items)))
+
+		var dds state.State
+		for _, stu := range pool.Updates() {
+			if stu.Key() == StateKeyDocumentData(DocId(t.docid)) {
+				dds = stu.GetState()
+			}
+		}
+		t.NotNil(dds)
+
+		ndd, err := StateDocumentDataValue(dds)
+		t.NoError(err)
+
+		next, found := ndd.NextSigner()
+		t.True(found)
+		t.True(next.Address().Equal(sa.Address))
+	}
+}
+
+func (t *testSignDocumentsOperations) TestExpiredDocument() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	pool, _ := t.statepool(sta, stb)
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address).WithExpiry(pool.Height() - 1)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ = t.statepool(sta, stb, sts)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	tfd := t.newSignDocument(sa.Address, sa.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document expired")
+}
+
+func (t *testSignDocumentsOperations) TestCanceledDocument() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address).Cancel(base.Height(0))
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	tfd := t.newSignDocument(sa.Address, sa.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document canceled at height")
+}
+
+func (t *testSignDocumentsOperations) TestNotYetExpiredDocument() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	pool, _ := t.statepool(sta, stb)
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address).WithExpiry(pool.Height())
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ = t.statepool(sta, stb, sts)
+
+	feeer := t.newTestFixedFeeer(ca.Address)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+}
+
 func (t *testSignDocumentsOperations) TestInsufficientBalanceForFee() {
 	balance := []currency.Amount{currency.NewAmount(currency.NewBig(2), t.cid)}
 	sa, st := t.newAccount(true, balance) // sender, signer
@@ -273,7 +742,7 @@ func (t *testSignDocumentsOperations) TestMultipleItemsWithFee() {
 	ca, stb := t.newAccount(true, []currency.Amount{currency.NewAmount(currency.NewBig(0), cid0)})
 
 	dd0 := t.newTestDocumentData(ca.Address, sa.Address)
-	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: FileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signed: false}})
+	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signcode: t.signcode1, status: DocSignPending}})
 	sts0 := t.newStateDocument(ca.Address, dd0)
 	dinv0, _ := StateDocumentsValue(sts0[0])
 	err := dinv0.Append(DocInfo{idx: currency.NewBig(1), filehash: dd1.FileHash()})
@@ -335,7 +804,7 @@ func (t *testSignDocumentsOperations) TestInsufficientMultipleItemsWithFee() {
 	ca, stb := t.newAccount(true, []currency.Amount{currency.NewAmount(currency.NewBig(0), cid0)})
 
 	dd0 := t.newTestDocumentData(ca.Address, sa.Address)
-	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: FileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signed: false}})
+	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signcode: t.signcode1, status: DocSignPending}})
 	sts0 := t.newStateDocument(ca.Address, dd0)
 	dinv0, _ := StateDocumentsValue(sts0[0])
 	err := dinv0.Append(DocInfo{idx: currency.NewBig(1), filehash: dd1.FileHash()})
@@ -386,7 +855,7 @@ func (t *testSignDocumentsOperations) TestSameSenders() {
 	ca, stb := t.newAccount(true, []currency.Amount{currency.NewAmount(currency.NewBig(0), cid0)})
 
 	dd0 := t.newTestDocumentData(ca.Address, sa.Address)
-	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: FileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signed: false}})
+	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("EFGH")}, ca.Address, t.signcode0, t.title, t.size, []DocSign{{address: sa.Address, signcode: t.signcode1, status: DocSignPending}})
 	sts0 := t.newStateDocument(ca.Address, dd0)
 	dinv0, _ := StateDocumentsValue(sts0[0])
 	err := dinv0.Append(DocInfo{idx: currency.NewBig(1), filehash: dd1.FileHash()})
@@ -425,6 +894,69 @@ func (t *testSignDocumentsOperations) TestSameSenders() {
 	t.Contains(err.Error(), "violates only one sender")
 }
 
+func (t *testSignDocumentsOperations) TestPendingDocuments() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance) // sender, signer
+	ca, stb := t.newAccount(true, balance) // creator, owner
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	other := MustNewDocInfo(1, MustFileHash("EFGH"))
+	pst := t.newStatePendingDocuments(sa.Address, currency.ZeroBig, []DocInfo{dd.Info(), other})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts, []state.State{pst})
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewNilFeeer())))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+
+	var ust state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyPendingDocuments(sa.Address, currency.ZeroBig) {
+			ust = stu.GetState()
+		}
+	}
+	t.NotNil(ust)
+
+	inv, err := StatePendingDocumentsValue(ust)
+	t.NoError(err)
+	t.Equal(1, len(inv.Documents()))
+	t.True(inv.Documents()[0].Equal(other))
+
+	// NOTE the loaded state is not modified
+	oinv, err := StatePendingDocumentsValue(pst)
+	t.NoError(err)
+	t.Equal(2, len(oinv.Documents()))
+}
+
+func (t *testSignDocumentsOperations) TestWithoutPendingDocuments() {
+	balance := t.newTestBalance()
+	sa, sta := t.newAccount(true, balance)
+	ca, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, sa.Address)
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewNilFeeer())))
+
+	opr := t.processor(cp, pool)
+
+	items := []SignDocumentItem{t.newSignDocumentsItem(t.docid, ca.Address, t.cid)}
+	t.NoError(opr.Process(t.newSignDocument(sa.Address, sa.Privs(), items)))
+
+	for _, stu := range pool.Updates() {
+		t.False(IsStatePendingDocumentsKey(stu.Key()))
+	}
+}
+
 func TestSignDocumentsOperations(t *testing.T) {
 	suite.Run(t, new(testSignDocumentsOperations))
 }
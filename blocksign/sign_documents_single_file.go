@@ -1,6 +1,7 @@
 package blocksign
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/util/hint"
@@ -8,17 +9,25 @@ import (
 
 var (
 	SignItemSingleDocumentType   = hint.Type("mitum-blocksign-sign-item-single-document")
-	SignItemSingleDocumentHint   = hint.NewHint(SignItemSingleDocumentType, "v0.0.1")
+	SignItemSingleDocumentHint   = hint.NewHint(SignItemSingleDocumentType, "v0.0.2")
 	SignItemSingleDocumentHinter = BaseSignDocumentsItem{hint: SignItemSingleDocumentHint}
+	// SignItemSingleDocumentLegacyHint is the hint of item before the signer
+	// signcode.
+	SignItemSingleDocumentLegacyHint = hint.NewHint(SignItemSingleDocumentType, "v0.0.1")
 )
 
 type SignDocumentsItemSingleFile struct {
 	BaseSignDocumentsItem
 }
 
-func NewSignDocumentsItemSingleFile(docId currency.Big, owner base.Address, cid currency.CurrencyID) SignDocumentsItemSingleFile {
+func NewSignDocumentsItemSingleFile(
+	docId currency.Big,
+	owner base.Address,
+	signcode string,
+	cid currency.CurrencyID,
+) SignDocumentsItemSingleFile {
 	return SignDocumentsItemSingleFile{
-		BaseSignDocumentsItem: NewBaseSignDocumentsItem(SignItemSingleDocumentHint, docId, owner, cid),
+		BaseSignDocumentsItem: NewBaseSignDocumentsItem(SignItemSingleDocumentHint, docId, owner, signcode, cid),
 	}
 }
 
@@ -27,6 +36,10 @@ func (it SignDocumentsItemSingleFile) IsValid([]byte) error {
 		return err
 	}
 
+	if !it.isLegacy() && len(it.signcode) < 1 {
+		return errors.Errorf("empty signer signcode")
+	}
+
 	return nil
 }
 
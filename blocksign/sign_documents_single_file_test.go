@@ -1,6 +1,7 @@
 package blocksign
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/spikeekips/mitum-currency/currency"
@@ -30,7 +31,7 @@ func (t *testSignDocumentsItemSingleFile) TestNew() {
 	g := MustAddress(util.UUID().String())
 
 	token := util.UUID().Bytes()
-	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, t.cid)}
+	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, "user1", t.cid)}
 	fact := NewSignDocumentsFact(token, g, items)
 
 	var fs []operation.FactSign
@@ -61,7 +62,7 @@ func (t *testSignDocumentsItemSingleFile) TestZeroBig() {
 
 	token := util.UUID().Bytes()
 	cid := currency.CurrencyID("")
-	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, cid)}
+	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, "user1", cid)}
 
 	err := items[0].IsValid(nil)
 	t.Contains(err.Error(), "invalid length of currency id")
@@ -81,6 +82,27 @@ func (t *testSignDocumentsItemSingleFile) TestZeroBig() {
 	t.Contains(err.Error(), "invalid length of currency id")
 }
 
+func (t *testSignDocumentsItemSingleFile) TestEmptySigncode() {
+	s := MustAddress(util.UUID().String())
+
+	err := NewSignDocumentsItemSingleFile(t.docId, s, "", t.cid).IsValid(nil)
+	t.Contains(err.Error(), "empty signer signcode")
+}
+
+func (t *testSignDocumentsItemSingleFile) TestLegacy() {
+	it := NewSignDocumentsItemSingleFile(currency.NewBig(3), MustAddress("owner0"), "", t.cid)
+	it.hint = SignItemSingleDocumentLegacyHint
+
+	// NOTE legacy item does not have the signer signcode
+	t.NoError(it.IsValid(nil))
+
+	// NOTE the bytes are generated by the encoding before the signer signcode
+	t.Equal("036f776e6572303a6d63612d76302e302e3153484f574d45", hex.EncodeToString(it.Bytes()))
+
+	nit := NewSignDocumentsItemSingleFile(currency.NewBig(3), MustAddress("owner0"), "user1", t.cid)
+	t.NotEqual(it.Bytes(), nit.Bytes())
+}
+
 func TestSignDocumentsItemSingleFile(t *testing.T) {
 	suite.Run(t, new(testSignDocumentsItemSingleFile))
 }
@@ -97,8 +119,8 @@ func testSignDocumentsItemSingleFileEncode(enc encoder.Encoder) suite.TestingSui
 
 		token := util.UUID().Bytes()
 		items := []SignDocumentItem{
-			NewSignDocumentsItemSingleFile(docId0, s, currency.CurrencyID("SHOWME")),
-			NewSignDocumentsItemSingleFile(docId1, s, currency.CurrencyID("FINDME")),
+			NewSignDocumentsItemSingleFile(docId0, s, "user1", currency.CurrencyID("SHOWME")),
+			NewSignDocumentsItemSingleFile(docId1, s, "user1", currency.CurrencyID("FINDME")),
 		}
 		fact := NewSignDocumentsFact(token, g, items)
 
@@ -138,6 +160,7 @@ func testSignDocumentsItemSingleFileEncode(enc encoder.Encoder) suite.TestingSui
 			b := ufact.Items()[i]
 			t.True(a.DocumentId().Equal(b.DocumentId()))
 			t.True(a.Owner().Equal(b.Owner()))
+			t.Equal(a.Signcode(), b.Signcode())
 			t.Equal(a.Currency(), (b.Currency()))
 		}
 
@@ -29,7 +29,7 @@ func (t *testSignDocuments) TestNew() {
 	g := MustAddress(util.UUID().String())
 
 	token := util.UUID().Bytes()
-	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, t.cid)}
+	items := []SignDocumentItem{NewSignDocumentsItemSingleFile(t.docId, s, "user1", t.cid)}
 	fact := NewSignDocumentsFact(token, g, items)
 
 	var fs []operation.FactSign
@@ -60,8 +60,8 @@ func (t *testSignDocuments) TestDuplicatedDocuments() {
 
 	token := util.UUID().Bytes()
 	items := []SignDocumentItem{
-		NewSignDocumentsItemSingleFile(t.docId, s, t.cid),
-		NewSignDocumentsItemSingleFile(t.docId, s, t.cid),
+		NewSignDocumentsItemSingleFile(t.docId, s, "user1", t.cid),
+		NewSignDocumentsItemSingleFile(t.docId, s, "user1", t.cid),
 	}
 	fact := NewSignDocumentsFact(token, g, items)
 
@@ -85,7 +85,7 @@ func (t *testSignDocuments) TestOverSizeMemo() {
 	token := util.UUID().Bytes()
 
 	items := []SignDocumentItem{
-		NewSignDocumentsItemSingleFile(t.docId, s, t.cid),
+		NewSignDocumentsItemSingleFile(t.docId, s, "user1", t.cid),
 	}
 	fact := NewSignDocumentsFact(token, g, items)
 
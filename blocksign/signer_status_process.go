@@ -0,0 +1,234 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+// checkSignerStatus checks whether the signing status of signer can be changed
+// in document at the given height.
+type checkSignerStatus func(dd DocumentData, ds DocSign, height base.Height) error
+
+// SignerStatusItemProcessor changes the signing status of sender in the
+// document of item; it is shared by RejectDocuments and RevokeSignDocuments.
+type SignerStatusItemProcessor struct {
+	cp     *currency.CurrencyPool
+	sender base.Address
+	height base.Height
+	h      valuehash.Hash
+	item   SignDocumentItem
+	status DocSignStatus     // new signing status of sender
+	check  checkSignerStatus // checks signing status of sender before changed
+	info   DocInfo           // document info of pending document inventory
+	nds    state.State       // new document data state (key = document filehash)
+}
+
+func (opp *SignerStatusItemProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) error {
+
+	if err := opp.item.IsValid(nil); err != nil {
+		return err
+	}
+
+	// check existence of owner account
+	if _, found, err := getState(currency.StateKeyAccount(opp.item.Owner())); err != nil {
+		return err
+	} else if !found {
+		return errors.Errorf("owner does not exist, %q", opp.item.Owner())
+	}
+
+	// get document info from document inventory of owner
+	docinfo, err := loadOwnerDocument(opp.item.Owner(), opp.item.DocumentId(), getState)
+	if err != nil {
+		return err
+	}
+
+	// check existence of new document state with documentid
+	switch st, found, err := getState(StateKeyDocumentData(DocId(docinfo.Index()))); {
+	case err != nil:
+		return err
+	case !found:
+		return errors.Errorf("document not registered with documentid, %q", docinfo.Index())
+	default:
+		opp.nds = st
+	}
+
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return err
+	}
+
+	if !dd.Creator().Equal(opp.item.Owner()) {
+		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
+	}
+
+	if dd.IsCanceled() {
+		return errors.Errorf("document canceled at height, %v", dd.Canceled())
+	}
+
+	// check signer exist in document data signers
+	ds, found := dd.Signer(opp.sender)
+	if !found {
+		return errors.Errorf("sender not found in document Signers, %v", opp.sender)
+	}
+
+	if err := opp.check(dd, ds, opp.height); err != nil {
+		return err
+	}
+
+	opp.info = dd.Info()
+
+	dd, err = dd.SetSignerStatus(opp.sender, opp.status, opp.height)
+	if err != nil {
+		return err
+	}
+
+	// update document data state
+	st, err := SetStateDocumentDataValue(opp.nds, dd)
+	if err != nil {
+		return err
+	}
+	opp.nds = st
+
+	return nil
+}
+
+func (opp *SignerStatusItemProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) ([]state.State, error) {
+
+	sts := make([]state.State, 1)
+	sts[0] = opp.nds
+
+	return sts, nil
+}
+
+// signerStatusProcessor processes the items, which change the signing status
+// of sender, and pays the fee of items from sender balance.
+type signerStatusProcessor struct {
+	cp       *currency.CurrencyPool
+	height   base.Height                                  // height of block, which signing status is changed in
+	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
+	ns       []*SignerStatusItemProcessor                 // ItemProcessor
+	required map[currency.CurrencyID][2]currency.Big      // Fee
+	pending  *documentPages                               // pending document inventory pages of sender
+}
+
+func (opp *signerStatusProcessor) preProcess(
+	h valuehash.Hash,
+	sender base.Address,
+	items []SignDocumentItem,
+	fs []operation.FactSign,
+	status DocSignStatus,
+	check checkSignerStatus,
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	// check sender account state existence
+	if err := checkExistsState(currency.StateKeyAccount(sender), getState); err != nil {
+		return err
+	}
+
+	cids := make([]currency.CurrencyID, len(items))
+	for i := range items {
+		cids[i] = items[i].Currency()
+	}
+
+	if required, err := CalculateFlatItemsFee(opp.cp, cids); err != nil {
+		return operation.NewBaseReasonError("failed to calculate fee: %w", err)
+	} else if sb, err := CheckDocumentOwnerEnoughBalance(sender, required, getState); err != nil {
+		return err
+	} else {
+		opp.required = required
+		opp.sb = sb
+	}
+
+	ns := make([]*SignerStatusItemProcessor, len(items))
+	for i := range items {
+
+		c := &SignerStatusItemProcessor{
+			cp:     opp.cp,
+			sender: sender,
+			height: opp.height,
+			h:      h,
+			item:   items[i],
+			status: status,
+			check:  check,
+		}
+		if err := c.PreProcess(getState, setState); err != nil {
+			return operation.NewBaseReasonErrorFromError(err)
+		}
+
+		ns[i] = c
+	}
+
+	// check fact sign
+	if err := checkFactSignsByState(sender, fs, getState); err != nil {
+		return operation.NewBaseReasonError("invalid signing: %w", err)
+	}
+
+	pending := newPendingDocumentPages()
+	for i := range items {
+		if err := pending.load(sender, items[i].DocumentId(), getState); err != nil {
+			return err
+		}
+	}
+
+	opp.ns = ns
+	opp.pending = pending
+
+	return nil
+}
+
+func (opp *signerStatusProcessor) process(
+	factHash valuehash.Hash,
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	var sts []state.State // nolint:prealloc
+
+	for i := range opp.ns {
+		c := opp.ns[i]
+		if s, err := c.Process(getState, setState); err != nil {
+			return operation.NewBaseReasonError("failed to process document item: %w", err)
+		} else {
+			sts = append(sts, s...)
+		}
+
+		// NOTE revoked signer has to sign document again and rejected signer
+		// does not
+		var err error
+		if c.status == DocSignPending {
+			err = opp.pending.set(c.sender, c.info)
+		} else {
+			err = opp.pending.discard(c.sender, c.info.Index())
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if pts, err := opp.pending.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, pts...)
+	}
+
+	for k := range opp.required {
+		rq := opp.required[k]
+		sts = append(sts, opp.sb[k].Sub(rq[0]).AddFee(rq[1]))
+	}
+
+	return setState(factHash, sts...)
+}
+
+func (opp *signerStatusProcessor) lockedKeys() []string {
+	return opp.pending.keys()
+}
@@ -13,9 +13,13 @@ import (
 )
 
 var (
-	StateKeyDocumentsSuffix    = ":documents"
-	StateKeyDocumentDataSuffix = ":documentData"
-	StateKeyLastDocumentId     = "lastdocumentId"
+	StateKeyDocumentsSuffix        = ":documents"
+	StateKeyDocumentsPageSuffix    = ":documentspage"
+	StateKeyPendingDocumentsSuffix = ":pendingdocuments"
+	StateKeyDocumentDataSuffix     = ":documentData"
+	StateKeyFileHashSuffix         = ":filehash"
+	StateKeyLastDocumentId         = "lastdocumentId"
+	StateKeyDocumentPolicy         = "documentpolicy"
 )
 
 func StateKeyDocumentData(documentid DocId) string {
@@ -55,6 +59,16 @@ func IsStateDocumentsKey(key string) bool {
 	return strings.HasSuffix(key, StateKeyDocumentsSuffix)
 }
 
+// StateKeyDocumentsPage is the key of the page of document inventory; the
+// document is stored in the page by its document id.
+func StateKeyDocumentsPage(a base.Address, page currency.Big) string {
+	return fmt.Sprintf("%s-%s%s", currency.StateAddressKeyPrefix(a), page.String(), StateKeyDocumentsPageSuffix)
+}
+
+func IsStateDocumentsPageKey(key string) bool {
+	return strings.HasSuffix(key, StateKeyDocumentsPageSuffix)
+}
+
 func StateDocumentsValue(st state.State) (DocumentInventory, error) {
 	v := st.Value()
 	if v == nil {
@@ -76,6 +90,192 @@ func SetStateDocumentsValue(st state.State, v DocumentInventory) (state.State, e
 	}
 }
 
+// StateKeyPendingDocuments is the key of the page of document inventory of
+// signer; the documents in inventory are not yet signed by signer.
+func StateKeyPendingDocuments(a base.Address, page currency.Big) string {
+	return fmt.Sprintf("%s-%s%s", currency.StateAddressKeyPrefix(a), page.String(), StateKeyPendingDocumentsSuffix)
+}
+
+func IsStatePendingDocumentsKey(key string) bool {
+	return strings.HasSuffix(key, StateKeyPendingDocumentsSuffix)
+}
+
+func StatePendingDocumentsValue(st state.State) (DocumentInventory, error) {
+	v := st.Value()
+	if v == nil {
+		return DocumentInventory{}, util.NotFoundError.Errorf("pending document inventory not found in State")
+	}
+
+	if s, ok := v.Interface().(DocumentInventory); !ok {
+		return DocumentInventory{}, errors.Errorf("invalid pending document inventory value found, %T", v.Interface())
+	} else {
+		return s, nil
+	}
+}
+
+func SetStatePendingDocumentsValue(st state.State, v DocumentInventory) (state.State, error) {
+	if uv, err := state.NewHintedValue(v); err != nil {
+		return nil, err
+	} else {
+		return st.SetValue(uv)
+	}
+}
+
+// StateKeyFileHash is the key of file hash index; the value is the document
+// inventory of documents, which are registered with the file hash.
+func StateKeyFileHash(fh FileHash) string {
+	return fmt.Sprintf("%s%s", fh.String(), StateKeyFileHashSuffix)
+}
+
+func IsStateFileHashKey(key string) bool {
+	return strings.HasSuffix(key, StateKeyFileHashSuffix)
+}
+
+func StateFileHashValue(st state.State) (DocumentInventory, error) {
+	v := st.Value()
+	if v == nil {
+		return DocumentInventory{}, util.NotFoundError.Errorf("file hash index not found in State")
+	}
+
+	if s, ok := v.Interface().(DocumentInventory); !ok {
+		return DocumentInventory{}, errors.Errorf("invalid file hash index value found, %T", v.Interface())
+	} else {
+		return s, nil
+	}
+}
+
+func SetStateFileHashValue(st state.State, v DocumentInventory) (state.State, error) {
+	if uv, err := state.NewHintedValue(v); err != nil {
+		return nil, err
+	} else {
+		return st.SetValue(uv)
+	}
+}
+
+// loadFileHashIndex returns the file hash index state and the documents
+// registered with file hash. With unique, the file hash, which is already
+// registered by the other document, is not allowed.
+func loadFileHashIndex(
+	fh FileHash,
+	id currency.Big,
+	unique bool,
+	getState func(key string) (state.State, bool, error),
+) (state.State, DocumentInventory, error) {
+	st, found, err := getState(StateKeyFileHash(fh))
+	switch {
+	case err != nil:
+		return nil, DocumentInventory{}, err
+	case !found:
+		return st, NewDocumentInventory(nil), nil
+	}
+
+	fhinv, err := StateFileHashValue(st)
+	if err != nil {
+		return nil, DocumentInventory{}, err
+	}
+
+	if unique {
+		for i := range fhinv.Documents() {
+			if !fhinv.Documents()[i].Index().Equal(id) {
+				return nil, DocumentInventory{}, errors.Errorf(
+					"filehash already registered by document, %v", fhinv.Documents()[i].Index())
+			}
+		}
+	}
+
+	return st, fhinv, nil
+}
+
+// appendFileHashIndex adds document to file hash index state.
+func appendFileHashIndex(st state.State, fhinv DocumentInventory, docInfo DocInfo) (state.State, error) {
+	if !fhinv.Exists(docInfo.Index()) {
+		if err := fhinv.Append(docInfo); err != nil {
+			return nil, err
+		}
+		fhinv.Sort(true)
+	}
+
+	return SetStateFileHashValue(st, fhinv)
+}
+
+func IsStateLastDocumentIdKey(key string) bool {
+	return key == StateKeyLastDocumentId
+}
+
+func StateLastDocumentIdValue(st state.State) (DocId, error) {
+	v := st.Value()
+	if v == nil {
+		return DocId{}, util.NotFoundError.Errorf("last document id not found in State")
+	}
+
+	if s, ok := v.Interface().(DocId); !ok {
+		return DocId{}, errors.Errorf("invalid last document id value found, %T", v.Interface())
+	} else {
+		return s, nil
+	}
+}
+
+func SetStateLastDocumentIdValue(st state.State, v DocId) (state.State, error) {
+	if uv, err := state.NewHintedValue(v); err != nil {
+		return nil, err
+	} else {
+		return st.SetValue(uv)
+	}
+}
+
+// NextDocumentId returns the document id which will be assigned to the next
+// document; without last document id state, ids start from 0.
+func NextDocumentId(st state.State) (currency.Big, error) {
+	if st == nil || st.Value() == nil {
+		return currency.ZeroBig, nil
+	}
+
+	last, err := StateLastDocumentIdValue(st)
+	if err != nil {
+		return currency.Big{}, err
+	}
+
+	return last.Index().Add(currency.NewBig(1)), nil
+}
+
+func IsStateDocumentPolicyKey(key string) bool {
+	return key == StateKeyDocumentPolicy
+}
+
+func StateDocumentPolicyValue(st state.State) (DocumentPolicy, error) {
+	v := st.Value()
+	if v == nil {
+		return DocumentPolicy{}, util.NotFoundError.Errorf("document policy not found in State")
+	}
+
+	if s, ok := v.Interface().(DocumentPolicy); !ok {
+		return DocumentPolicy{}, errors.Errorf("invalid document policy value found, %T", v.Interface())
+	} else {
+		return s, nil
+	}
+}
+
+func SetStateDocumentPolicyValue(st state.State, v DocumentPolicy) (state.State, error) {
+	if uv, err := state.NewHintedValue(v); err != nil {
+		return nil, err
+	} else {
+		return st.SetValue(uv)
+	}
+}
+
+// LoadDocumentPolicy returns the stored DocumentPolicy; without the document
+// policy state, DefaultDocumentPolicy.
+func LoadDocumentPolicy(getState func(key string) (state.State, bool, error)) (DocumentPolicy, error) {
+	switch st, found, err := getState(StateKeyDocumentPolicy); {
+	case err != nil:
+		return DocumentPolicy{}, err
+	case !found || st.Value() == nil:
+		return DefaultDocumentPolicy, nil
+	default:
+		return StateDocumentPolicyValue(st)
+	}
+}
+
 func checkExistsState(
 	key string,
 	getState func(key string) (state.State, bool, error),
@@ -0,0 +1,17 @@
+// +build test
+
+package blocksign
+
+import "crypto/sha256"
+
+// MustFileHash returns sha256 FileHash of string.
+func MustFileHash(s string) FileHash {
+	d := sha256.Sum256([]byte(s))
+
+	fh, err := NewFileHash(FileHashSHA256, d[:])
+	if err != nil {
+		panic(err)
+	}
+
+	return fh
+}
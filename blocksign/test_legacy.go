@@ -0,0 +1,49 @@
+// +build test
+
+package blocksign
+
+// LegacyDocSign returns ds with DocSignLegacyHint.
+func LegacyDocSign(ds DocSign) DocSign {
+	ds.hint = DocSignLegacyHint
+
+	return ds
+}
+
+// LegacyDocumentData returns doc with DocumentDataLegacyHint; the creator and
+// signers also have DocSignLegacyHint.
+func LegacyDocumentData(doc DocumentData) DocumentData {
+	doc.hint = DocumentDataLegacyHint
+	doc.creator = LegacyDocSign(doc.creator)
+
+	signers := make([]DocSign, len(doc.signers))
+	for i := range doc.signers {
+		signers[i] = LegacyDocSign(doc.signers[i])
+	}
+	doc.signers = signers
+
+	return doc
+}
+
+// LegacyCreateDocumentsItemSingleFile returns it with
+// CreateDocumentsItemSingleFileLegacyHint.
+func LegacyCreateDocumentsItemSingleFile(it CreateDocumentsItemSingleFile) CreateDocumentsItemSingleFile {
+	it.hint = CreateDocumentsItemSingleFileLegacyHint
+
+	return it
+}
+
+// LegacyCreateDocumentsItemMultiFiles returns it with
+// CreateDocumentsItemMultiFilesLegacyHint.
+func LegacyCreateDocumentsItemMultiFiles(it CreateDocumentsItemMultiFiles) CreateDocumentsItemMultiFiles {
+	it.hint = CreateDocumentsItemMultiFilesLegacyHint
+
+	return it
+}
+
+// LegacySignDocumentsItemSingleFile returns it with
+// SignItemSingleDocumentLegacyHint.
+func LegacySignDocumentsItemSingleFile(it SignDocumentsItemSingleFile) SignDocumentsItemSingleFile {
+	it.hint = SignItemSingleDocumentLegacyHint
+
+	return it
+}
@@ -69,6 +69,18 @@ func (t *baseTest) SetupSuite() {
 	_ = t.Encs.TestAddHinter(CreateDocumentsFact{})
 	_ = t.Encs.TestAddHinter(SignDocuments{})
 	_ = t.Encs.TestAddHinter(SignDocumentsFact{})
+	_ = t.Encs.TestAddHinter(RejectDocuments{})
+	_ = t.Encs.TestAddHinter(RejectDocumentsFact{})
+	_ = t.Encs.TestAddHinter(RevokeSignDocuments{})
+	_ = t.Encs.TestAddHinter(RevokeSignDocumentsFact{})
+	_ = t.Encs.TestAddHinter(TransferDocuments{})
+	_ = t.Encs.TestAddHinter(TransferDocumentsFact{})
+	_ = t.Encs.TestAddHinter(UpdateDocumentSigners{})
+	_ = t.Encs.TestAddHinter(UpdateDocumentSignersFact{})
+	_ = t.Encs.TestAddHinter(ReviseDocuments{})
+	_ = t.Encs.TestAddHinter(ReviseDocumentsFact{})
+	_ = t.Encs.TestAddHinter(CancelDocuments{})
+	_ = t.Encs.TestAddHinter(CancelDocumentsFact{})
 	_ = t.Encs.TestAddHinter(currency.KeyUpdaterFact{})
 	_ = t.Encs.TestAddHinter(currency.KeyUpdater{})
 	_ = t.Encs.TestAddHinter(currency.FeeOperationFact{})
@@ -79,9 +91,15 @@ func (t *baseTest) SetupSuite() {
 	_ = t.Encs.TestAddHinter(currency.CurrencyPolicyUpdater{})
 	_ = t.Encs.TestAddHinter(currency.CurrencyPolicy{})
 	_ = t.Encs.TestAddHinter(DocSign{})
+	_ = t.Encs.TestAddHinter(DocRevision{})
+	_ = t.Encs.TestAddHinter(DocFile{})
 	_ = t.Encs.TestAddHinter(DocInfo{})
+	_ = t.Encs.TestAddHinter(DocId{})
 	_ = t.Encs.TestAddHinter(DocumentData{})
 	_ = t.Encs.TestAddHinter(DocumentInventory{})
+	_ = t.Encs.TestAddHinter(DocumentPolicy{})
+	_ = t.Encs.TestAddHinter(DocumentPolicyUpdater{})
+	_ = t.Encs.TestAddHinter(DocumentPolicyUpdaterFact{})
 
 	t.cid = currency.CurrencyID("SEEME")
 }
@@ -180,7 +198,7 @@ func (t *baseTestOperationProcessor) newStateBalance(a base.Address, big currenc
 }
 
 func (t *baseTestOperationProcessor) newStateDocuments(a base.Address, doc DocInfo) state.State {
-	key := StateKeyDocuments(a)
+	key := StateKeyDocumentsPage(a, DocumentInventoryPage(doc.Index()))
 
 	docinv := NewDocumentInventory([]DocInfo{doc})
 
@@ -191,6 +209,14 @@ func (t *baseTestOperationProcessor) newStateDocuments(a base.Address, doc DocIn
 	return su
 }
 
+func (t *baseTestOperationProcessor) newStateLegacyDocuments(a base.Address, docs []DocInfo) state.State {
+	value, _ := state.NewHintedValue(NewDocumentInventory(docs))
+	su, err := state.NewStateV0(StateKeyDocuments(a), value, base.NilHeight)
+	t.NoError(err)
+
+	return su
+}
+
 func (t *baseTestOperationProcessor) newStateDocument(a base.Address, docData DocumentData) []state.State {
 
 	var sts []state.State
@@ -211,6 +237,55 @@ func (t *baseTestOperationProcessor) newStateDocumentData(docData DocumentData)
 	return su
 }
 
+func (t *baseTestOperationProcessor) newStateFileHash(fh FileHash, docs []DocInfo) state.State {
+	value, _ := state.NewHintedValue(NewDocumentInventory(docs))
+	su, err := state.NewStateV0(StateKeyFileHash(fh), value, base.NilHeight)
+	t.NoError(err)
+
+	return su
+}
+
+func (t *baseTestOperationProcessor) newStateDocumentPolicy(po DocumentPolicy) state.State {
+	value, _ := state.NewHintedValue(po)
+	su, err := state.NewStateV0(StateKeyDocumentPolicy, value, base.NilHeight)
+	t.NoError(err)
+
+	return su
+}
+
+func (t *baseTestOperationProcessor) newStatePendingDocuments(a base.Address, page currency.Big, docs []DocInfo) state.State {
+	value, _ := state.NewHintedValue(NewDocumentInventory(docs))
+	su, err := state.NewStateV0(StateKeyPendingDocuments(a, page), value, base.NilHeight)
+	t.NoError(err)
+
+	return su
+}
+
+// updatedPendingDocuments returns the updated pending document inventory of
+// address in the first page.
+func (t *baseTestOperationProcessor) updatedPendingDocuments(pool *storage.Statepool, a base.Address) (DocumentInventory, bool) {
+	for _, stu := range pool.Updates() {
+		if stu.Key() != StateKeyPendingDocuments(a, currency.ZeroBig) {
+			continue
+		}
+
+		inv, err := StatePendingDocumentsValue(stu.GetState())
+		t.NoError(err)
+
+		return inv, true
+	}
+
+	return DocumentInventory{}, false
+}
+
+func (t *baseTestOperationProcessor) newStateLastDocumentId(id currency.Big) state.State {
+	value, _ := state.NewHintedValue(DocId(id))
+	su, err := state.NewStateV0(StateKeyLastDocumentId, value, base.NilHeight)
+	t.NoError(err)
+
+	return su
+}
+
 func (t *baseTestOperationProcessor) newCurrencyDesignState(cid currency.CurrencyID, big currency.Big, genesisAccount base.Address, feeer currency.Feeer) state.State {
 	de := currency.NewCurrencyDesign(currency.NewAmount(big, cid), genesisAccount, currency.NewCurrencyPolicy(currency.ZeroBig, feeer))
 
@@ -0,0 +1,92 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+type BaseTransferDocumentsItem struct {
+	hint     hint.Hint
+	id       currency.Big
+	owner    base.Address
+	receiver base.Address
+	cid      currency.CurrencyID
+}
+
+func NewBaseTransferDocumentsItem(ht hint.Hint,
+	id currency.Big,
+	owner base.Address,
+	receiver base.Address,
+	cid currency.CurrencyID,
+) BaseTransferDocumentsItem {
+	return BaseTransferDocumentsItem{
+		hint:     ht,
+		id:       id,
+		owner:    owner,
+		receiver: receiver,
+		cid:      cid,
+	}
+}
+
+func (it BaseTransferDocumentsItem) Hint() hint.Hint {
+	return it.hint
+}
+
+func (it BaseTransferDocumentsItem) Bytes() []byte {
+	bs := make([][]byte, 4)
+	bs[0] = it.id.Bytes()
+	bs[1] = it.owner.Bytes()
+	bs[2] = it.receiver.Bytes()
+	bs[3] = it.cid.Bytes()
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (it BaseTransferDocumentsItem) IsValid([]byte) error {
+	if err := it.id.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.owner.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.receiver.IsValid(nil); err != nil {
+		return err
+	}
+
+	if it.owner.Equal(it.receiver) {
+		return errors.Errorf("receiver is same with owner, %q", it.receiver)
+	}
+
+	if err := it.cid.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DocumentId return BaseTransferDocumentsItem's document id.
+func (it BaseTransferDocumentsItem) DocumentId() currency.Big {
+	return it.id
+}
+
+func (it BaseTransferDocumentsItem) Owner() base.Address {
+	return it.owner
+}
+
+// Receiver return BaseTransferDocumentsItem's new owner address.
+func (it BaseTransferDocumentsItem) Receiver() base.Address {
+	return it.receiver
+}
+
+func (it BaseTransferDocumentsItem) Currency() currency.CurrencyID {
+	return it.cid
+}
+
+func (it BaseTransferDocumentsItem) Rebuild() TransferDocumentsItem {
+	return it
+}
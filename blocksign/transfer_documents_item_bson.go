@@ -0,0 +1,41 @@
+package blocksign // nolint:dupl
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (it BaseTransferDocumentsItem) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()),
+			bson.M{
+				"documentid": it.id,
+				"owner":      it.owner,
+				"receiver":   it.receiver,
+				"currency":   it.cid,
+			}),
+	)
+}
+
+type TransferDocumentsItemBSONUnpacker struct {
+	DI currency.Big        `bson:"documentid"`
+	OW base.AddressDecoder `bson:"owner"`
+	RC base.AddressDecoder `bson:"receiver"`
+	CI string              `bson:"currency"`
+}
+
+func (it *BaseTransferDocumentsItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ht bsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var utd TransferDocumentsItemBSONUnpacker
+	if err := bson.Unmarshal(b, &utd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, utd.DI, utd.OW, utd.RC, utd.CI)
+}
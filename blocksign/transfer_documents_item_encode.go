@@ -0,0 +1,37 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/encoder"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+func (it *BaseTransferDocumentsItem) unpack(
+	enc encoder.Encoder,
+	ht hint.Hint,
+	di currency.Big,
+	ow base.AddressDecoder,
+	rc base.AddressDecoder,
+	scid string,
+) error {
+	it.hint = ht
+
+	it.id = di
+
+	a, err := ow.Encode(enc)
+	if err != nil {
+		return err
+	}
+	it.owner = a
+
+	r, err := rc.Encode(enc)
+	if err != nil {
+		return err
+	}
+	it.receiver = r
+
+	it.cid = currency.CurrencyID(scid)
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type TransferDocumentsItemJSONPacker struct {
+	jsonenc.HintedHead
+	DI currency.Big        `json:"documentid"`
+	OW base.Address        `json:"owner"`
+	RC base.Address        `json:"receiver"`
+	CI currency.CurrencyID `json:"currency"`
+}
+
+func (it BaseTransferDocumentsItem) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(TransferDocumentsItemJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(it.Hint()),
+		DI:         it.id,
+		OW:         it.owner,
+		RC:         it.receiver,
+		CI:         it.cid,
+	})
+}
+
+type TransferDocumentsItemJSONUnpacker struct {
+	DI currency.Big        `json:"documentid"`
+	OW base.AddressDecoder `json:"owner"`
+	RC base.AddressDecoder `json:"receiver"`
+	CI string              `json:"currency"`
+}
+
+func (it *BaseTransferDocumentsItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ht jsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var utd TransferDocumentsItemJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &utd); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, utd.DI, utd.OW, utd.RC, utd.CI)
+}
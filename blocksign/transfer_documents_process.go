@@ -0,0 +1,242 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op TransferDocuments) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+type TransferDocumentsItemProcessor struct {
+	cp      *currency.CurrencyPool
+	sender  base.Address
+	h       valuehash.Hash
+	item    TransferDocumentsItem
+	docInfo DocInfo     // transferred document info
+	nds     state.State // document data state (key = document id)
+}
+
+func (opp *TransferDocumentsItemProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) error {
+	if err := opp.item.IsValid(nil); err != nil {
+		return err
+	}
+
+	if !opp.item.Owner().Equal(opp.sender) {
+		return errors.Errorf("sender is not owner of document, %v", opp.item.DocumentId())
+	}
+
+	// check existence of receiver account
+	if _, found, err := getState(currency.StateKeyAccount(opp.item.Receiver())); err != nil {
+		return err
+	} else if !found {
+		return errors.Errorf("receiver does not exist, %q", opp.item.Receiver())
+	}
+
+	// check existence of document data state with documentid
+	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.item.DocumentId()))); {
+	case err != nil:
+		return err
+	case !found:
+		return errors.Errorf("document not registered with documentid, %q", opp.item.DocumentId())
+	default:
+		opp.nds = st
+	}
+
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return err
+	}
+
+	if !dd.Creator().Equal(opp.item.Owner()) {
+		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
+	}
+
+	if dd.IsCanceled() {
+		return errors.Errorf("document canceled at height, %v", dd.Canceled())
+	}
+
+	for i := range dd.Signers() {
+		if dd.Signers()[i].Address().Equal(opp.item.Receiver()) {
+			return errors.Errorf("receiver is signer of document, %q", opp.item.Receiver())
+		}
+	}
+
+	opp.docInfo = dd.Info()
+
+	return nil
+}
+
+func (opp *TransferDocumentsItemProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) ([]state.State, error) {
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return nil, err
+	}
+
+	// change document creator to receiver
+	dd.creator = NewDocSignWithStatus(opp.item.Receiver(), dd.creator.signcode, dd.creator.status, dd.creator.height)
+
+	sts := make([]state.State, 1)
+	if dst, err := SetStateDocumentDataValue(opp.nds, dd); err != nil {
+		return nil, err
+	} else {
+		sts[0] = dst
+	}
+
+	return sts, nil
+}
+
+type TransferDocumentsProcessor struct {
+	cp *currency.CurrencyPool
+	TransferDocuments
+	docs     *documentPages                               // sender and receiver document inventory pages
+	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
+	ns       []*TransferDocumentsItemProcessor            // ItemProcessor
+	required map[currency.CurrencyID][2]currency.Big      // Fee
+}
+
+func NewTransferDocumentsProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(TransferDocuments); !ok {
+			return nil, errors.Errorf("not TransferDocuments, %T", op)
+		} else {
+			return &TransferDocumentsProcessor{
+				cp:                cp,
+				TransferDocuments: i,
+			}, nil
+		}
+	}
+}
+
+func (opp *TransferDocumentsProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(TransferDocumentsFact)
+
+	// check sender account state existence
+	if err := checkExistsState(currency.StateKeyAccount(fact.sender), getState); err != nil {
+		return nil, err
+	}
+
+	// check existence of documents in sender document inventory
+	docs := newOwnerDocumentPages()
+	if err := docs.migrate(fact.sender, getState); err != nil {
+		return nil, err
+	}
+
+	for i := range fact.items {
+		if err := docs.load(fact.sender, fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+
+		if !docs.exists(fact.sender, fact.items[i].DocumentId()) {
+			return nil, operation.NewBaseReasonError(
+				"document not found in sender document inventory, %v", fact.items[i].DocumentId())
+		}
+	}
+
+	// prepare receiver document inventory pages
+	for i := range fact.items {
+		if err := docs.migrate(fact.items[i].Receiver(), getState); err != nil {
+			return nil, err
+		}
+
+		if err := docs.load(fact.items[i].Receiver(), fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+	}
+
+	if required, err := opp.calculateItemsFee(); err != nil {
+		return nil, operation.NewBaseReasonError("failed to calculate fee: %w", err)
+	} else if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
+		return nil, err
+	} else {
+		opp.required = required
+		opp.sb = sb
+	}
+
+	ns := make([]*TransferDocumentsItemProcessor, len(fact.items))
+	for i := range fact.items {
+		c := &TransferDocumentsItemProcessor{cp: opp.cp, sender: fact.sender, h: opp.Hash(), item: fact.items[i]}
+		if err := c.PreProcess(getState, setState); err != nil {
+			return nil, operation.NewBaseReasonErrorFromError(err)
+		}
+
+		ns[i] = c
+	}
+
+	// check fact sign
+	if err := checkFactSignsByState(fact.sender, opp.Signs(), getState); err != nil {
+		return nil, operation.NewBaseReasonError("invalid signing: %w", err)
+	}
+
+	opp.docs = docs
+	opp.ns = ns
+
+	return opp, nil
+}
+
+func (opp *TransferDocumentsProcessor) Process( // nolint:dupl
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := opp.Fact().(TransferDocumentsFact)
+
+	var sts []state.State // nolint:prealloc
+
+	// move doc info from sender document inventory to receiver document inventory
+	for i := range opp.ns {
+		s, err := opp.ns[i].Process(getState, setState)
+		if err != nil {
+			return operation.NewBaseReasonError("failed to process transfer document item: %w", err)
+		}
+		sts = append(sts, s...)
+
+		if err := opp.docs.remove(fact.sender, opp.ns[i].docInfo.Index()); err != nil {
+			return err
+		}
+
+		if err := opp.docs.append(opp.ns[i].item.Receiver(), opp.ns[i].docInfo); err != nil {
+			return err
+		}
+	}
+
+	if dts, err := opp.docs.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, dts...)
+	}
+
+	for k := range opp.required {
+		rq := opp.required[k]
+		sts = append(sts, opp.sb[k].Sub(rq[0]).AddFee(rq[1]))
+	}
+
+	return setState(fact.Hash(), sts...)
+}
+
+func (opp *TransferDocumentsProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
+	fact := opp.Fact().(TransferDocumentsFact)
+
+	cids := make([]currency.CurrencyID, len(fact.items))
+	for i := range fact.items {
+		cids[i] = fact.items[i].Currency()
+	}
+
+	return CalculateFlatItemsFee(opp.cp, cids)
+}
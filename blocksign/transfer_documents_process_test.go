@@ -0,0 +1,319 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testTransferDocumentsOperations struct {
+	baseTestOperationProcessor
+	cid      currency.CurrencyID
+	docid    currency.Big
+	fh       FileHash
+	fee      currency.Big
+	signcode string
+	title    string
+	size     currency.Big
+}
+
+func (t *testTransferDocumentsOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.fee = currency.NewBig(3)
+	t.signcode = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+}
+
+func (t *testTransferDocumentsOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(TransferDocuments{}, NewTransferDocumentsProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testTransferDocumentsOperations) newTransferDocument(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []TransferDocumentsItem,
+) TransferDocuments {
+	token := util.UUID().Bytes()
+	fact := NewTransferDocumentsFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	tfd, err := NewTransferDocuments(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(tfd.IsValid(nil))
+
+	return tfd
+}
+
+func (t *testTransferDocumentsOperations) newTestDocumentData(ca base.Address, signers []DocSign) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	return NewDocumentData(info, ca, t.signcode, t.title, t.size, signers)
+}
+
+func (t *testTransferDocumentsOperations) newTestBalance() []currency.Amount {
+	return []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+}
+
+func (t *testTransferDocumentsOperations) TestNormalCase() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance) // creator, owner
+	ra, stb := t.newAccount(true, balance) // receiver
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	feeer := currency.NewFixedFeeer(ca.Address, t.fee)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), feeer)))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	tfd := t.newTransferDocument(ca.Address, ca.Privs(), items)
+
+	t.NoError(opr.Process(tfd))
+
+	var dds, sdinvs, rdinvs, sb state.State
+	for _, stu := range pool.Updates() {
+		switch {
+		case stu.Key() == currency.StateKeyBalance(ca.Address, t.cid):
+			sb = stu.GetState()
+		case stu.Key() == StateKeyDocumentData(DocId(t.docid)):
+			dds = stu.GetState()
+		case stu.Key() == StateKeyDocumentsPage(ca.Address, currency.ZeroBig):
+			sdinvs = stu.GetState()
+		case stu.Key() == StateKeyDocumentsPage(ra.Address, currency.ZeroBig):
+			rdinvs = stu.GetState()
+		}
+	}
+
+	t.NotNil(sb)
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+	t.Equal(t.fee, sb.(currency.AmountState).Fee())
+
+	ndd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+	t.True(ndd.Creator().Equal(ra.Address))
+	t.True(ndd.FileHash().Equal(t.fh))
+
+	sdinv, err := StateDocumentsValue(sdinvs)
+	t.NoError(err)
+	t.False(sdinv.Exists(t.docid))
+
+	rdinv, err := StateDocumentsValue(rdinvs)
+	t.NoError(err)
+	t.True(rdinv.Exists(t.docid))
+}
+
+func (t *testTransferDocumentsOperations) TestLegacyDocuments() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance) // creator, owner
+	ra, stb := t.newAccount(true, balance) // receiver
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{})
+
+	// both of owner and receiver have the legacy document inventory
+	other := MustNewDocInfo(150, MustFileHash("EFGH"))
+	sts := []state.State{
+		t.newStateDocumentData(dd),
+		t.newStateLegacyDocuments(ca.Address, []DocInfo{dd.Info()}),
+		t.newStateLegacyDocuments(ra.Address, []DocInfo{other}),
+	}
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewNilFeeer())))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	t.NoError(opr.Process(t.newTransferDocument(ca.Address, ca.Privs(), items)))
+
+	invs := map[string]DocumentInventory{}
+	for _, stu := range pool.Updates() {
+		if IsStateDocumentsKey(stu.Key()) || IsStateDocumentsPageKey(stu.Key()) {
+			inv, err := StateDocumentsValue(stu.GetState())
+			t.NoError(err)
+
+			invs[stu.Key()] = inv
+		}
+	}
+
+	t.True(invs[StateKeyDocuments(ca.Address)].IsEmpty())
+	t.True(invs[StateKeyDocuments(ra.Address)].IsEmpty())
+	t.True(invs[StateKeyDocumentsPage(ca.Address, currency.ZeroBig)].IsEmpty())
+
+	rinv0 := invs[StateKeyDocumentsPage(ra.Address, currency.ZeroBig)]
+	t.Equal(1, len(rinv0.Documents()))
+	t.True(rinv0.Exists(t.docid))
+
+	rinv1 := invs[StateKeyDocumentsPage(ra.Address, currency.NewBig(1))]
+	t.Equal(1, len(rinv1.Documents()))
+	t.True(rinv1.Exists(other.Index()))
+}
+
+func (t *testTransferDocumentsOperations) TestReceiverNotExist() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ra, _ := t.newAccount(false, nil)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	tfd := t.newTransferDocument(ca.Address, ca.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "receiver does not exist")
+}
+
+func (t *testTransferDocumentsOperations) TestDocumentNotInInventory() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ra, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(currency.NewBig(1), ca.Address, ra.Address, t.cid)}
+	tfd := t.newTransferDocument(ca.Address, ca.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "document not found in sender document inventory")
+}
+
+func (t *testTransferDocumentsOperations) TestReceiverIsSigner() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ra, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ra.Address, "user1", false)})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	tfd := t.newTransferDocument(ca.Address, ca.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "receiver is signer of document")
+}
+
+func (t *testTransferDocumentsOperations) TestInsufficientBalanceForFee() {
+	balance := []currency.Amount{currency.NewAmount(currency.NewBig(2), t.cid)}
+	ca, sta := t.newAccount(true, balance)
+	ra, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	tfd := t.newTransferDocument(ca.Address, ca.Privs(), items)
+
+	err := opr.Process(tfd)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "insufficient balance")
+}
+
+func (t *testTransferDocumentsOperations) TestReceiverDocumentInProposal() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ra, stb := t.newAccount(true, balance)
+	xa, stc := t.newAccount(true, balance)
+
+	dd0 := t.newTestDocumentData(ca.Address, []DocSign{})
+	dd1 := NewDocumentData(DocInfo{idx: currency.NewBig(1), filehash: MustFileHash("EFGH")}, xa.Address, t.signcode, t.title, t.size, []DocSign{})
+
+	sts0 := t.newStateDocument(ca.Address, dd0)
+	sts1 := t.newStateDocument(xa.Address, dd1)
+	pool, _ := t.statepool(sta, stb, stc, sts0, sts1)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items0 := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(t.docid, ca.Address, ra.Address, t.cid)}
+	t.NoError(opr.Process(t.newTransferDocument(ca.Address, ca.Privs(), items0)))
+
+	items1 := []TransferDocumentsItem{NewTransferDocumentsItemSingleFile(currency.NewBig(1), xa.Address, ra.Address, t.cid)}
+	err := opr.Process(t.newTransferDocument(xa.Address, xa.Privs(), items1))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "already processed")
+}
+
+func TestTransferDocumentsOperations(t *testing.T) {
+	suite.Run(t, new(testTransferDocumentsOperations))
+}
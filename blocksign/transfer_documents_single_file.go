@@ -0,0 +1,42 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	TransferItemSingleDocumentType   = hint.Type("mitum-blocksign-transfer-item-single-document")
+	TransferItemSingleDocumentHint   = hint.NewHint(TransferItemSingleDocumentType, "v0.0.1")
+	TransferItemSingleDocumentHinter = BaseTransferDocumentsItem{hint: TransferItemSingleDocumentHint}
+)
+
+type TransferDocumentsItemSingleFile struct {
+	BaseTransferDocumentsItem
+}
+
+func NewTransferDocumentsItemSingleFile(
+	docId currency.Big,
+	owner base.Address,
+	receiver base.Address,
+	cid currency.CurrencyID,
+) TransferDocumentsItemSingleFile {
+	return TransferDocumentsItemSingleFile{
+		BaseTransferDocumentsItem: NewBaseTransferDocumentsItem(TransferItemSingleDocumentHint, docId, owner, receiver, cid),
+	}
+}
+
+func (it TransferDocumentsItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseTransferDocumentsItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it TransferDocumentsItemSingleFile) Rebuild() TransferDocumentsItem {
+	it.BaseTransferDocumentsItem = it.BaseTransferDocumentsItem.Rebuild().(BaseTransferDocumentsItem)
+
+	return it
+}
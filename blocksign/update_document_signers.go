@@ -0,0 +1,208 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/isvalid"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var (
+	UpdateDocumentSignersFactType = hint.Type("mitum-blocksign-update-document-signers-operation-fact")
+	UpdateDocumentSignersFactHint = hint.NewHint(UpdateDocumentSignersFactType, "v0.0.1")
+	UpdateDocumentSignersType     = hint.Type("mitum-blocksign-update-document-signers-operation")
+	UpdateDocumentSignersHint     = hint.NewHint(UpdateDocumentSignersType, "v0.0.1")
+)
+
+var MaxUpdateDocumentSignersItems uint = 10
+
+type UpdateDocumentSignersItem interface {
+	hint.Hinter
+	isvalid.IsValider
+	Bytes() []byte
+	DocumentId() currency.Big
+	Owner() base.Address
+	Adds() []base.Address
+	Signcodes() []string
+	Weights() []uint
+	Removes() []base.Address
+	Currency() currency.CurrencyID
+	Rebuild() UpdateDocumentSignersItem
+}
+
+type UpdateDocumentSignersFact struct {
+	h      valuehash.Hash
+	token  []byte
+	sender base.Address
+	items  []UpdateDocumentSignersItem
+}
+
+func NewUpdateDocumentSignersFact(token []byte, sender base.Address, items []UpdateDocumentSignersItem) UpdateDocumentSignersFact {
+	fact := UpdateDocumentSignersFact{
+		token:  token,
+		sender: sender,
+		items:  items,
+	}
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+func (fact UpdateDocumentSignersFact) Hint() hint.Hint {
+	return UpdateDocumentSignersFactHint
+}
+
+func (fact UpdateDocumentSignersFact) Hash() valuehash.Hash {
+	return fact.h
+}
+
+func (fact UpdateDocumentSignersFact) GenerateHash() valuehash.Hash {
+	return valuehash.NewSHA256(fact.Bytes())
+}
+
+func (fact UpdateDocumentSignersFact) Bytes() []byte {
+	is := make([][]byte, len(fact.items))
+	for i := range fact.items {
+		is[i] = fact.items[i].Bytes()
+	}
+
+	return util.ConcatBytesSlice(
+		fact.token,
+		fact.sender.Bytes(),
+		util.ConcatBytesSlice(is...),
+	)
+}
+
+func (fact UpdateDocumentSignersFact) IsValid([]byte) error {
+	if len(fact.token) < 1 {
+		return errors.Errorf("empty token for UpdateDocumentSignersFact")
+	} else if n := len(fact.items); n < 1 {
+		return errors.Errorf("empty items")
+	} else if n > int(MaxUpdateDocumentSignersItems) {
+		return errors.Errorf("items, %d over max, %d", n, MaxUpdateDocumentSignersItems)
+	}
+
+	if err := isvalid.Check([]isvalid.IsValider{
+		fact.h,
+		fact.sender,
+	}, nil, false); err != nil {
+		return err
+	}
+
+	// check duplicated document
+	foundDocId := map[string]bool{}
+	for i := range fact.items {
+		if err := fact.items[i].IsValid(nil); err != nil {
+			return err
+		}
+
+		if !fact.items[i].Owner().Equal(fact.sender) {
+			return errors.Errorf("sender is not owner of document, %s", fact.items[i].DocumentId())
+		}
+
+		k := fact.items[i].DocumentId().String()
+		if _, found := foundDocId[k]; found {
+			return errors.Errorf("duplicated document found, %s", k)
+		}
+		foundDocId[k] = true
+	}
+
+	if !fact.h.Equal(fact.GenerateHash()) {
+		return isvalid.InvalidError.Errorf("wrong Fact hash")
+	}
+
+	return nil
+}
+
+func (fact UpdateDocumentSignersFact) Token() []byte {
+	return fact.token
+}
+
+func (fact UpdateDocumentSignersFact) Sender() base.Address {
+	return fact.sender
+}
+
+func (fact UpdateDocumentSignersFact) Items() []UpdateDocumentSignersItem {
+	return fact.items
+}
+
+func (fact UpdateDocumentSignersFact) Addresses() ([]base.Address, error) {
+	var as []base.Address
+	for i := range fact.items {
+		as = append(as, fact.items[i].Adds()...)
+		as = append(as, fact.items[i].Removes()...)
+	}
+
+	return append(as, fact.Sender()), nil
+}
+
+func (fact UpdateDocumentSignersFact) Rebuild() UpdateDocumentSignersFact {
+	items := make([]UpdateDocumentSignersItem, len(fact.items))
+	for i := range fact.items {
+		it := fact.items[i]
+		items[i] = it.Rebuild()
+	}
+
+	fact.items = items
+	fact.h = fact.GenerateHash()
+
+	return fact
+}
+
+type UpdateDocumentSigners struct {
+	operation.BaseOperation
+	Memo string
+}
+
+func NewUpdateDocumentSigners(fact UpdateDocumentSignersFact, fs []operation.FactSign, memo string) (UpdateDocumentSigners, error) {
+	if bo, err := operation.NewBaseOperationFromFact(UpdateDocumentSignersHint, fact, fs); err != nil {
+		return UpdateDocumentSigners{}, err
+	} else {
+		op := UpdateDocumentSigners{BaseOperation: bo, Memo: memo}
+
+		op.BaseOperation = bo.SetHash(op.GenerateHash())
+
+		return op, nil
+	}
+}
+
+func (op UpdateDocumentSigners) Hint() hint.Hint {
+	return UpdateDocumentSignersHint
+}
+
+func (op UpdateDocumentSigners) IsValid(networkID []byte) error {
+	if err := currency.IsValidMemo(op.Memo); err != nil {
+		return err
+	}
+
+	return operation.IsValidOperation(op, networkID)
+}
+
+func (op UpdateDocumentSigners) GenerateHash() valuehash.Hash {
+	bs := make([][]byte, len(op.Signs())+1)
+	for i := range op.Signs() {
+		bs[i] = op.Signs()[i].Bytes()
+	}
+
+	bs[len(bs)-1] = []byte(op.Memo)
+
+	e := util.ConcatBytesSlice(op.Fact().Hash().Bytes(), util.ConcatBytesSlice(bs...))
+
+	return valuehash.NewSHA256(e)
+}
+
+func (op UpdateDocumentSigners) AddFactSigns(fs ...operation.FactSign) (operation.FactSignUpdater, error) {
+	if o, err := op.BaseOperation.AddFactSigns(fs...); err != nil {
+		return nil, err
+	} else {
+		op.BaseOperation = o.(operation.BaseOperation)
+	}
+
+	op.BaseOperation = op.SetHash(op.GenerateHash())
+
+	return op, nil
+}
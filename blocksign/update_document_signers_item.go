@@ -0,0 +1,173 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+type BaseUpdateDocumentSignersItem struct {
+	hint      hint.Hint
+	id        currency.Big
+	owner     base.Address
+	adds      []base.Address // new signers
+	signcodes []string       // new signers signcode
+	weights   []uint         // new signers weight; empty when document has no threshold
+	removes   []base.Address // removed signers
+	cid       currency.CurrencyID
+}
+
+func NewBaseUpdateDocumentSignersItem(ht hint.Hint,
+	id currency.Big,
+	owner base.Address,
+	adds []base.Address,
+	signcodes []string,
+	weights []uint,
+	removes []base.Address,
+	cid currency.CurrencyID,
+) BaseUpdateDocumentSignersItem {
+	return BaseUpdateDocumentSignersItem{
+		hint:      ht,
+		id:        id,
+		owner:     owner,
+		adds:      adds,
+		signcodes: signcodes,
+		weights:   weights,
+		removes:   removes,
+		cid:       cid,
+	}
+}
+
+func (it BaseUpdateDocumentSignersItem) Hint() hint.Hint {
+	return it.hint
+}
+
+func (it BaseUpdateDocumentSignersItem) Bytes() []byte {
+	bs := make([][]byte, 3)
+	bs[0] = it.id.Bytes()
+	bs[1] = it.owner.Bytes()
+	bs[2] = it.cid.Bytes()
+
+	for i := range it.adds {
+		bs = append(bs, it.adds[i].Bytes())
+	}
+	for i := range it.signcodes {
+		bs = append(bs, []byte(it.signcodes[i]))
+	}
+	for i := range it.weights {
+		bs = append(bs, util.UintToBytes(it.weights[i]))
+	}
+	for i := range it.removes {
+		bs = append(bs, it.removes[i].Bytes())
+	}
+
+	return util.ConcatBytesSlice(bs...)
+}
+
+func (it BaseUpdateDocumentSignersItem) IsValid([]byte) error {
+	if err := it.id.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.owner.IsValid(nil); err != nil {
+		return err
+	}
+
+	if err := it.cid.IsValid(nil); err != nil {
+		return err
+	}
+
+	if len(it.adds) < 1 && len(it.removes) < 1 {
+		return errors.Errorf("empty signers to add or remove")
+	}
+
+	if len(it.adds) != len(it.signcodes) {
+		return errors.Errorf("length of signcodes array is not same with length of added signers array")
+	}
+
+	if len(it.weights) > 0 && len(it.weights) != len(it.adds) {
+		return errors.Errorf("length of weights array is not same with length of added signers array")
+	}
+
+	for i := range it.weights {
+		if w := it.weights[i]; w < 1 || w > 100 {
+			return errors.Errorf("invalid signer weight, %d, 1 <= weight <= 100", w)
+		}
+	}
+
+	founds := map[string]bool{}
+	for i := range it.adds {
+		if err := it.adds[i].IsValid(nil); err != nil {
+			return err
+		}
+
+		if len(it.signcodes[i]) < 1 {
+			return errors.Errorf("empty signcode of added signer, %v", it.adds[i])
+		}
+
+		if err := SignCode(it.signcodes[i]).IsValidFormat(); err != nil {
+			return errors.Wrapf(err, "invalid signcode of added signer, %v", it.adds[i])
+		}
+
+		if it.adds[i].Equal(it.owner) {
+			return errors.Errorf("added signer is same with owner, %q", it.adds[i])
+		}
+
+		k := it.adds[i].String()
+		if _, found := founds[k]; found {
+			return errors.Errorf("duplicated signer, %v", it.adds[i])
+		}
+		founds[k] = true
+	}
+
+	for i := range it.removes {
+		if err := it.removes[i].IsValid(nil); err != nil {
+			return err
+		}
+
+		k := it.removes[i].String()
+		if _, found := founds[k]; found {
+			return errors.Errorf("duplicated signer, %v", it.removes[i])
+		}
+		founds[k] = true
+	}
+
+	return nil
+}
+
+func (it BaseUpdateDocumentSignersItem) DocumentId() currency.Big {
+	return it.id
+}
+
+func (it BaseUpdateDocumentSignersItem) Owner() base.Address {
+	return it.owner
+}
+
+// Adds return BaseUpdateDocumentSignersItem's signer addresses to be added.
+func (it BaseUpdateDocumentSignersItem) Adds() []base.Address {
+	return it.adds
+}
+
+func (it BaseUpdateDocumentSignersItem) Signcodes() []string {
+	return it.signcodes
+}
+
+func (it BaseUpdateDocumentSignersItem) Weights() []uint {
+	return it.weights
+}
+
+// Removes return BaseUpdateDocumentSignersItem's signer addresses to be
+// removed.
+func (it BaseUpdateDocumentSignersItem) Removes() []base.Address {
+	return it.removes
+}
+
+func (it BaseUpdateDocumentSignersItem) Currency() currency.CurrencyID {
+	return it.cid
+}
+
+func (it BaseUpdateDocumentSignersItem) Rebuild() UpdateDocumentSignersItem {
+	return it
+}
@@ -0,0 +1,47 @@
+package blocksign // nolint:dupl
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (it BaseUpdateDocumentSignersItem) MarshalBSON() ([]byte, error) {
+	return bsonenc.Marshal(
+		bsonenc.MergeBSONM(bsonenc.NewHintedDoc(it.Hint()),
+			bson.M{
+				"documentid": it.id,
+				"owner":      it.owner,
+				"adds":       it.adds,
+				"signcodes":  it.signcodes,
+				"weights":    it.weights,
+				"removes":    it.removes,
+				"currency":   it.cid,
+			}),
+	)
+}
+
+type UpdateDocumentSignersItemBSONUnpacker struct {
+	DI currency.Big          `bson:"documentid"`
+	OW base.AddressDecoder   `bson:"owner"`
+	AD []base.AddressDecoder `bson:"adds"`
+	SD []string              `bson:"signcodes"`
+	WT []uint                `bson:"weights"`
+	RM []base.AddressDecoder `bson:"removes"`
+	CI string                `bson:"currency"`
+}
+
+func (it *BaseUpdateDocumentSignersItem) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var ht bsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var uud UpdateDocumentSignersItemBSONUnpacker
+	if err := bson.Unmarshal(b, &uud); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, uud.DI, uud.OW, uud.AD, uud.SD, uud.WT, uud.RM, uud.CI)
+}
@@ -0,0 +1,56 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/encoder"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+func (it *BaseUpdateDocumentSignersItem) unpack(
+	enc encoder.Encoder,
+	ht hint.Hint,
+	di currency.Big,
+	ow base.AddressDecoder,
+	bad []base.AddressDecoder,
+	bsd []string,
+	bwt []uint,
+	brm []base.AddressDecoder,
+	scid string,
+) error {
+	it.hint = ht
+
+	it.id = di
+
+	a, err := ow.Encode(enc)
+	if err != nil {
+		return err
+	}
+	it.owner = a
+
+	adds := make([]base.Address, len(bad))
+	for i := range bad {
+		if a, err := bad[i].Encode(enc); err != nil {
+			return err
+		} else {
+			adds[i] = a
+		}
+	}
+	it.adds = adds
+
+	removes := make([]base.Address, len(brm))
+	for i := range brm {
+		if a, err := brm[i].Encode(enc); err != nil {
+			return err
+		} else {
+			removes[i] = a
+		}
+	}
+	it.removes = removes
+
+	it.signcodes = bsd
+	it.weights = bwt
+	it.cid = currency.CurrencyID(scid)
+
+	return nil
+}
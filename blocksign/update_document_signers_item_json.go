@@ -0,0 +1,55 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type UpdateDocumentSignersItemJSONPacker struct {
+	jsonenc.HintedHead
+	DI currency.Big        `json:"documentid"`
+	OW base.Address        `json:"owner"`
+	AD []base.Address      `json:"adds"`
+	SD []string            `json:"signcodes"`
+	WT []uint              `json:"weights"`
+	RM []base.Address      `json:"removes"`
+	CI currency.CurrencyID `json:"currency"`
+}
+
+func (it BaseUpdateDocumentSignersItem) MarshalJSON() ([]byte, error) {
+	return jsonenc.Marshal(UpdateDocumentSignersItemJSONPacker{
+		HintedHead: jsonenc.NewHintedHead(it.Hint()),
+		DI:         it.id,
+		OW:         it.owner,
+		AD:         it.adds,
+		SD:         it.signcodes,
+		WT:         it.weights,
+		RM:         it.removes,
+		CI:         it.cid,
+	})
+}
+
+type UpdateDocumentSignersItemJSONUnpacker struct {
+	DI currency.Big          `json:"documentid"`
+	OW base.AddressDecoder   `json:"owner"`
+	AD []base.AddressDecoder `json:"adds"`
+	SD []string              `json:"signcodes"`
+	WT []uint                `json:"weights"`
+	RM []base.AddressDecoder `json:"removes"`
+	CI string                `json:"currency"`
+}
+
+func (it *BaseUpdateDocumentSignersItem) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
+	var ht jsonenc.HintedHead
+	if err := enc.Unmarshal(b, &ht); err != nil {
+		return err
+	}
+
+	var uud UpdateDocumentSignersItemJSONUnpacker
+	if err := jsonenc.Unmarshal(b, &uud); err != nil {
+		return err
+	}
+
+	return it.unpack(enc, ht.H, uud.DI, uud.OW, uud.AD, uud.SD, uud.WT, uud.RM, uud.CI)
+}
@@ -0,0 +1,325 @@
+package blocksign
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+func (op UpdateDocumentSigners) Process(
+	func(key string) (state.State, bool, error),
+	func(valuehash.Hash, ...state.State) error,
+) error {
+	return nil
+}
+
+type UpdateDocumentSignersItemProcessor struct {
+	cp     *currency.CurrencyPool
+	sender base.Address
+	height base.Height
+	h      valuehash.Hash
+	item   UpdateDocumentSignersItem
+	info   DocInfo     // document info of pending document inventory
+	nds    state.State // document data state (key = document id)
+}
+
+func (opp *UpdateDocumentSignersItemProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) error {
+	if err := opp.item.IsValid(nil); err != nil {
+		return err
+	}
+
+	if !opp.item.Owner().Equal(opp.sender) {
+		return errors.Errorf("sender is not owner of document, %v", opp.item.DocumentId())
+	}
+
+	// check existence of document data state with documentid
+	switch st, found, err := getState(StateKeyDocumentData(DocId(opp.item.DocumentId()))); {
+	case err != nil:
+		return err
+	case !found:
+		return errors.Errorf("document not registered with documentid, %q", opp.item.DocumentId())
+	default:
+		opp.nds = st
+	}
+
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return err
+	}
+
+	if !dd.Creator().Equal(opp.item.Owner()) {
+		return errors.Errorf("Owner not matched with creator in document, %v", opp.item.Owner())
+	}
+
+	switch status := dd.StatusAt(opp.height); status {
+	case DocumentFullySigned, DocumentRejected, DocumentExpired, DocumentCanceled:
+		return errors.Errorf("signers of document can not be updated, document %v", status)
+	}
+
+	// removed signers must not have signed yet
+	for i := range opp.item.Removes() {
+		switch ds, found := dd.Signer(opp.item.Removes()[i]); {
+		case !found:
+			return errors.Errorf("removed signer not found in document signers, %v", opp.item.Removes()[i])
+		case ds.Signed():
+			return errors.Errorf("removed signer already signed document, %v", opp.item.Removes()[i])
+		}
+	}
+
+	// check added signers account existence
+	adds := opp.item.Adds()
+	for i := range adds {
+		switch _, found, err := getState(currency.StateKeyAccount(adds[i])); {
+		case err != nil:
+			return err
+		case !found:
+			return errors.Errorf("signer account not found, %q", adds[i])
+		}
+		if adds[i].Equal(opp.sender) {
+			return errors.Errorf("signer account is same with document creator, %q", adds[i])
+		}
+		if _, found := dd.Signer(adds[i]); found {
+			return errors.Errorf("added signer already in document signers, %v", adds[i])
+		}
+	}
+
+	if dd.Threshold() > 0 {
+		if len(opp.item.Weights()) != len(adds) {
+			return errors.Errorf("weights of added signers required for document with threshold")
+		}
+	} else if len(opp.item.Weights()) > 0 {
+		return errors.Errorf("weights of added signers not allowed for document without threshold")
+	}
+
+	opp.info = dd.Info()
+
+	signers := opp.updatedSigners(dd)
+	if len(signers) < 1 {
+		return errors.Errorf("empty signers after update")
+	}
+
+	if dd.Threshold() > 0 {
+		var sum uint
+		for i := range signers {
+			sum += signers[i].Weight()
+		}
+
+		if sum < dd.Threshold() {
+			return errors.Errorf("sum of weights under threshold, %d < %d", sum, dd.Threshold())
+		}
+	}
+
+	return nil
+}
+
+func (opp *UpdateDocumentSignersItemProcessor) Process(
+	_ func(key string) (state.State, bool, error),
+	_ func(valuehash.Hash, ...state.State) error,
+) ([]state.State, error) {
+	dd, err := StateDocumentDataValue(opp.nds)
+	if err != nil {
+		return nil, err
+	}
+
+	dd.signers = opp.updatedSigners(dd)
+
+	sts := make([]state.State, 1)
+	if dst, err := SetStateDocumentDataValue(opp.nds, dd); err != nil {
+		return nil, err
+	} else {
+		sts[0] = dst
+	}
+
+	return sts, nil
+}
+
+// updatedSigners returns the new signers of document; the order of remaining
+// signers is kept and the added signers are appended as pending.
+func (opp *UpdateDocumentSignersItemProcessor) updatedSigners(dd DocumentData) []DocSign {
+	removes := map[string]bool{}
+	for i := range opp.item.Removes() {
+		removes[opp.item.Removes()[i].String()] = true
+	}
+
+	var signers []DocSign
+	for i := range dd.Signers() {
+		if _, found := removes[dd.Signers()[i].Address().String()]; found {
+			continue
+		}
+		signers = append(signers, dd.Signers()[i])
+	}
+
+	for i := range opp.item.Adds() {
+		ds := NewDocSign(opp.item.Adds()[i], SignCode(opp.item.Signcodes()[i]).Commitment().String(), false)
+		if len(opp.item.Weights()) > 0 {
+			ds = ds.WithWeight(opp.item.Weights()[i])
+		}
+		signers = append(signers, ds)
+	}
+
+	return signers
+}
+
+type UpdateDocumentSignersProcessor struct {
+	cp     *currency.CurrencyPool
+	height base.Height // height of block, which signers are updated in
+	UpdateDocumentSigners
+	sb       map[currency.CurrencyID]currency.AmountState // sender StateBalance
+	ns       []*UpdateDocumentSignersItemProcessor        // ItemProcessor
+	required map[currency.CurrencyID][2]currency.Big      // Fee
+	pending  *documentPages                               // pending document inventory pages of updated signers
+}
+
+func NewUpdateDocumentSignersProcessor(cp *currency.CurrencyPool) currency.GetNewProcessor {
+	return func(op state.Processor) (state.Processor, error) {
+		if i, ok := op.(UpdateDocumentSigners); !ok {
+			return nil, errors.Errorf("not UpdateDocumentSigners, %T", op)
+		} else {
+			return &UpdateDocumentSignersProcessor{
+				cp:                    cp,
+				UpdateDocumentSigners: i,
+			}, nil
+		}
+	}
+}
+
+func (opp *UpdateDocumentSignersProcessor) PreProcess(
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) (state.Processor, error) {
+	fact := opp.Fact().(UpdateDocumentSignersFact)
+
+	// check sender account state existence
+	if err := checkExistsState(currency.StateKeyAccount(fact.sender), getState); err != nil {
+		return nil, err
+	}
+
+	// check existence of documents in sender document inventory
+	docs := newOwnerDocumentPages()
+	for i := range fact.items {
+		if err := docs.load(fact.sender, fact.items[i].DocumentId(), getState); err != nil {
+			return nil, err
+		}
+
+		if !docs.exists(fact.sender, fact.items[i].DocumentId()) {
+			return nil, operation.NewBaseReasonError(
+				"document not found in sender document inventory, %v", fact.items[i].DocumentId())
+		}
+	}
+
+	if required, err := opp.calculateItemsFee(); err != nil {
+		return nil, operation.NewBaseReasonError("failed to calculate fee: %w", err)
+	} else if sb, err := CheckDocumentOwnerEnoughBalance(fact.sender, required, getState); err != nil {
+		return nil, err
+	} else {
+		opp.required = required
+		opp.sb = sb
+	}
+
+	ns := make([]*UpdateDocumentSignersItemProcessor, len(fact.items))
+	for i := range fact.items {
+		c := &UpdateDocumentSignersItemProcessor{
+			cp:     opp.cp,
+			sender: fact.sender,
+			height: opp.height,
+			h:      opp.Hash(),
+			item:   fact.items[i],
+		}
+		if err := c.PreProcess(getState, setState); err != nil {
+			return nil, operation.NewBaseReasonErrorFromError(err)
+		}
+
+		ns[i] = c
+	}
+
+	// check fact sign
+	if err := checkFactSignsByState(fact.sender, opp.Signs(), getState); err != nil {
+		return nil, operation.NewBaseReasonError("invalid signing: %w", err)
+	}
+
+	pending := newPendingDocumentPages()
+	for i := range fact.items {
+		it := fact.items[i]
+		for j := range it.Adds() {
+			if err := pending.load(it.Adds()[j], it.DocumentId(), getState); err != nil {
+				return nil, err
+			}
+		}
+
+		for j := range it.Removes() {
+			if err := pending.load(it.Removes()[j], it.DocumentId(), getState); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	opp.ns = ns
+	opp.pending = pending
+
+	return opp, nil
+}
+
+func (opp *UpdateDocumentSignersProcessor) Process( // nolint:dupl
+	getState func(key string) (state.State, bool, error),
+	setState func(valuehash.Hash, ...state.State) error,
+) error {
+	fact := opp.Fact().(UpdateDocumentSignersFact)
+
+	var sts []state.State // nolint:prealloc
+
+	for i := range opp.ns {
+		s, err := opp.ns[i].Process(getState, setState)
+		if err != nil {
+			return operation.NewBaseReasonError("failed to process update document signers item: %w", err)
+		}
+		sts = append(sts, s...)
+
+		// added signers have to sign document and removed signers do not
+		c := opp.ns[i]
+		for j := range c.item.Adds() {
+			if err := opp.pending.set(c.item.Adds()[j], c.info); err != nil {
+				return err
+			}
+		}
+
+		for j := range c.item.Removes() {
+			if err := opp.pending.discard(c.item.Removes()[j], c.info.Index()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if pts, err := opp.pending.states(); err != nil {
+		return err
+	} else {
+		sts = append(sts, pts...)
+	}
+
+	for k := range opp.required {
+		rq := opp.required[k]
+		sts = append(sts, opp.sb[k].Sub(rq[0]).AddFee(rq[1]))
+	}
+
+	return setState(fact.Hash(), sts...)
+}
+
+func (opp *UpdateDocumentSignersProcessor) lockedKeys() []string {
+	return opp.pending.keys()
+}
+
+func (opp *UpdateDocumentSignersProcessor) calculateItemsFee() (map[currency.CurrencyID][2]currency.Big, error) {
+	fact := opp.Fact().(UpdateDocumentSignersFact)
+
+	cids := make([]currency.CurrencyID, len(fact.items))
+	for i := range fact.items {
+		cids[i] = fact.items[i].Currency()
+	}
+
+	return CalculateFlatItemsFee(opp.cp, cids)
+}
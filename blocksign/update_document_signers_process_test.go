@@ -0,0 +1,355 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+	"github.com/spikeekips/mitum/util"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/xerrors"
+)
+
+type testUpdateDocumentSignersOperations struct {
+	baseTestOperationProcessor
+	cid      currency.CurrencyID
+	docid    currency.Big
+	fh       FileHash
+	fee      currency.Big
+	signcode string
+	title    string
+	size     currency.Big
+}
+
+func (t *testUpdateDocumentSignersOperations) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docid = currency.NewBig(0)
+	t.fh = MustFileHash("ABCD")
+	t.fee = currency.NewBig(3)
+	t.signcode = "user0"
+	t.title = "title01"
+	t.size = currency.NewBig(555)
+}
+
+func (t *testUpdateDocumentSignersOperations) processor(cp *currency.CurrencyPool, pool *storage.Statepool) prprocessor.OperationProcessor {
+	copr, err := NewOperationProcessor(cp).
+		SetProcessor(UpdateDocumentSigners{}, NewUpdateDocumentSignersProcessor(cp))
+	t.NoError(err)
+
+	if pool == nil {
+		return copr
+	}
+
+	return copr.New(pool)
+}
+
+func (t *testUpdateDocumentSignersOperations) newUpdateDocumentSigners(
+	sender base.Address,
+	keys []key.Privatekey,
+	items []UpdateDocumentSignersItem,
+) UpdateDocumentSigners {
+	token := util.UUID().Bytes()
+	fact := NewUpdateDocumentSignersFact(token, sender, items)
+
+	var fs []operation.FactSign
+	for _, pk := range keys {
+		sig, err := operation.NewFactSignature(pk, fact, nil)
+		t.NoError(err)
+
+		fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+	}
+
+	op, err := NewUpdateDocumentSigners(fact, fs, "")
+	t.NoError(err)
+
+	t.NoError(op.IsValid(nil))
+
+	return op
+}
+
+func (t *testUpdateDocumentSignersOperations) newTestDocumentData(ca base.Address, signers []DocSign) DocumentData {
+	info := DocInfo{idx: t.docid, filehash: t.fh}
+
+	return NewDocumentData(info, ca, t.signcode, t.title, t.size, signers)
+}
+
+func (t *testUpdateDocumentSignersOperations) newTestBalance() []currency.Amount {
+	return []currency.Amount{currency.NewAmount(currency.NewBig(33), t.cid)}
+}
+
+func (t *testUpdateDocumentSignersOperations) updatedDocumentData(pool *storage.Statepool) DocumentData {
+	var dds state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == StateKeyDocumentData(DocId(t.docid)) {
+			dds = stu.GetState()
+		}
+	}
+	t.NotNil(dds)
+
+	dd, err := StateDocumentDataValue(dds)
+	t.NoError(err)
+
+	return dd
+}
+
+func (t *testUpdateDocumentSignersOperations) process(
+	ca *account, dd DocumentData, item UpdateDocumentSignersItem, sts ...[]state.State,
+) (*storage.Statepool, error) {
+	sts = append(sts, t.newStateDocument(ca.Address, dd))
+	pool, _ := t.statepool(sts...)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	return pool, opr.Process(t.newUpdateDocumentSigners(ca.Address, ca.Privs(), []UpdateDocumentSignersItem{item}))
+}
+
+func (t *testUpdateDocumentSignersOperations) TestNormalCase() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance) // creator, owner
+	ga, stb := t.newAccount(true, balance) // remaining signer
+	ra, stc := t.newAccount(true, balance) // removed signer
+	na, std := t.newAccount(true, balance) // new signer
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(ga.Address, "user1", true),
+		NewDocSign(ra.Address, "user2", false),
+	})
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user3"}, nil, []base.Address{ra.Address}, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc, std)
+	t.NoError(err)
+
+	ndd := t.updatedDocumentData(pool)
+	t.Equal(2, len(ndd.Signers()))
+
+	ds, found := ndd.Signer(ga.Address)
+	t.True(found)
+	t.True(ds.Signed())
+
+	_, found = ndd.Signer(ra.Address)
+	t.False(found)
+
+	ds, found = ndd.Signer(na.Address)
+	t.True(found)
+	t.Equal(DocSignPending, ds.Status())
+	t.Equal(SignCode("user3").Commitment().String(), ds.Signcode())
+	t.True(SignCode(ds.Signcode()).Verify("user3"))
+
+	var sb state.State
+	for _, stu := range pool.Updates() {
+		if stu.Key() == currency.StateKeyBalance(ca.Address, t.cid) {
+			sb = stu.GetState()
+		}
+	}
+	t.NotNil(sb)
+
+	sba, _ := currency.StateBalanceValue(sb)
+	t.True(sba.Big().Equal(balance[0].Big().Sub(t.fee)))
+}
+
+func (t *testUpdateDocumentSignersOperations) TestPendingDocuments() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance) // creator, owner
+	ga, stb := t.newAccount(true, balance) // remaining signer
+	ra, stc := t.newAccount(true, balance) // removed signer
+	na, std := t.newAccount(true, balance) // new signer
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(ga.Address, "user1", false),
+		NewDocSign(ra.Address, "user2", false),
+	})
+
+	other := MustNewDocInfo(1, MustFileHash("EFGH"))
+	psts := []state.State{
+		t.newStatePendingDocuments(ga.Address, currency.ZeroBig, []DocInfo{dd.Info()}),
+		t.newStatePendingDocuments(ra.Address, currency.ZeroBig, []DocInfo{dd.Info(), other}),
+	}
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user3"}, nil, []base.Address{ra.Address}, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc, std, psts)
+	t.NoError(err)
+
+	// NOTE pending document inventory of remaining signer is not changed
+	_, found := t.updatedPendingDocuments(pool, ga.Address)
+	t.False(found)
+
+	inv, found := t.updatedPendingDocuments(pool, ra.Address)
+	t.True(found)
+	t.Equal(1, len(inv.Documents()))
+	t.True(inv.Documents()[0].Equal(other))
+
+	inv, found = t.updatedPendingDocuments(pool, na.Address)
+	t.True(found)
+	t.Equal(1, len(inv.Documents()))
+	t.True(inv.Documents()[0].Equal(dd.Info()))
+}
+
+func (t *testUpdateDocumentSignersOperations) TestRemoveSignedSigner() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	ra, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(ga.Address, "user1", false),
+		NewDocSign(ra.Address, "user2", true),
+	})
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docid, ca.Address, nil, nil, nil, []base.Address{ra.Address}, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb, stc)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "removed signer already signed document")
+}
+
+func (t *testUpdateDocumentSignersOperations) TestRemoveUnknownSigner() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	ra, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ga.Address, "user1", false)})
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docid, ca.Address, nil, nil, nil, []base.Address{ra.Address}, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb, stc)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "removed signer not found in document signers")
+}
+
+func (t *testUpdateDocumentSignersOperations) TestAddNotExistingAccount() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	na, _ := t.newAccount(false, nil)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ga.Address, "user1", false)})
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user2"}, nil, nil, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "signer account not found")
+}
+
+func (t *testUpdateDocumentSignersOperations) TestAddExistingSigner() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ga.Address, "user1", false)})
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{ga.Address}, []string{"user1"}, nil, nil, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "added signer already in document signers")
+}
+
+func (t *testUpdateDocumentSignersOperations) TestFullySignedDocument() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	na, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ga.Address, "user1", true)})
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user2"}, nil, nil, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb, stc)
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "signers of document can not be updated")
+}
+
+func (t *testUpdateDocumentSignersOperations) TestQuorum() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	ra, stc := t.newAccount(true, balance)
+	na, std := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{
+		NewDocSign(ga.Address, "user1", false).WithWeight(50),
+		NewDocSign(ra.Address, "user2", false).WithWeight(50),
+	}).WithThreshold(100)
+
+	// weights required
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user3"}, nil, []base.Address{ra.Address}, t.cid)
+
+	_, err := t.process(ca, dd, item, sta, stb, stc, std)
+	t.Contains(err.Error(), "weights of added signers required")
+
+	// under threshold
+	item = NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user3"}, []uint{30}, []base.Address{ra.Address}, t.cid)
+
+	_, err = t.process(ca, dd, item, sta, stb, stc, std)
+	t.Contains(err.Error(), "sum of weights under threshold")
+
+	item = NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user3"}, []uint{50}, []base.Address{ra.Address}, t.cid)
+
+	pool, err := t.process(ca, dd, item, sta, stb, stc, std)
+	t.NoError(err)
+
+	ds, found := t.updatedDocumentData(pool).Signer(na.Address)
+	t.True(found)
+	t.Equal(uint(50), ds.Weight())
+}
+
+func (t *testUpdateDocumentSignersOperations) TestSameDocumentInProposal() {
+	balance := t.newTestBalance()
+	ca, sta := t.newAccount(true, balance)
+	ga, stb := t.newAccount(true, balance)
+	na, stc := t.newAccount(true, balance)
+
+	dd := t.newTestDocumentData(ca.Address, []DocSign{NewDocSign(ga.Address, "user1", false)})
+
+	sts := t.newStateDocument(ca.Address, dd)
+	pool, _ := t.statepool(sta, stb, stc, sts)
+
+	cp := currency.NewCurrencyPool()
+	t.NoError(cp.Set(t.newCurrencyDesignState(t.cid, currency.NewBig(99), NewTestAddress(), currency.NewFixedFeeer(ca.Address, t.fee))))
+
+	opr := t.processor(cp, pool)
+
+	items := []UpdateDocumentSignersItem{NewUpdateDocumentSignersItemSingleFile(
+		t.docid, ca.Address, []base.Address{na.Address}, []string{"user2"}, nil, nil, t.cid)}
+	t.NoError(opr.Process(t.newUpdateDocumentSigners(ca.Address, ca.Privs(), items)))
+
+	err := opr.Process(t.newUpdateDocumentSigners(ca.Address, ca.Privs(), items))
+
+	var oper operation.ReasonError
+	t.True(xerrors.As(err, &oper))
+	t.Contains(err.Error(), "violates only one sender")
+}
+
+func TestUpdateDocumentSignersOperations(t *testing.T) {
+	suite.Run(t, new(testUpdateDocumentSignersOperations))
+}
@@ -0,0 +1,46 @@
+package blocksign
+
+import (
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/util/hint"
+)
+
+var (
+	UpdateSignersItemSingleDocumentType   = hint.Type("mitum-blocksign-update-signers-item-single-document")
+	UpdateSignersItemSingleDocumentHint   = hint.NewHint(UpdateSignersItemSingleDocumentType, "v0.0.1")
+	UpdateSignersItemSingleDocumentHinter = BaseUpdateDocumentSignersItem{hint: UpdateSignersItemSingleDocumentHint}
+)
+
+type UpdateDocumentSignersItemSingleFile struct {
+	BaseUpdateDocumentSignersItem
+}
+
+func NewUpdateDocumentSignersItemSingleFile(
+	docId currency.Big,
+	owner base.Address,
+	adds []base.Address,
+	signcodes []string,
+	weights []uint,
+	removes []base.Address,
+	cid currency.CurrencyID,
+) UpdateDocumentSignersItemSingleFile {
+	return UpdateDocumentSignersItemSingleFile{
+		BaseUpdateDocumentSignersItem: NewBaseUpdateDocumentSignersItem(
+			UpdateSignersItemSingleDocumentHint, docId, owner, adds, signcodes, weights, removes, cid),
+	}
+}
+
+func (it UpdateDocumentSignersItemSingleFile) IsValid([]byte) error {
+	if err := it.BaseUpdateDocumentSignersItem.IsValid(nil); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (it UpdateDocumentSignersItemSingleFile) Rebuild() UpdateDocumentSignersItem {
+	it.BaseUpdateDocumentSignersItem = it.BaseUpdateDocumentSignersItem.Rebuild().(BaseUpdateDocumentSignersItem)
+
+	return it
+}
@@ -0,0 +1,179 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type testUpdateDocumentSignersItemSingleFile struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) TestEmpty() {
+	s := MustAddress(util.UUID().String())
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docId, s, nil, nil, nil, nil, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "empty signers to add or remove")
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) TestWrongSigncodes() {
+	s := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, nil, nil, nil, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "length of signcodes array is not same")
+
+	item = NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, []string{""}, nil, nil, t.cid)
+
+	err = item.IsValid(nil)
+	t.Contains(err.Error(), "empty signcode of added signer")
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) TestWrongWeights() {
+	s := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, []string{"user1"}, []uint{1, 2}, nil, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "length of weights array is not same")
+
+	item = NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, []string{"user1"}, []uint{101}, nil, t.cid)
+
+	err = item.IsValid(nil)
+	t.Contains(err.Error(), "invalid signer weight")
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) TestDuplicatedSigner() {
+	s := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+
+	item := NewUpdateDocumentSignersItemSingleFile(
+		t.docId, s, []base.Address{a, a}, []string{"user1", "user2"}, nil, nil, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "duplicated signer")
+
+	item = NewUpdateDocumentSignersItemSingleFile(
+		t.docId, s, []base.Address{a}, []string{"user1"}, nil, []base.Address{a}, t.cid)
+
+	err = item.IsValid(nil)
+	t.Contains(err.Error(), "duplicated signer")
+}
+
+func (t *testUpdateDocumentSignersItemSingleFile) TestAddOwner() {
+	s := MustAddress(util.UUID().String())
+
+	item := NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{s}, []string{"user1"}, nil, nil, t.cid)
+
+	err := item.IsValid(nil)
+	t.Contains(err.Error(), "added signer is same with owner")
+}
+
+func TestUpdateDocumentSignersItemSingleFile(t *testing.T) {
+	suite.Run(t, new(testUpdateDocumentSignersItemSingleFile))
+}
+
+func testUpdateDocumentSignersItemSingleFileEncode(enc encoder.Encoder) suite.TestingSuite {
+	t := new(baseTestOperationEncode)
+
+	docId0 := currency.NewBig(0)
+	docId1 := currency.NewBig(1)
+	t.enc = enc
+	t.newObject = func() interface{} {
+		s := MustAddress(util.UUID().String())
+		a := MustAddress(util.UUID().String())
+		r := MustAddress(util.UUID().String())
+
+		token := util.UUID().Bytes()
+		items := []UpdateDocumentSignersItem{
+			NewUpdateDocumentSignersItemSingleFile(
+				docId0, s, []base.Address{a}, []string{"user1"}, []uint{30}, []base.Address{r}, currency.CurrencyID("SHOWME")),
+			NewUpdateDocumentSignersItemSingleFile(
+				docId1, s, nil, nil, nil, []base.Address{r}, currency.CurrencyID("FINDME")),
+		}
+		fact := NewUpdateDocumentSignersFact(token, s, items)
+
+		var fs []operation.FactSign
+
+		for _, pk := range []key.Privatekey{
+			key.MustNewBTCPrivatekey(),
+			key.MustNewBTCPrivatekey(),
+		} {
+			sig, err := operation.NewFactSignature(pk, fact, nil)
+			t.NoError(err)
+
+			fs = append(fs, operation.NewBaseFactSign(pk.Publickey(), sig))
+		}
+
+		op, err := NewUpdateDocumentSigners(fact, fs, util.UUID().String())
+		t.NoError(err)
+
+		return op
+	}
+
+	t.compare = func(a, b interface{}) {
+		ta := a.(UpdateDocumentSigners)
+		tb := b.(UpdateDocumentSigners)
+
+		t.Equal(ta.Memo, tb.Memo)
+
+		fact := ta.Fact().(UpdateDocumentSignersFact)
+		ufact := tb.Fact().(UpdateDocumentSignersFact)
+
+		t.True(fact.sender.Equal(ufact.sender))
+		t.Equal(len(fact.Items()), len(ufact.Items()))
+
+		for i := range fact.Items() {
+			a := fact.Items()[i]
+			b := ufact.Items()[i]
+			t.True(a.DocumentId().Equal(b.DocumentId()))
+			t.True(a.Owner().Equal(b.Owner()))
+			t.Equal(len(a.Adds()), len(b.Adds()))
+			for j := range a.Adds() {
+				t.True(a.Adds()[j].Equal(b.Adds()[j]))
+			}
+			t.Equal(a.Signcodes(), b.Signcodes())
+			t.Equal(len(a.Weights()), len(b.Weights()))
+			for j := range a.Weights() {
+				t.Equal(a.Weights()[j], b.Weights()[j])
+			}
+			t.Equal(len(a.Removes()), len(b.Removes()))
+			for j := range a.Removes() {
+				t.True(a.Removes()[j].Equal(b.Removes()[j]))
+			}
+			t.Equal(a.Currency(), (b.Currency()))
+			t.Equal(a.Bytes(), b.Bytes())
+		}
+	}
+
+	return t
+}
+
+func TestUpdateDocumentSignersItemSingleFileEncodeJSON(t *testing.T) {
+	suite.Run(t, testUpdateDocumentSignersItemSingleFileEncode(jsonenc.NewEncoder()))
+}
+
+func TestUpdateDocumentSignersItemSingleFileEncodeBSON(t *testing.T) {
+	suite.Run(t, testUpdateDocumentSignersItemSingleFileEncode(bsonenc.NewEncoder()))
+}
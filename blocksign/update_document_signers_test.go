@@ -0,0 +1,90 @@
+package blocksign
+
+import (
+	"testing"
+
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+)
+
+type testUpdateDocumentSigners struct {
+	suite.Suite
+	cid   currency.CurrencyID
+	docId currency.Big
+}
+
+func (t *testUpdateDocumentSigners) SetupSuite() {
+	t.cid = currency.CurrencyID("SHOWME")
+	t.docId = currency.NewBig(0)
+}
+
+func (t *testUpdateDocumentSigners) newOperation(sender base.Address, items []UpdateDocumentSignersItem) UpdateDocumentSigners {
+	token := util.UUID().Bytes()
+	fact := NewUpdateDocumentSignersFact(token, sender, items)
+
+	pk := key.MustNewBTCPrivatekey()
+	sig, err := operation.NewFactSignature(pk, fact, nil)
+	t.NoError(err)
+
+	fs := []operation.FactSign{operation.NewBaseFactSign(pk.Publickey(), sig)}
+
+	op, err := NewUpdateDocumentSigners(fact, fs, "")
+	t.NoError(err)
+
+	return op
+}
+
+func (t *testUpdateDocumentSigners) TestNew() {
+	s := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+	r := MustAddress(util.UUID().String())
+
+	items := []UpdateDocumentSignersItem{NewUpdateDocumentSignersItemSingleFile(
+		t.docId, s, []base.Address{a}, []string{"user1"}, nil, []base.Address{r}, t.cid,
+	)}
+	op := t.newOperation(s, items)
+
+	t.NoError(op.IsValid(nil))
+
+	t.Implements((*base.Fact)(nil), op.Fact())
+	t.Implements((*operation.Operation)(nil), op)
+
+	as, err := op.Fact().(UpdateDocumentSignersFact).Addresses()
+	t.NoError(err)
+	t.Equal(3, len(as))
+}
+
+func (t *testUpdateDocumentSigners) TestDuplicatedDocuments() {
+	s := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+
+	items := []UpdateDocumentSignersItem{
+		NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, []string{"user1"}, nil, nil, t.cid),
+		NewUpdateDocumentSignersItemSingleFile(t.docId, s, []base.Address{a}, []string{"user1"}, nil, nil, t.cid),
+	}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "duplicated document found")
+}
+
+func (t *testUpdateDocumentSigners) TestSenderNotOwner() {
+	s := MustAddress(util.UUID().String())
+	o := MustAddress(util.UUID().String())
+	a := MustAddress(util.UUID().String())
+
+	items := []UpdateDocumentSignersItem{
+		NewUpdateDocumentSignersItemSingleFile(t.docId, o, []base.Address{a}, []string{"user1"}, nil, nil, t.cid),
+	}
+
+	err := t.newOperation(s, items).IsValid(nil)
+	t.Contains(err.Error(), "sender is not owner of document")
+}
+
+func TestUpdateDocumentSigners(t *testing.T) {
+	suite.Run(t, new(testUpdateDocumentSigners))
+}
@@ -156,6 +156,18 @@ func AttachProposalProcessor(
 		return nil, err
 	} else if _, err := opr.SetProcessor(blocksign.SignDocuments{}, blocksign.NewSignDocumentsProcessor(cp)); err != nil {
 		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.RejectDocuments{}, blocksign.NewRejectDocumentsProcessor(cp)); err != nil {
+		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.RevokeSignDocuments{}, blocksign.NewRevokeSignDocumentsProcessor(cp)); err != nil {
+		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.TransferDocuments{}, blocksign.NewTransferDocumentsProcessor(cp)); err != nil {
+		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.UpdateDocumentSigners{}, blocksign.NewUpdateDocumentSignersProcessor(cp)); err != nil {
+		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.ReviseDocuments{}, blocksign.NewReviseDocumentsProcessor(cp)); err != nil {
+		return nil, err
+	} else if _, err := opr.SetProcessor(blocksign.CancelDocuments{}, blocksign.NewCancelDocumentsProcessor(cp)); err != nil {
+		return nil, err
 	}
 
 	threshold, err := base.NewThreshold(uint(len(suffrage.Nodes())), policy.ThresholdRatio())
@@ -185,6 +197,12 @@ func AttachProposalProcessor(
 		return nil, err
 	}
 
+	if _, err := opr.SetProcessor(blocksign.DocumentPolicyUpdater{},
+		blocksign.NewDocumentPolicyUpdaterProcessor(pubs, threshold),
+	); err != nil {
+		return nil, err
+	}
+
 	return opr, nil
 }
 
@@ -210,6 +228,13 @@ func InitializeProposalProcessor(ctx context.Context, opr *blocksign.OperationPr
 		currency.CurrencyRegister{},
 		blocksign.CreateDocuments{},
 		blocksign.SignDocuments{},
+		blocksign.RejectDocuments{},
+		blocksign.RevokeSignDocuments{},
+		blocksign.TransferDocuments{},
+		blocksign.UpdateDocumentSigners{},
+		blocksign.ReviseDocuments{},
+		blocksign.CancelDocuments{},
+		blocksign.DocumentPolicyUpdater{},
 	} {
 		if err := oprs.Add(hinter, opr); err != nil {
 			return ctx, err
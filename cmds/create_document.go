@@ -2,6 +2,8 @@ package cmds
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 
@@ -13,6 +15,7 @@ import (
 
 	"github.com/soonkuk/mitum-blocksign/blocksign"
 	currencycmds "github.com/spikeekips/mitum-currency/cmds"
+	"github.com/spikeekips/mitum-currency/currency"
 	mitumcmds "github.com/spikeekips/mitum/launch/cmds"
 )
 
@@ -20,17 +23,28 @@ type CreateDocumentCommand struct {
 	*BaseCommand
 	currencycmds.OperationFlags
 	Sender     currencycmds.AddressFlag    `arg:"" name:"sender" help:"sender address" required:""`
-	FileHash   string                      `arg:"" name:"filehash" help:"filehash" required:""`
+	FileHash   FileHashFlag                `arg:"" name:"filehash" help:"filehash (ex: \"sha256:<hex digest>\"); \"auto\" to be computed from --file" required:""`
 	Signcode   string                      `arg:"" name:"signcode" help:"signcode" required:""`
-	DocumentId currencycmds.BigFlag        `arg:"" name:"documentid" help:"document id" required:""`
-	Title      string                      `arg:"" name:"title" help:"title" required:""`
-	Size       currencycmds.BigFlag        `arg:"" name:"size" help:"size" required:""`
+	DocumentId DocumentIdFlag              `arg:"" name:"documentid" help:"document id; \"auto\" to be assigned by chain" required:""`
+	Title      string                      `arg:"" name:"title" help:"title; \"auto\" to be file name of --file" required:""`
+	Size       SizeFlag                    `arg:"" name:"size" help:"size; \"auto\" to be computed from --file" required:""`
 	Currency   currencycmds.CurrencyIDFlag `arg:"" name:"currency" help:"currency id" required:""`
 	Signers    []DocSignFlag               `name:"signers" help:"signers for document (ex: \"<address>,<signcode>\")" sep:"@"`
+	Expiry     HeightFlag                  `name:"expiry" help:"height, after which document can not be signed" optional:""`
+	Sequential bool                        `name:"sequential" help:"signers sign in the order of signers" optional:""`
+	Weights    []uint                      `name:"weights" help:"weights of signers in the order of signers" optional:""`
+	Threshold  uint                        `name:"threshold" help:"sum of signed weights for document to be fully signed" optional:""`
+	File       string                      `name:"file" help:"local file of document to compute filehash and size" optional:""`
+	HashAlgo   string                      `name:"hash-algorithm" help:"hash algorithm for --file; sha256, sha3-256 or blake2b" default:"sha256"`
+	Metadata   map[string]string           `name:"metadata" help:"metadata of document (ex: \"mimetype=application/pdf\", \"tags=contract,hr\")" mapsep:"none" optional:""`
+	Attachment []DocFileFlag               `name:"attachment" help:"attached file of document (ex: \"<filehash>,<size>,<name>\")" sep:"@" optional:""`
+	AttachFile []string                    `name:"attachment-file" help:"local attached file of document to compute filehash and size" optional:""`
+	Sponsored  bool                        `name:"sponsored" help:"escrow the signing fees of signers from sender balance" optional:""`
 	Seal       mitumcmds.FileLoad          `help:"seal" optional:""`
 	sender     base.Address
 	signers    []base.Address
 	signcodes  []string
+	files      []blocksign.DocFile
 }
 
 func NewCreateDocumentCommand() CreateDocumentCommand {
@@ -88,17 +102,153 @@ func (cmd *CreateDocumentCommand) parseFlags() error {
 				return errors.Errorf("invalid sender format, %q: %q", cmd.Signers[i].String(), err)
 			} else {
 				signers[i] = a
-				signcodes[i] = cmd.Signers[i].SC
-
+				// NOTE only the commitment of signcode is published
+				signcodes[i] = blocksign.SignCode(cmd.Signers[i].SC).Commitment().String()
 			}
 		}
 		cmd.signers = signers
 		cmd.signcodes = signcodes
 	}
 
+	if err := cmd.parseFile(); err != nil {
+		return err
+	}
+
+	return cmd.parseAttachments()
+}
+
+func (cmd *CreateDocumentCommand) parseFile() error {
+	if len(cmd.File) < 1 {
+		switch {
+		case len(cmd.FileHash.FH) < 1:
+			return errors.Errorf("filehash is \"auto\", but --file is not given")
+		case cmd.Size.IsAuto():
+			return errors.Errorf("size is \"auto\", but --file is not given")
+		}
+
+		return nil
+	}
+
+	fh, size, err := hashLocalFile(cmd.File, blocksign.FileHashAlgorithm(cmd.HashAlgo))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(cmd.FileHash.FH) < 1:
+		cmd.FileHash.FH = fh
+	case !cmd.FileHash.FH.Equal(fh):
+		return errors.Errorf("filehash does not match with file, %q != %q", cmd.FileHash.FH, fh)
+	}
+
+	switch {
+	case cmd.Size.IsAuto():
+		cmd.Size.Big = size
+	case !cmd.Size.Big.Equal(size):
+		return errors.Errorf("size does not match with file, %v != %v", cmd.Size.Big, size)
+	}
+
+	if cmd.Title == "auto" {
+		cmd.Title = filepath.Base(cmd.File)
+	}
+
 	return nil
 }
 
+// parseAttachments collects the files of document; the document file of
+// positional arguments becomes the first file.
+func (cmd *CreateDocumentCommand) parseAttachments() error {
+	if len(cmd.Attachment) < 1 && len(cmd.AttachFile) < 1 {
+		cmd.files = nil
+
+		return nil
+	}
+
+	name := cmd.Title
+	if len(cmd.File) > 0 {
+		name = filepath.Base(cmd.File)
+	}
+
+	files := []blocksign.DocFile{blocksign.NewDocFile(cmd.FileHash.FH, name, cmd.Size.Big)}
+	for i := range cmd.Attachment {
+		files = append(files, cmd.Attachment[i].DocFile())
+	}
+
+	for i := range cmd.AttachFile {
+		fh, size, err := hashLocalFile(cmd.AttachFile[i], blocksign.FileHashAlgorithm(cmd.HashAlgo))
+		if err != nil {
+			return err
+		}
+
+		files = append(files, blocksign.NewDocFile(fh, filepath.Base(cmd.AttachFile[i]), size))
+	}
+
+	cmd.files = files
+
+	return nil
+}
+
+func (cmd *CreateDocumentCommand) createItem() blocksign.CreateDocumentsItem {
+	if len(cmd.files) > 0 {
+		item := blocksign.NewCreateDocumentsItemMultiFiles(
+			cmd.DocumentId.ID,
+			cmd.Signcode,
+			cmd.Title,
+			cmd.files,
+			cmd.signers,
+			cmd.signcodes,
+			cmd.Currency.CID,
+		).WithExpiry(cmd.Expiry.Height())
+
+		if cmd.Sequential {
+			item = item.WithSigningMode(blocksign.SigningSequential)
+		}
+
+		if cmd.Threshold > 0 || len(cmd.Weights) > 0 {
+			item = item.WithQuorum(cmd.Weights, cmd.Threshold)
+		}
+
+		if len(cmd.Metadata) > 0 {
+			item = item.WithMetadata(blocksign.DocumentMetadata(cmd.Metadata))
+		}
+
+		if cmd.Sponsored {
+			item = item.WithSponsored(true)
+		}
+
+		return item
+	}
+
+	item := blocksign.NewCreateDocumentsItemSingleFile(
+		cmd.FileHash.FH,
+		cmd.DocumentId.ID,
+		cmd.Signcode,
+		cmd.Title,
+		cmd.Size.Big,
+		cmd.signers,
+		cmd.signcodes,
+		cmd.Currency.CID,
+	).WithExpiry(cmd.Expiry.Height())
+
+	if cmd.Sequential {
+		item = item.WithSigningMode(blocksign.SigningSequential)
+	}
+
+	if cmd.Threshold > 0 || len(cmd.Weights) > 0 {
+		item = item.WithQuorum(cmd.Weights, cmd.Threshold)
+	}
+
+	if len(cmd.Metadata) > 0 {
+		item = item.WithMetadata(blocksign.DocumentMetadata(cmd.Metadata))
+	}
+
+	if cmd.Sponsored {
+		item = item.WithSponsored(true)
+	}
+
+	return item
+}
+
 func (cmd *CreateDocumentCommand) createOperation() (operation.Operation, error) { // nolint:dupl
 	var items []blocksign.CreateDocumentsItem
 	if i, err := loadOperations(cmd.Seal.Bytes(), cmd.NetworkID.NetworkID()); err != nil {
@@ -111,17 +261,7 @@ func (cmd *CreateDocumentCommand) createOperation() (operation.Operation, error)
 		}
 	}
 
-	item := blocksign.NewCreateDocumentsItemSingleFile(
-		blocksign.FileHash(cmd.FileHash),
-		cmd.DocumentId.Big,
-		cmd.Signcode,
-		cmd.Title,
-		cmd.Size.Big,
-		cmd.signers,
-		cmd.signcodes,
-		cmd.Currency.CID,
-	)
-
+	item := cmd.createItem()
 	if err := item.IsValid(nil); err != nil {
 		return nil, err
 	} else {
@@ -144,6 +284,24 @@ func (cmd *CreateDocumentCommand) createOperation() (operation.Operation, error)
 	}
 }
 
+// hashLocalFile computes filehash and size of local file.
+func hashLocalFile(p string, algo blocksign.FileHashAlgorithm) (blocksign.FileHash, currency.Big, error) {
+	f, err := os.Open(filepath.Clean(p))
+	if err != nil {
+		return blocksign.FileHash(""), currency.ZeroBig, errors.Wrap(err, "failed to open file")
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	fh, n, err := blocksign.NewFileHashFromReader(algo, f)
+	if err != nil {
+		return blocksign.FileHash(""), currency.ZeroBig, err
+	}
+
+	return fh, currency.NewBig(n), nil
+}
+
 func loadSeal(b []byte, networkID base.NetworkID) (seal.Seal, error) {
 	if len(bytes.TrimSpace(b)) < 1 {
 		return nil, errors.Errorf("empty input")
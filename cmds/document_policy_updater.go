@@ -0,0 +1,92 @@
+package cmds
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	currencycmds "github.com/spikeekips/mitum-currency/cmds"
+	mitumcmds "github.com/spikeekips/mitum/launch/cmds"
+)
+
+type DocumentPolicyUpdaterCommand struct {
+	*BaseCommand
+	currencycmds.OperationFlags
+	UniqueFileHash bool               `name:"unique-filehash" help:"reject filehash already registered by other document" optional:""`                  // revive:disable-line:line-length-limit
+	Fees           []DocumentFeeFlag  `name:"fee" help:"document fee of currency (ex: \"<currency id>,<size unit>,<signer fee>\")" sep:"@" optional:""` // revive:disable-line:line-length-limit
+	Seal           mitumcmds.FileLoad `help:"seal" optional:""`
+	po             blocksign.DocumentPolicy
+}
+
+func NewDocumentPolicyUpdaterCommand() DocumentPolicyUpdaterCommand {
+	return DocumentPolicyUpdaterCommand{
+		BaseCommand: NewBaseCommand("document-policy-updater-operation"),
+	}
+}
+
+func (cmd *DocumentPolicyUpdaterCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return errors.Errorf("failed to initialize command: %q", err)
+	}
+
+	if err := cmd.parseFlags(); err != nil {
+		return err
+	}
+
+	var op operation.Operation
+	if o, err := cmd.createOperation(); err != nil {
+		return err
+	} else {
+		op = o
+	}
+
+	if sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.NetworkID(),
+		op,
+	); err != nil {
+		return err
+	} else {
+		currencycmds.PrettyPrint(cmd.Out, cmd.Pretty, sl)
+	}
+
+	return nil
+}
+
+func (cmd *DocumentPolicyUpdaterCommand) parseFlags() error {
+	if err := cmd.OperationFlags.IsValid(nil); err != nil {
+		return err
+	}
+
+	po := blocksign.NewDocumentPolicy(cmd.UniqueFileHash)
+	for i := range cmd.Fees {
+		if _, found := po.FeePolicies()[cmd.Fees[i].CID]; found {
+			return errors.Errorf("duplicated document fee of %q", cmd.Fees[i].CID)
+		}
+
+		po = po.WithFeePolicy(cmd.Fees[i].CID, cmd.Fees[i].PO)
+	}
+	cmd.po = po
+
+	return cmd.po.IsValid(nil)
+}
+
+func (cmd *DocumentPolicyUpdaterCommand) createOperation() (operation.Operation, error) {
+	fact := blocksign.NewDocumentPolicyUpdaterFact([]byte(cmd.Token), cmd.po)
+
+	var fs []operation.FactSign
+	if sig, err := operation.NewFactSignature(cmd.Privatekey, fact, cmd.NetworkID.NetworkID()); err != nil {
+		return nil, err
+	} else {
+		fs = append(fs, operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig))
+	}
+
+	if op, err := blocksign.NewDocumentPolicyUpdater(fact, fs, cmd.Memo); err != nil {
+		return nil, errors.Wrap(err, "failed to create document-policy-updater operation")
+	} else {
+		return op, nil
+	}
+}
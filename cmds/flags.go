@@ -5,18 +5,27 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/soonkuk/mitum-blocksign/blocksign"
+	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/util/encoder"
 	"github.com/spikeekips/mitum/util/hint"
 )
 
+// FileHashFlag parses filehash; "auto" leaves filehash to be computed from
+// local file.
 type FileHashFlag struct {
 	FH blocksign.FileHash
 }
 
 func (v *FileHashFlag) UnmarshalText(b []byte) error {
-	fh := blocksign.FileHash(string(b))
-	if err := fh.IsValid(nil); err != nil {
+	if strings.TrimSpace(string(b)) == "auto" {
+		v.FH = blocksign.FileHash("")
+
+		return nil
+	}
+
+	fh, err := blocksign.ParseFileHash(string(b))
+	if err != nil {
 		return err
 	}
 	v.FH = fh
@@ -25,9 +34,79 @@ func (v *FileHashFlag) UnmarshalText(b []byte) error {
 }
 
 func (v *FileHashFlag) String() string {
+	if len(v.FH) < 1 {
+		return "auto"
+	}
+
 	return v.FH.String()
 }
 
+// SizeFlag parses document size; "auto" leaves size to be computed from local
+// file.
+type SizeFlag struct {
+	Big currency.Big
+}
+
+func (v *SizeFlag) UnmarshalText(b []byte) error {
+	if strings.TrimSpace(string(b)) == "auto" {
+		v.Big = currency.NilBig
+
+		return nil
+	}
+
+	i, err := currency.NewBigFromString(string(b))
+	if err != nil {
+		return errors.Wrapf(err, "invalid size, %q", string(b))
+	}
+	v.Big = i
+
+	return nil
+}
+
+func (v *SizeFlag) String() string {
+	if v.Big.Equal(currency.NilBig) {
+		return "auto"
+	}
+
+	return v.Big.String()
+}
+
+func (v *SizeFlag) IsAuto() bool {
+	return v.Big.Equal(currency.NilBig)
+}
+
+// DocumentIdFlag parses document id; "auto" leaves document id to be assigned
+// by the chain.
+type DocumentIdFlag struct {
+	ID currency.Big
+}
+
+func (v *DocumentIdFlag) UnmarshalText(b []byte) error {
+	if strings.TrimSpace(string(b)) == "auto" {
+		v.ID = currency.NilBig
+
+		return nil
+	}
+
+	i, err := currency.NewBigFromString(string(b))
+	if err != nil {
+		return errors.Wrapf(err, "invalid document id, %q", string(b))
+	} else if !i.OverZero() {
+		return errors.Errorf("document id should be over zero, %q", string(b))
+	}
+	v.ID = i
+
+	return nil
+}
+
+func (v *DocumentIdFlag) String() string {
+	if v.ID.Equal(currency.NilBig) {
+		return "auto"
+	}
+
+	return v.ID.String()
+}
+
 type DocSignFlag struct {
 	AD base.AddressDecoder
 	SC string
@@ -55,3 +134,110 @@ func (v *DocSignFlag) UnmarshalText(b []byte) error {
 func (v *DocSignFlag) String() string {
 	return v.sa
 }
+
+// HeightFlag parses block height; base.NilHeight is used when not given.
+type DocFileFlag struct {
+	FH blocksign.FileHash
+	SZ currency.Big
+	NM string
+}
+
+func (v *DocFileFlag) UnmarshalText(b []byte) error {
+	l := strings.SplitN(string(b), ",", 3)
+	if len(l) != 3 {
+		return errors.Errorf(`wrong formatted; "<filehash>,<size>,<name>"`)
+	}
+
+	fh, err := blocksign.ParseFileHash(l[0])
+	if err != nil {
+		return err
+	}
+
+	size, err := currency.NewBigFromString(l[1])
+	if err != nil {
+		return errors.Wrapf(err, "invalid size, %q", l[1])
+	}
+
+	v.FH = fh
+	v.SZ = size
+	v.NM = l[2]
+
+	return nil
+}
+
+func (v *DocFileFlag) String() string {
+	return v.FH.String() + "," + v.SZ.String() + "," + v.NM
+}
+
+func (v *DocFileFlag) DocFile() blocksign.DocFile {
+	return blocksign.NewDocFile(v.FH, v.NM, v.SZ)
+}
+
+// DocumentFeeFlag parses the document fee policy of currency.
+type DocumentFeeFlag struct {
+	CID currency.CurrencyID
+	PO  blocksign.DocumentFeePolicy
+}
+
+func (v *DocumentFeeFlag) UnmarshalText(b []byte) error {
+	l := strings.SplitN(string(b), ",", 3)
+	if len(l) != 3 {
+		return errors.Errorf(`wrong formatted; "<currency id>,<size unit>,<signer fee>"`)
+	}
+
+	cid := currency.CurrencyID(l[0])
+	if err := cid.IsValid(nil); err != nil {
+		return errors.Wrapf(err, "invalid currency id of document fee, %q", l[0])
+	}
+
+	var bigs [2]currency.Big
+	for i, s := range l[1:] {
+		k, err := currency.NewBigFromString(s)
+		if err != nil {
+			return errors.Wrapf(err, "invalid number, %q", s)
+		}
+		bigs[i] = k
+	}
+
+	po := blocksign.NewDocumentFeePolicy(bigs[0], bigs[1])
+	if err := po.IsValid(nil); err != nil {
+		return errors.Wrapf(err, "invalid document fee of %q", l[0])
+	}
+
+	v.CID = cid
+	v.PO = po
+
+	return nil
+}
+
+func (v *DocumentFeeFlag) String() string {
+	return v.CID.String() + "," + v.PO.SizeUnit().String() + "," + v.PO.SignerFee().String()
+}
+
+type HeightFlag struct {
+	HT *base.Height
+}
+
+func (v *HeightFlag) UnmarshalText(b []byte) error {
+	h, err := base.NewHeightFromString(string(b))
+	if err != nil {
+		return errors.Wrapf(err, "invalid height, %q", string(b))
+	} else if h < base.Height(0) {
+		return errors.Errorf("height is negative number, %q", string(b))
+	}
+	v.HT = &h
+
+	return nil
+}
+
+func (v *HeightFlag) Height() base.Height {
+	if v.HT == nil {
+		return base.NilHeight
+	}
+
+	return *v.HT
+}
+
+func (v *HeightFlag) String() string {
+	return v.Height().String()
+}
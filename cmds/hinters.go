@@ -45,15 +45,40 @@ var types = []hint.Type{
 	currency.KeyUpdaterFactType,
 	currency.KeyUpdaterType,
 	blocksign.CreateDocumentsItemSingleFileType,
+	blocksign.CreateDocumentsItemMultiFilesType,
 	blocksign.CreateDocumentsFactType,
 	blocksign.CreateDocumentsType,
 	blocksign.SignItemSingleDocumentType,
 	blocksign.SignDocumentsFactType,
 	blocksign.SignDocumentsType,
+	blocksign.RejectItemSingleDocumentType,
+	blocksign.RejectDocumentsFactType,
+	blocksign.RejectDocumentsType,
+	blocksign.RevokeSignItemSingleDocumentType,
+	blocksign.RevokeSignDocumentsFactType,
+	blocksign.RevokeSignDocumentsType,
+	blocksign.TransferItemSingleDocumentType,
+	blocksign.TransferDocumentsFactType,
+	blocksign.TransferDocumentsType,
+	blocksign.UpdateSignersItemSingleDocumentType,
+	blocksign.UpdateDocumentSignersFactType,
+	blocksign.UpdateDocumentSignersType,
+	blocksign.ReviseItemSingleDocumentType,
+	blocksign.ReviseDocumentsFactType,
+	blocksign.ReviseDocumentsType,
+	blocksign.CancelItemSingleDocumentType,
+	blocksign.CancelDocumentsFactType,
+	blocksign.CancelDocumentsType,
 	blocksign.DocumentDataType,
 	blocksign.DocInfoType,
+	blocksign.DocIdType,
 	blocksign.DocSignType,
+	blocksign.DocRevisionType,
+	blocksign.DocFileType,
 	blocksign.DocumentInventoryType,
+	blocksign.DocumentPolicyType,
+	blocksign.DocumentPolicyUpdaterFactType,
+	blocksign.DocumentPolicyUpdaterType,
 	digest.ProblemType,
 	digest.NodeInfoType,
 	digest.BaseHalType,
@@ -94,14 +119,39 @@ var hinters = []hint.Hinter{
 	currency.Transfers{},
 	blocksign.CreateDocumentsFact{},
 	blocksign.CreateDocumentsItemSingleFileHinter,
+	blocksign.CreateDocumentsItemMultiFilesHinter,
 	blocksign.CreateDocuments{},
 	blocksign.SignDocumentsFact{},
 	blocksign.SignDocuments{},
 	blocksign.SignItemSingleDocumentHinter,
+	blocksign.RejectDocumentsFact{},
+	blocksign.RejectDocuments{},
+	blocksign.RejectItemSingleDocumentHinter,
+	blocksign.RevokeSignDocumentsFact{},
+	blocksign.RevokeSignDocuments{},
+	blocksign.RevokeSignItemSingleDocumentHinter,
+	blocksign.TransferDocumentsFact{},
+	blocksign.TransferDocuments{},
+	blocksign.TransferItemSingleDocumentHinter,
+	blocksign.UpdateDocumentSignersFact{},
+	blocksign.UpdateDocumentSigners{},
+	blocksign.UpdateSignersItemSingleDocumentHinter,
+	blocksign.ReviseDocumentsFact{},
+	blocksign.ReviseDocuments{},
+	blocksign.ReviseItemSingleDocumentHinter,
+	blocksign.CancelDocumentsFact{},
+	blocksign.CancelDocuments{},
+	blocksign.CancelItemSingleDocumentHinter,
 	blocksign.DocumentData{},
 	blocksign.DocInfo{},
+	blocksign.DocId{},
 	blocksign.DocSign{},
+	blocksign.DocRevision{},
+	blocksign.DocFile{},
 	blocksign.DocumentInventory{},
+	blocksign.DocumentPolicy{},
+	blocksign.DocumentPolicyUpdaterFact{},
+	blocksign.DocumentPolicyUpdater{},
 	digest.AccountValue{},
 	digest.DocumentValue{},
 	digest.BaseHal{},
@@ -0,0 +1,646 @@
+package cmds
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	"github.com/soonkuk/mitum-blocksign/digest"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/block"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/node"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/launch"
+	"github.com/spikeekips/mitum/network"
+	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/encoder"
+	bsonenc "github.com/spikeekips/mitum/util/encoder/bson"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/spikeekips/mitum/util/hint"
+	"github.com/spikeekips/mitum/util/valuehash"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// nonBSONHinters are not encoded as BSON document by design.
+var nonBSONHinters = map[hint.Type]string{
+	currency.AddressType: "encoded as BSON string value inside the other document",
+	digest.BaseHalType:   "JSON only; HTTP response of digest API",
+	digest.ProblemType:   "JSON only; HTTP response of digest API",
+}
+
+// testHinters checks the every hinter in Hinters is encoded and decoded back
+// to the same value by JSON and BSON encoder. The hinters of mitum in
+// launch.EncoderHinters are checked by mitum itself.
+type testHinters struct {
+	suite.Suite
+	networkID base.NetworkID
+	cid       currency.CurrencyID
+	priv      key.Privatekey
+	samples   map[hint.Type]hint.Hinter
+}
+
+func (t *testHinters) SetupSuite() {
+	t.networkID = base.NetworkID(util.UUID().Bytes())
+	t.cid = currency.CurrencyID("SHOWME")
+	t.priv = key.MustNewBTCPrivatekey()
+
+	t.samples = map[hint.Type]hint.Hinter{}
+	for _, ht := range t.newSamples() {
+		t.samples[ht.Hint().Type()] = ht
+	}
+}
+
+func (t *testHinters) address() base.Address {
+	k, err := currency.NewKey(key.MustNewBTCPrivatekey().Publickey(), 100)
+	t.NoError(err)
+	keys, err := currency.NewKeys([]currency.Key{k}, 100)
+	t.NoError(err)
+	a, err := currency.NewAddressFromKeys(keys)
+	t.NoError(err)
+
+	return a
+}
+
+func (t *testHinters) keys() currency.Keys {
+	k, err := currency.NewKey(t.priv.Publickey(), 100)
+	t.NoError(err)
+	keys, err := currency.NewKeys([]currency.Key{k}, 100)
+	t.NoError(err)
+
+	return keys
+}
+
+func (t *testHinters) factSigns(fact base.Fact) []operation.FactSign {
+	sig, err := operation.NewFactSignature(t.priv, fact, t.networkID)
+	t.NoError(err)
+
+	return []operation.FactSign{operation.NewBaseFactSign(t.priv.Publickey(), sig)}
+}
+
+func (t *testHinters) amount() currency.Amount {
+	return currency.NewAmount(currency.NewBig(33), t.cid)
+}
+
+func (t *testHinters) currencyDesign() currency.CurrencyDesign {
+	return currency.NewCurrencyDesign(
+		currency.NewAmount(currency.NewBig(1000), t.cid),
+		t.address(),
+		currency.NewCurrencyPolicy(currency.NewBig(1), currency.NewFixedFeeer(t.address(), currency.NewBig(2))),
+	)
+}
+
+func (t *testHinters) documentData() blocksign.DocumentData {
+	return blocksign.NewDocumentData(
+		blocksign.MustNewDocInfo(3, blocksign.MustFileHash("ABCD")),
+		t.address(),
+		"user0",
+		"title",
+		currency.NewBig(555),
+		[]blocksign.DocSign{blocksign.NewDocSign(t.address(), "user1", true)},
+	).
+		WithExpiry(base.Height(99)).
+		WithMetadata(blocksign.DocumentMetadata{blocksign.MetadataKeyReference: "REF-0001"})
+}
+
+func (t *testHinters) newSamples() []hint.Hinter { // nolint:funlen
+	token := util.UUID().Bytes()
+	sender := t.address()
+	keys := t.keys()
+	docid := currency.NewBig(3)
+
+	ac, err := currency.NewAccountFromKeys(keys)
+	t.NoError(err)
+
+	j, err := state.NewStateV0(currency.StateKeyBalance(sender, t.cid), nil, base.Height(3))
+	t.NoError(err)
+	st, err := currency.SetStateBalanceValue(j, t.amount())
+	t.NoError(err)
+	st, err = st.SetHash(st.GenerateHash())
+	t.NoError(err)
+	ast := currency.NewAmountState(st, t.cid).Add(currency.NewBig(3)).AddFee(currency.NewBig(1))
+
+	var samples []hint.Hinter
+
+	// currency
+	caSingle := currency.NewCreateAccountsItemSingleAmount(keys, t.amount())
+	caMulti := currency.NewCreateAccountsItemMultiAmounts(keys, []currency.Amount{t.amount()})
+	caFact := currency.NewCreateAccountsFact(token, sender, []currency.CreateAccountsItem{caSingle})
+	ca, err := currency.NewCreateAccounts(caFact, t.factSigns(caFact), "")
+	t.NoError(err)
+
+	trSingle := currency.NewTransfersItemSingleAmount(t.address(), t.amount())
+	trMulti := currency.NewTransfersItemMultiAmounts(t.address(), []currency.Amount{t.amount()})
+	trFact := currency.NewTransfersFact(token, sender, []currency.TransfersItem{trSingle})
+	tr, err := currency.NewTransfers(trFact, t.factSigns(trFact), "")
+	t.NoError(err)
+
+	kuFact := currency.NewKeyUpdaterFact(token, sender, keys, t.cid)
+	ku, err := currency.NewKeyUpdater(kuFact, t.factSigns(kuFact), "")
+	t.NoError(err)
+
+	de := t.currencyDesign()
+	crFact := currency.NewCurrencyRegisterFact(token, de)
+	cr, err := currency.NewCurrencyRegister(crFact, t.factSigns(crFact), "")
+	t.NoError(err)
+
+	cpuFact := currency.NewCurrencyPolicyUpdaterFact(token, t.cid, de.Policy())
+	cpu, err := currency.NewCurrencyPolicyUpdater(cpuFact, t.factSigns(cpuFact), "")
+	t.NoError(err)
+
+	gcFact := currency.NewGenesisCurrenciesFact(t.networkID, t.priv.Publickey(), keys, []currency.CurrencyDesign{de})
+	gc, err := currency.NewGenesisCurrencies(t.priv, keys, []currency.CurrencyDesign{de}, t.networkID)
+	t.NoError(err)
+
+	foFact := currency.NewFeeOperationFact(base.Height(3), map[currency.CurrencyID]currency.Big{t.cid: currency.NewBig(3)})
+
+	samples = append(samples,
+		ac,
+		sender,
+		ast,
+		t.amount(),
+		caFact, caSingle, caMulti, ca,
+		de,
+		cpuFact, cpu,
+		de.Policy(),
+		crFact, cr,
+		foFact, currency.NewFeeOperation(foFact),
+		currency.NewFixedFeeer(t.address(), currency.NewBig(3)),
+		gcFact, gc,
+		kuFact, ku,
+		keys,
+		keys.Keys()[0],
+		currency.NewNilFeeer(),
+		currency.NewRatioFeeer(t.address(), 0.5, currency.NewBig(1), currency.NewBig(9)),
+		trFact, trSingle, trMulti, tr,
+	)
+
+	// blocksign
+	cdSingle := blocksign.NewCreateDocumentsItemSingleFile(
+		blocksign.MustFileHash("ABCD"), docid, "user0", "title", currency.NewBig(555),
+		[]base.Address{t.address()}, []string{"user1"}, t.cid,
+	)
+	cdMulti := blocksign.NewCreateDocumentsItemMultiFiles(
+		docid, "user0", "title",
+		[]blocksign.DocFile{blocksign.NewDocFile(blocksign.MustFileHash("ABCD"), "main.pdf", currency.NewBig(555))},
+		[]base.Address{t.address()}, []string{"user1"}, t.cid,
+	)
+	cdFact := blocksign.NewCreateDocumentsFact(token, sender, []blocksign.CreateDocumentsItem{cdSingle})
+	cd, err := blocksign.NewCreateDocuments(cdFact, t.factSigns(cdFact), "")
+	t.NoError(err)
+
+	sdItem := blocksign.NewSignDocumentsItemSingleFile(docid, t.address(), "user1", t.cid)
+	sdFact := blocksign.NewSignDocumentsFact(token, sender, []blocksign.SignDocumentItem{sdItem})
+	sd, err := blocksign.NewSignDocuments(sdFact, t.factSigns(sdFact), "")
+	t.NoError(err)
+
+	rjItem := blocksign.NewRejectDocumentsItemSingleFile(docid, t.address(), t.cid)
+	rjFact := blocksign.NewRejectDocumentsFact(token, sender, []blocksign.SignDocumentItem{rjItem})
+	rj, err := blocksign.NewRejectDocuments(rjFact, t.factSigns(rjFact), "")
+	t.NoError(err)
+
+	rvItem := blocksign.NewRevokeSignDocumentsItemSingleFile(docid, t.address(), t.cid)
+	rvFact := blocksign.NewRevokeSignDocumentsFact(token, sender, []blocksign.SignDocumentItem{rvItem})
+	rv, err := blocksign.NewRevokeSignDocuments(rvFact, t.factSigns(rvFact), "")
+	t.NoError(err)
+
+	tdItem := blocksign.NewTransferDocumentsItemSingleFile(docid, sender, t.address(), t.cid)
+	tdFact := blocksign.NewTransferDocumentsFact(token, sender, []blocksign.TransferDocumentsItem{tdItem})
+	td, err := blocksign.NewTransferDocuments(tdFact, t.factSigns(tdFact), "")
+	t.NoError(err)
+
+	usItem := blocksign.NewUpdateDocumentSignersItemSingleFile(
+		docid, sender, []base.Address{t.address()}, []string{"user2"}, []uint{1}, []base.Address{t.address()}, t.cid,
+	)
+	usFact := blocksign.NewUpdateDocumentSignersFact(token, sender, []blocksign.UpdateDocumentSignersItem{usItem})
+	us, err := blocksign.NewUpdateDocumentSigners(usFact, t.factSigns(usFact), "")
+	t.NoError(err)
+
+	reItem := blocksign.NewReviseDocumentsItemSingleFile(docid, sender, blocksign.MustFileHash("EFGH"), "title2", currency.NewBig(666), t.cid)
+	reFact := blocksign.NewReviseDocumentsFact(token, sender, []blocksign.ReviseDocumentsItem{reItem})
+	re, err := blocksign.NewReviseDocuments(reFact, t.factSigns(reFact), "")
+	t.NoError(err)
+
+	ccItem := blocksign.NewCancelDocumentsItemSingleFile(docid, sender, true, t.cid)
+	ccFact := blocksign.NewCancelDocumentsFact(token, sender, []blocksign.CancelDocumentsItem{ccItem})
+	cc, err := blocksign.NewCancelDocuments(ccFact, t.factSigns(ccFact), "")
+	t.NoError(err)
+
+	po := blocksign.NewDocumentPolicy(true).
+		WithFeePolicy(t.cid, blocksign.NewDocumentFeePolicy(currency.NewBig(1024), currency.NewBig(1)))
+	puFact := blocksign.NewDocumentPolicyUpdaterFact(token, po)
+	pu, err := blocksign.NewDocumentPolicyUpdater(puFact, t.factSigns(puFact), "")
+	t.NoError(err)
+
+	dd := t.documentData()
+
+	samples = append(samples,
+		cdFact, cdSingle, cdMulti, cd,
+		sdFact, sd, sdItem,
+		rjFact, rj, rjItem,
+		rvFact, rv, rvItem,
+		tdFact, td, tdItem,
+		usFact, us, usItem,
+		reFact, re, reItem,
+		ccFact, cc, ccItem,
+		po, puFact, pu,
+		dd,
+		dd.Info(),
+		blocksign.NewDocId(3),
+		dd.Signers()[0],
+		blocksign.NewDocRevision(blocksign.MustFileHash("EFGH"), "title2", currency.NewBig(666), dd.Signers(), base.Height(3)),
+		blocksign.NewDocFile(blocksign.MustFileHash("ABCD"), "main.pdf", currency.NewBig(555)),
+		blocksign.NewDocumentInventory([]blocksign.DocInfo{dd.Info(), blocksign.MustNewDocInfo(4, blocksign.MustFileHash("EFGH"))}),
+	)
+
+	// digest
+	i, err := state.NewStateV0(currency.StateKeyAccount(ac.Address()), nil, base.Height(3))
+	t.NoError(err)
+	acst, err := currency.SetStateAccountValue(i, ac)
+	t.NoError(err)
+	va, err := digest.NewAccountValue(acst)
+	t.NoError(err)
+	va = va.SetBalance([]currency.Amount{t.amount()}).
+		SetDocument(blocksign.NewDocumentInventory([]blocksign.DocInfo{dd.Info()}))
+
+	blk, err := block.NewTestBlockV0(base.Height(33), base.Round(0), valuehash.RandomSHA256(), valuehash.RandomSHA256())
+	t.NoError(err)
+
+	ni := network.NewNodeInfoV0(
+		node.RandomNode("n0").BaseV0,
+		t.networkID,
+		base.StateBooting,
+		blk.Manifest(),
+		util.Version("0.1.1"),
+		map[string]interface{}{"showme": "findme"},
+		[]network.RemoteNode{network.NewRemoteNode(
+			node.RandomNode("n1").BaseV0,
+			network.NewHTTPConnInfo(&url.URL{Scheme: "https", Host: "n1:443"}, true),
+		)},
+		nil,
+		network.NewHTTPConnInfo(&url.URL{Scheme: "https", Host: "local:443"}, true),
+	)
+
+	confirmed := time.Date(2021, 10, 20, 4, 48, 6, 0, time.UTC)
+
+	samples = append(samples,
+		va,
+		digest.NewDocumentValue(dd, base.Height(3)),
+		digest.NewBaseHal(dd.Info(), digest.NewHalLink("/document/3", nil)),
+		digest.NewNodeInfo(ni),
+		digest.NewOperationValue(tr, base.Height(3), confirmed, true, nil, 0),
+		digest.NewProblemFromError(fmt.Errorf("showme")),
+	)
+
+	return samples
+}
+
+// TestSamples checks every hinter has the sample, which is checked by
+// encoders; the new hinter should have the sample.
+func (t *testHinters) TestSamples() {
+	for _, ht := range hinters {
+		_, found := t.samples[ht.Hint().Type()]
+		t.True(found, "sample not found, %q", ht.Hint())
+	}
+
+	for _, ht := range launch.EncoderHinters {
+		_, found := t.samples[ht.Hint().Type()]
+		t.False(found, "mitum hinter found in samples, %q", ht.Hint())
+	}
+}
+
+func (t *testHinters) checkEncoder(ty hint.Type) {
+	enc, err := encs.Encoder(ty, "")
+	t.NoError(err)
+
+	for _, ht := range hinters {
+		sample := t.samples[ht.Hint().Type()]
+		if sample == nil {
+			continue
+		}
+
+		if ty == bsonenc.BSONEncoderType {
+			_, ok := sample.(bson.Marshaler)
+			_, skip := nonBSONHinters[ht.Hint().Type()]
+			t.True(ok != skip, "BSON marshaler of sample, %q; marshaler=%v skipped=%v", ht.Hint(), ok, skip)
+
+			if !ok {
+				continue
+			}
+		}
+
+		t.Run(ht.Hint().String(), func() {
+			b, err := enc.Marshal(sample)
+			t.NoError(err)
+
+			decoded, err := enc.Decode(b)
+			t.NoError(err)
+			t.NotNil(decoded)
+
+			if hal, ok := decoded.(digest.BaseHal); ok {
+				t.compareHal(enc, sample.(digest.BaseHal), hal)
+
+				return
+			}
+
+			t.NoError(compareValue(reflect.ValueOf(sample), reflect.ValueOf(decoded), fmt.Sprintf("%T", decoded)))
+		})
+	}
+}
+
+// compareHal compares BaseHal by it's accessors; the embedded of decoded is
+// left as raw by BaseHal.UnmarshalJSON and self link is in the links.
+func (t *testHinters) compareHal(enc encoder.Encoder, a, b digest.BaseHal) {
+	i, err := enc.Decode(b.RawInterface())
+	t.NoError(err)
+
+	t.NoError(compareValue(reflect.ValueOf(a.Interface()), reflect.ValueOf(i), "BaseHal.Interface()"))
+	t.NoError(compareValue(reflect.ValueOf(a.Self()), reflect.ValueOf(b.Links()["self"]), "BaseHal.Self()"))
+	t.NoError(compareValue(reflect.ValueOf(a.Links()), reflect.ValueOf(b.Links()), "BaseHal.Links()"))
+	t.NoError(compareValue(reflect.ValueOf(a.Extras()), reflect.ValueOf(b.Extras()), "BaseHal.Extras()"))
+}
+
+// embeddedValue returns the embedded field of a by the given type; some
+// hinters are decoded to their embedded value, for example, the single file
+// items are decoded to the base item and currency.AmountState is decoded to
+// the inside state.
+func embeddedValue(a reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if a.Kind() != reflect.Struct {
+		return a, false
+	}
+
+	for i := 0; i < a.NumField(); i++ {
+		if !a.Type().Field(i).Anonymous {
+			continue
+		}
+
+		f := elem(exported(a.Field(i)))
+		if f.IsValid() && f.Type() == t {
+			return f, true
+		}
+	}
+
+	return a, false
+}
+
+// compareValue compares a and b by the Equal method of value if exists,
+// otherwise by it's elements. The nil and empty slice or map are same.
+func compareValue(a, b reflect.Value, path string) error {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			return errors.Errorf("%s: nil not matched", path)
+		}
+
+		return nil
+	}
+
+	a, b = elem(addressable(a)), elem(addressable(b))
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			return errors.Errorf("%s: nil not matched", path)
+		}
+
+		return nil
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		// NOTE BSON datetime is in milliseconds
+		ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+		if !ta.Truncate(time.Millisecond).Equal(tb.Truncate(time.Millisecond)) {
+			return errors.Errorf("%s: time not matched, %v != %v", path, ta, tb)
+		}
+
+		return nil
+	}
+
+	if a.Type() != b.Type() {
+		if e, found := embeddedValue(a, b.Type()); found {
+			a = e
+		}
+	}
+
+	if eq, found := callEqual(a, b); found {
+		if !eq {
+			return errors.Errorf("%s: not equal, %v != %v", path, a, b)
+		}
+
+		return nil
+	}
+
+	if a.Type() != b.Type() {
+		return errors.Errorf("%s: type not matched, %v != %v", path, a.Type(), b.Type())
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				return errors.Errorf("%s: nil not matched", path)
+			}
+
+			return nil
+		}
+
+		return compareValue(a.Elem(), b.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if err := compareValue(
+				exported(a.Field(i)), exported(b.Field(i)), path+"."+a.Type().Field(i).Name,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return errors.Errorf("%s: length not matched, %d != %d", path, a.Len(), b.Len())
+		}
+
+		for i := 0; i < a.Len(); i++ {
+			if err := compareValue(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return errors.Errorf("%s: length not matched, %d != %d", path, a.Len(), b.Len())
+		}
+
+		iter := a.MapRange()
+		for iter.Next() {
+			if err := compareValue(
+				iter.Value(), b.MapIndex(iter.Key()), fmt.Sprintf("%s[%v]", path, iter.Key()),
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			return errors.Errorf("%s: pointer not matched", path)
+		}
+
+		return nil
+	default:
+		if a.Interface() != b.Interface() {
+			return errors.Errorf("%s: not matched, %v != %v", path, a, b)
+		}
+
+		return nil
+	}
+}
+
+// callEqual calls the Equal method of a with b like base.Address.Equal() or
+// time.Time.Equal().
+func callEqual(a, b reflect.Value) (bool, bool) {
+	m := a.MethodByName("Equal")
+	if !m.IsValid() && a.CanAddr() {
+		m = a.Addr().MethodByName("Equal")
+	}
+
+	if !m.IsValid() {
+		return false, false
+	}
+
+	mt := m.Type()
+	if mt.NumIn() != 1 || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool || !b.Type().AssignableTo(mt.In(0)) {
+		return false, false
+	}
+
+	return m.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+func elem(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Interface {
+		return v
+	}
+
+	if v = v.Elem(); !v.IsValid() {
+		return v
+	}
+
+	return addressable(v)
+}
+
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	n := reflect.New(v.Type()).Elem()
+	n.Set(v)
+
+	return n
+}
+
+// exported makes the unexported field of addressable struct accessible.
+func exported(f reflect.Value) reflect.Value {
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem() // nolint:gosec
+}
+
+// legacySamples returns the hinters with the legacy hints, which are only
+// decoded from chain history.
+func (t *testHinters) legacySamples() []hint.Hinter {
+	token := util.UUID().Bytes()
+	sender := t.address()
+	docid := currency.NewBig(3)
+
+	// NOTE legacy items have the plain filehash
+	fh := blocksign.FileHash("ABCD")
+
+	dd := blocksign.LegacyDocumentData(blocksign.NewDocumentData(
+		blocksign.MustNewDocInfo(3, fh),
+		t.address(),
+		"user0",
+		"title",
+		currency.NewBig(555),
+		[]blocksign.DocSign{blocksign.NewDocSign(t.address(), "user1", true)},
+	))
+
+	cdSingle := blocksign.LegacyCreateDocumentsItemSingleFile(blocksign.NewCreateDocumentsItemSingleFile(
+		fh, docid, "user0", "title", currency.NewBig(555),
+		[]base.Address{t.address()}, []string{"user1"}, t.cid,
+	))
+	cdMulti := blocksign.LegacyCreateDocumentsItemMultiFiles(blocksign.NewCreateDocumentsItemMultiFiles(
+		docid, "user0", "title",
+		[]blocksign.DocFile{
+			blocksign.NewDocFile(fh, "main.pdf", currency.NewBig(555)),
+			blocksign.NewDocFile(blocksign.FileHash("EFGH"), "attached.pdf", currency.NewBig(10)),
+		},
+		[]base.Address{t.address()}, []string{"user1"}, t.cid,
+	))
+	cdFact := blocksign.NewCreateDocumentsFact(token, sender, []blocksign.CreateDocumentsItem{cdSingle, cdMulti})
+
+	sdItem := blocksign.LegacySignDocumentsItemSingleFile(
+		blocksign.NewSignDocumentsItemSingleFile(docid, t.address(), "", t.cid),
+	)
+	sdFact := blocksign.NewSignDocumentsFact(token, sender, []blocksign.SignDocumentItem{sdItem})
+
+	return []hint.Hinter{
+		dd,
+		dd.Signers()[0],
+		cdSingle,
+		cdMulti,
+		cdFact,
+		sdItem,
+		sdFact,
+	}
+}
+
+// TestLegacy checks the legacy hinters keep the legacy hint and their
+// original bytes after decoding, so the hashes of chain history are not
+// changed.
+func (t *testHinters) TestLegacy() {
+	for _, ty := range []hint.Type{jsonenc.JSONEncoderType, bsonenc.BSONEncoderType} {
+		enc, err := encs.Encoder(ty, "")
+		t.NoError(err)
+
+		for _, sample := range t.legacySamples() {
+			t.Run(fmt.Sprintf("%s-%s", ty, sample.Hint()), func() {
+				b, err := enc.Marshal(sample)
+				t.NoError(err)
+
+				decoded, err := enc.Decode(b)
+				t.NoError(err)
+
+				t.Equal(sample.Hint(), decoded.Hint())
+				t.Equal(sample.(util.Byter).Bytes(), decoded.(util.Byter).Bytes())
+
+				if h, ok := sample.(valuehash.Hasher); ok {
+					t.True(h.Hash().Equal(decoded.(valuehash.Hasher).Hash()))
+				}
+
+				if h, ok := decoded.(valuehash.HashGenerator); ok {
+					t.True(sample.(valuehash.Hasher).Hash().Equal(h.GenerateHash()))
+				}
+			})
+		}
+	}
+}
+
+func (t *testHinters) TestJSON() {
+	t.checkEncoder(jsonenc.JSONEncoderType)
+}
+
+func (t *testHinters) TestBSON() {
+	t.checkEncoder(bsonenc.BSONEncoderType)
+}
+
+func TestHinters(t *testing.T) {
+	suite.Run(t, new(testHinters))
+}
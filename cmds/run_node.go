@@ -10,6 +10,7 @@ import (
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/base/block"
 	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/isaac"
 	mitumcmds "github.com/spikeekips/mitum/launch/cmds"
 	"github.com/spikeekips/mitum/launch/config"
 	"github.com/spikeekips/mitum/launch/pm"
@@ -252,6 +253,10 @@ func (cmd *RunCommand) setDigestHandlers(
 	}
 	handlers = i
 
+	if _, err := cmd.setDigestSimulator(ctx, handlers); err != nil {
+		return nil, err
+	}
+
 	if nc := design.Network(); nc != nil && nc.RateLimit() != nil {
 		if _, err := cmd.attachDigestRateLimit(ctx, handlers, nc.RateLimit()); err != nil {
 			return nil, err
@@ -370,3 +375,44 @@ func (cmd *RunCommand) setDigestSendHandler(
 
 	return handlers, nil
 }
+
+func (cmd *RunCommand) setDigestSimulator(
+	ctx context.Context,
+	handlers *digest.Handlers,
+) (*digest.Handlers, error) {
+	var st *mongodbstorage.Database
+	if err := LoadDatabaseContextValue(ctx, &st); err != nil {
+		return nil, err
+	}
+
+	var nodepool *network.Nodepool
+	if err := process.LoadNodepoolContextValue(ctx, &nodepool); err != nil {
+		return nil, err
+	}
+
+	var suffrage base.Suffrage
+	if err := process.LoadSuffrageContextValue(ctx, &suffrage); err != nil {
+		return nil, err
+	}
+
+	var policy *isaac.LocalPolicy
+	if err := process.LoadPolicyContextValue(ctx, &policy); err != nil {
+		return nil, err
+	}
+
+	var cp *currency.CurrencyPool
+	if err := LoadCurrencyPoolContextValue(ctx, &cp); err != nil {
+		return nil, err
+	}
+
+	opr, err := AttachProposalProcessor(policy, nodepool, suffrage, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	handlers = handlers.SetSimulator(opr, st)
+
+	cmd.Log().Debug().Msg("simulator attached")
+
+	return handlers, nil
+}
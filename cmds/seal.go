@@ -7,10 +7,18 @@ type SealCommand struct {
 	CreateAccount         currencycmds.CreateAccountCommand         `cmd:"" name:"create-account" help:"create new account"`
 	CreateDocument        CreateDocumentCommand                     `cmd:"" name:"create-document" help:"create new document"`
 	SignDocument          SignDocumentCommand                       `cmd:"" name:"sign-document" help:"sign document"`
+	RejectDocument        RejectDocumentCommand                     `cmd:"" name:"reject-document" help:"reject document"`
+	RevokeSignDocument    RevokeSignDocumentCommand                 `cmd:"" name:"revoke-sign-document" help:"revoke signature of document"`
+	TransferDocument      TransferDocumentCommand                   `cmd:"" name:"transfer-document" help:"transfer document ownership"`
+	UpdateDocumentSigners UpdateDocumentSignersCommand              `cmd:"" name:"update-document-signers" help:"add or remove signers of document"`
+	ReviseDocument        ReviseDocumentCommand                     `cmd:"" name:"revise-document" help:"revise file of document"`
+	CancelDocument        CancelDocumentCommand                     `cmd:"" name:"cancel-document" help:"cancel document"`
+	VerifyDocument        VerifyDocumentCommand                     `cmd:"" name:"verify-document" help:"verify local file with document"`
 	Transfer              currencycmds.TransferCommand              `cmd:"" name:"transfer" help:"transfer big"`
 	KeyUpdater            currencycmds.KeyUpdaterCommand            `cmd:"" name:"key-updater" help:"update keys"`
 	CurrencyRegister      currencycmds.CurrencyRegisterCommand      `cmd:"" name:"currency-register" help:"register new currency"`
 	CurrencyPolicyUpdater currencycmds.CurrencyPolicyUpdaterCommand `cmd:"" name:"currency-policy-updater" help:"update currency policy"` // revive:disable-line:line-length-limit
+	DocumentPolicyUpdater DocumentPolicyUpdaterCommand              `cmd:"" name:"document-policy-updater" help:"update document policy"`
 	Sign                  currencycmds.SignSealCommand              `cmd:"" name:"sign" help:"sign seal"`
 	SignFact              currencycmds.SignFactCommand              `cmd:"" name:"sign-fact" help:"sign facts of operation seal"`
 }
@@ -21,10 +29,18 @@ func NewSealCommand() SealCommand {
 		CreateAccount:         currencycmds.NewCreateAccountCommand(),
 		CreateDocument:        NewCreateDocumentCommand(),
 		SignDocument:          NewSignDocumentCommand(),
+		RejectDocument:        NewRejectDocumentCommand(),
+		RevokeSignDocument:    NewRevokeSignDocumentCommand(),
+		TransferDocument:      NewTransferDocumentCommand(),
+		UpdateDocumentSigners: NewUpdateDocumentSignersCommand(),
+		ReviseDocument:        NewReviseDocumentCommand(),
+		CancelDocument:        NewCancelDocumentCommand(),
+		VerifyDocument:        NewVerifyDocumentCommand(),
 		Transfer:              currencycmds.NewTransferCommand(),
 		KeyUpdater:            currencycmds.NewKeyUpdaterCommand(),
 		CurrencyRegister:      currencycmds.NewCurrencyRegisterCommand(),
 		CurrencyPolicyUpdater: currencycmds.NewCurrencyPolicyUpdaterCommand(),
+		DocumentPolicyUpdater: NewDocumentPolicyUpdaterCommand(),
 		Sign:                  currencycmds.NewSignSealCommand(),
 		SignFact:              currencycmds.NewSignFactCommand(),
 	}
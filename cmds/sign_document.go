@@ -18,6 +18,7 @@ type SignDocumentCommand struct {
 	Sender   currencycmds.AddressFlag    `arg:"" name:"sender" help:"sender address" required:""`
 	DocId    currencycmds.BigFlag        `arg:"" name:"documentid" help:"document id" required:""`
 	Owner    currencycmds.AddressFlag    `arg:"" name:"owner" help:"owner address" required:""`
+	Signcode string                      `arg:"" name:"signcode" help:"signcode of signer" required:""`
 	Currency currencycmds.CurrencyIDFlag `arg:"" name:"currency" help:"currency id" required:""`
 	Seal     mitumcmds.FileLoad          `help:"seal" optional:""`
 	sender   base.Address
@@ -91,7 +92,7 @@ func (cmd *SignDocumentCommand) createOperation() (operation.Operation, error) {
 		}
 	}
 
-	item := blocksign.NewSignDocumentsItemSingleFile(cmd.DocId.Big, cmd.owner, cmd.Currency.CID)
+	item := blocksign.NewSignDocumentsItemSingleFile(cmd.DocId.Big, cmd.owner, cmd.Signcode, cmd.Currency.CID)
 
 	if err := item.IsValid(nil); err != nil {
 		return nil, err
@@ -0,0 +1,142 @@
+package cmds
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/util"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	currencycmds "github.com/spikeekips/mitum-currency/cmds"
+	mitumcmds "github.com/spikeekips/mitum/launch/cmds"
+)
+
+type UpdateDocumentSignersCommand struct {
+	*BaseCommand
+	currencycmds.OperationFlags
+	Sender    currencycmds.AddressFlag    `arg:"" name:"sender" help:"sender address" required:""`
+	DocId     currencycmds.BigFlag        `arg:"" name:"documentid" help:"document id" required:""`
+	Currency  currencycmds.CurrencyIDFlag `arg:"" name:"currency" help:"currency id" required:""`
+	Adds      []DocSignFlag               `name:"add" help:"signers to be added (ex: \"<address>,<signcode>\")" sep:"@" optional:""`
+	Weights   []uint                      `name:"weights" help:"weights of added signers in the order of signers" optional:""`
+	Removes   []currencycmds.AddressFlag  `name:"remove" help:"signers to be removed" optional:""`
+	Seal      mitumcmds.FileLoad          `help:"seal" optional:""`
+	sender    base.Address
+	adds      []base.Address
+	signcodes []string
+	removes   []base.Address
+}
+
+func NewUpdateDocumentSignersCommand() UpdateDocumentSignersCommand {
+	return UpdateDocumentSignersCommand{
+		BaseCommand: NewBaseCommand("update-document-signers-operation"),
+	}
+}
+
+func (cmd *UpdateDocumentSignersCommand) Run(version util.Version) error { // nolint:dupl
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return errors.Errorf("failed to initialize command: %q", err)
+	}
+
+	if err := cmd.parseFlags(); err != nil {
+		return err
+	}
+
+	var op operation.Operation
+	if o, err := cmd.createOperation(); err != nil {
+		return err
+	} else {
+		op = o
+	}
+
+	if sl, err := loadSealAndAddOperation(
+		cmd.Seal.Bytes(),
+		cmd.Privatekey,
+		cmd.NetworkID.NetworkID(),
+		op,
+	); err != nil {
+		return err
+	} else {
+		currencycmds.PrettyPrint(cmd.Out, cmd.Pretty, sl)
+	}
+
+	return nil
+}
+
+func (cmd *UpdateDocumentSignersCommand) parseFlags() error {
+	if err := cmd.OperationFlags.IsValid(nil); err != nil {
+		return err
+	}
+
+	if a, err := cmd.Sender.Encode(jenc); err != nil {
+		return errors.Errorf("invalid sender format, %q: %q", cmd.Sender.String(), err)
+	} else {
+		cmd.sender = a
+	}
+
+	{
+		adds := make([]base.Address, len(cmd.Adds))
+		signcodes := make([]string, len(cmd.Adds))
+		for i := range cmd.Adds {
+			if a, err := cmd.Adds[i].AD.Encode(jenc); err != nil {
+				return errors.Errorf("invalid signer format, %q: %q", cmd.Adds[i].String(), err)
+			} else {
+				adds[i] = a
+				// NOTE only the commitment of signcode is published
+				signcodes[i] = blocksign.SignCode(cmd.Adds[i].SC).Commitment().String()
+			}
+		}
+		cmd.adds = adds
+		cmd.signcodes = signcodes
+	}
+
+	removes := make([]base.Address, len(cmd.Removes))
+	for i := range cmd.Removes {
+		if a, err := cmd.Removes[i].Encode(jenc); err != nil {
+			return errors.Errorf("invalid signer format, %q: %q", cmd.Removes[i].String(), err)
+		} else {
+			removes[i] = a
+		}
+	}
+	cmd.removes = removes
+
+	return nil
+}
+
+func (cmd *UpdateDocumentSignersCommand) createOperation() (operation.Operation, error) { // nolint:dupl
+	var items []blocksign.UpdateDocumentSignersItem
+	if i, err := loadOperations(cmd.Seal.Bytes(), cmd.NetworkID.NetworkID()); err != nil {
+		return nil, err
+	} else {
+		for j := range i {
+			if t, ok := i[j].(blocksign.UpdateDocumentSigners); ok {
+				items = t.Fact().(blocksign.UpdateDocumentSignersFact).Items()
+			}
+		}
+	}
+
+	item := blocksign.NewUpdateDocumentSignersItemSingleFile(
+		cmd.DocId.Big, cmd.sender, cmd.adds, cmd.signcodes, cmd.Weights, cmd.removes, cmd.Currency.CID)
+
+	if err := item.IsValid(nil); err != nil {
+		return nil, err
+	} else {
+		items = append(items, item)
+	}
+
+	fact := blocksign.NewUpdateDocumentSignersFact([]byte(cmd.Token), cmd.sender, items)
+
+	var fs []operation.FactSign
+	if sig, err := operation.NewFactSignature(cmd.Privatekey, fact, cmd.NetworkID.NetworkID()); err != nil {
+		return nil, err
+	} else {
+		fs = append(fs, operation.NewBaseFactSign(cmd.Privatekey.Publickey(), sig))
+	}
+
+	if op, err := blocksign.NewUpdateDocumentSigners(fact, fs, cmd.Memo); err != nil {
+		return nil, errors.Wrap(err, "failed to create update-document-signers operation")
+	} else {
+		return op, nil
+	}
+}
@@ -0,0 +1,218 @@
+package cmds
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/spikeekips/mitum/util"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	currencycmds "github.com/spikeekips/mitum-currency/cmds"
+	"github.com/spikeekips/mitum-currency/currency"
+	mitumcmds "github.com/spikeekips/mitum/launch/cmds"
+)
+
+type VerifyDocumentCommand struct {
+	*BaseCommand
+	File       string                  `arg:"" name:"file" help:"local file of document" required:""`
+	Document   mitumcmds.FileLoad      `name:"document" help:"document data or response of digest document api" optional:""`
+	Seal       mitumcmds.FileLoad      `help:"seal, which has create-documents or revise-documents operation" optional:""`
+	NetworkID  mitumcmds.NetworkIDFlag `name:"network-id" help:"network-id of seal" optional:""`
+	DocumentId string                  `name:"documentid" help:"document id to be verified in seal" optional:""`
+	HashAlgo   string                  `name:"hash-algorithm" help:"hash algorithm for filehash without algorithm" default:"sha256"`
+	Pretty     bool                    `name:"pretty" help:"pretty format"`
+	documentid currency.Big
+}
+
+func NewVerifyDocumentCommand() VerifyDocumentCommand {
+	return VerifyDocumentCommand{
+		BaseCommand: NewBaseCommand("verify-document"),
+	}
+}
+
+// verifiedFile is the expected filehash and size of document.
+type verifiedFile struct {
+	DocumentId currency.Big       `json:"documentid"`
+	FileHash   blocksign.FileHash `json:"filehash"`
+	Size       currency.Big       `json:"size"`
+}
+
+func (cmd *VerifyDocumentCommand) Run(version util.Version) error {
+	if err := cmd.Initialize(cmd, version); err != nil {
+		return errors.Errorf("failed to initialize command: %q", err)
+	}
+
+	if err := cmd.parseFlags(); err != nil {
+		return err
+	}
+
+	var expected []verifiedFile
+	if len(cmd.Document.Bytes()) > 0 {
+		doc, err := loadDocumentData(cmd.Document.Bytes())
+		if err != nil {
+			return err
+		}
+
+		expected = documentVerifiedFiles(doc)
+	} else {
+		i, err := cmd.loadFromSeal()
+		if err != nil {
+			return err
+		}
+		expected = i
+	}
+
+	if len(expected) < 1 {
+		return errors.Errorf("no document found to be verified")
+	}
+
+	for i := range expected {
+		ok, err := cmd.verify(expected[i])
+		if err != nil {
+			return err
+		} else if !ok {
+			continue
+		}
+
+		cmd.printResult(expected[i], true)
+
+		return nil
+	}
+
+	cmd.printResult(expected[0], false)
+
+	return errors.Errorf("file does not match with document")
+}
+
+func (cmd *VerifyDocumentCommand) parseFlags() error {
+	switch d, s := len(cmd.Document.Bytes()) > 0, len(cmd.Seal.Bytes()) > 0; {
+	case d && s:
+		return errors.Errorf("--document and --seal can not be given together")
+	case !d && !s:
+		return errors.Errorf("--document or --seal should be given")
+	case s && len(cmd.NetworkID.NetworkID()) < 1:
+		return errors.Errorf("--network-id should be given with --seal")
+	}
+
+	cmd.documentid = currency.NilBig
+	if len(cmd.DocumentId) > 0 {
+		i, err := currency.NewBigFromString(cmd.DocumentId)
+		if err != nil {
+			return errors.Wrapf(err, "invalid document id, %q", cmd.DocumentId)
+		}
+		cmd.documentid = i
+	}
+
+	return nil
+}
+
+func (cmd *VerifyDocumentCommand) loadFromSeal() ([]verifiedFile, error) {
+	ops, err := loadOperations(cmd.Seal.Bytes(), cmd.NetworkID.NetworkID())
+	if err != nil {
+		return nil, err
+	}
+
+	var expected []verifiedFile
+	add := func(id currency.Big, fh blocksign.FileHash, size currency.Big) {
+		if !cmd.documentid.Equal(currency.NilBig) && !cmd.documentid.Equal(id) {
+			return
+		}
+
+		expected = append(expected, verifiedFile{DocumentId: id, FileHash: fh, Size: size})
+	}
+
+	for i := range ops {
+		switch t := ops[i].(type) {
+		case blocksign.CreateDocuments:
+			items := t.Fact().(blocksign.CreateDocumentsFact).Items()
+			for j := range items {
+				files := items[j].Files()
+				for k := range files {
+					add(items[j].DocumentId(), files[k].FileHash(), files[k].Size())
+				}
+			}
+		case blocksign.ReviseDocuments:
+			items := t.Fact().(blocksign.ReviseDocumentsFact).Items()
+			for j := range items {
+				add(items[j].DocumentId(), items[j].FileHash(), items[j].Size())
+			}
+		}
+	}
+
+	return expected, nil
+}
+
+// documentVerifiedFiles returns the files of document; the document, which
+// has multiple files, is verified by any of it's files.
+func documentVerifiedFiles(doc blocksign.DocumentData) []verifiedFile {
+	files := doc.Files()
+	if len(files) < 1 {
+		return []verifiedFile{{DocumentId: doc.Info().Index(), FileHash: doc.FileHash(), Size: doc.Size()}}
+	}
+
+	vfs := make([]verifiedFile, len(files))
+	for i := range files {
+		vfs[i] = verifiedFile{DocumentId: doc.Info().Index(), FileHash: files[i].FileHash(), Size: files[i].Size()}
+	}
+
+	return vfs
+}
+
+func (cmd *VerifyDocumentCommand) verify(vf verifiedFile) (bool, error) {
+	algo := vf.FileHash.Algorithm()
+	if vf.FileHash.IsLegacy() {
+		algo = blocksign.FileHashAlgorithm(cmd.HashAlgo)
+	}
+
+	fh, size, err := hashLocalFile(cmd.File, algo)
+	if err != nil {
+		return false, err
+	}
+
+	if !size.Equal(vf.Size) {
+		return false, nil
+	}
+
+	if vf.FileHash.IsLegacy() {
+		return strings.EqualFold(fh.Digest(), vf.FileHash.Digest()), nil
+	}
+
+	return fh.Equal(vf.FileHash), nil
+}
+
+func (cmd *VerifyDocumentCommand) printResult(vf verifiedFile, verified bool) {
+	currencycmds.PrettyPrint(cmd.Out, cmd.Pretty, map[string]interface{}{
+		"file":     cmd.File,
+		"document": vf,
+		"verified": verified,
+	})
+}
+
+// loadDocumentData decodes DocumentData from the response of digest document
+// api, DocumentValue or DocumentData itself.
+func loadDocumentData(b []byte) (blocksign.DocumentData, error) {
+	if len(bytes.TrimSpace(b)) < 1 {
+		return blocksign.DocumentData{}, errors.Errorf("empty input")
+	}
+
+	var m map[string]json.RawMessage
+	if err := jenc.Unmarshal(b, &m); err != nil {
+		return blocksign.DocumentData{}, errors.Wrap(err, "invalid document")
+	}
+
+	for _, k := range []string{"_embedded", "document"} {
+		if i, found := m[k]; found {
+			return loadDocumentData(i)
+		}
+	}
+
+	doc, err := blocksign.DecodeDocumentData(b, jenc)
+	if err != nil {
+		return blocksign.DocumentData{}, errors.Wrap(err, "invalid document")
+	}
+
+	return doc, nil
+}
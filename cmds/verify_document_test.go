@@ -0,0 +1,176 @@
+package cmds
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/util"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+)
+
+type testVerifyDocument struct {
+	suite.Suite
+	dir  string
+	file string
+	body []byte
+}
+
+func (t *testVerifyDocument) SetupTest() {
+	d, err := ioutil.TempDir("", "verify-document")
+	t.NoError(err)
+	t.dir = d
+
+	t.body = util.UUID().Bytes()
+	t.file = filepath.Join(d, "contract.pdf")
+	t.NoError(ioutil.WriteFile(t.file, t.body, 0o600))
+}
+
+func (t *testVerifyDocument) TearDownTest() {
+	_ = os.RemoveAll(t.dir)
+}
+
+func (t *testVerifyDocument) newDocumentData(fh blocksign.FileHash, size int64) blocksign.DocumentData {
+	creator := blocksign.MustAddress(util.UUID().String())
+
+	return blocksign.MustNewDocumentData(
+		blocksign.NewDocInfo(3, fh),
+		creator,
+		"user0",
+		"title",
+		currency.NewBig(size),
+		[]blocksign.DocSign{blocksign.MustNewDocSign(blocksign.MustAddress(util.UUID().String()), "user1", false)},
+	)
+}
+
+func (t *testVerifyDocument) TestHashLocalFile() {
+	fh, size, err := hashLocalFile(t.file, blocksign.FileHashSHA256)
+	t.NoError(err)
+
+	d := sha256.Sum256(t.body)
+	expected, err := blocksign.NewFileHash(blocksign.FileHashSHA256, d[:])
+	t.NoError(err)
+
+	t.Equal(expected, fh)
+	t.True(size.Equal(currency.NewBig(int64(len(t.body)))))
+
+	_, _, err = hashLocalFile(t.file, blocksign.FileHashAlgorithm("md5"))
+	t.Contains(err.Error(), "unknown filehash algorithm")
+}
+
+func (t *testVerifyDocument) TestCreateDocumentFromFile() {
+	cmd := NewCreateDocumentCommand()
+	cmd.File = t.file
+	cmd.HashAlgo = blocksign.FileHashBLAKE2b.String()
+	cmd.Title = "auto"
+	cmd.Size.Big = currency.NilBig
+
+	t.NoError(cmd.parseFile())
+	t.Equal(blocksign.FileHashBLAKE2b, cmd.FileHash.FH.Algorithm())
+	t.Equal("contract.pdf", cmd.Title)
+	t.True(cmd.Size.Big.Equal(currency.NewBig(int64(len(t.body)))))
+
+	cmd.Size.Big = currency.NewBig(1)
+	t.Contains(cmd.parseFile().Error(), "size does not match with file")
+}
+
+func (t *testVerifyDocument) TestCreateDocumentWithAttachments() {
+	attached := filepath.Join(t.dir, "appendix.pdf")
+	t.NoError(ioutil.WriteFile(attached, []byte("appendix"), 0o600))
+
+	var flag DocFileFlag
+	t.NoError(flag.UnmarshalText([]byte("sha256:" + strings.Repeat("a", 64) + ",3,scan.png")))
+
+	cmd := NewCreateDocumentCommand()
+	cmd.File = t.file
+	cmd.HashAlgo = blocksign.FileHashSHA256.String()
+	cmd.Title = "title"
+	cmd.Size.Big = currency.NilBig
+	cmd.Attachment = []DocFileFlag{flag}
+	cmd.AttachFile = []string{attached}
+
+	t.NoError(cmd.parseFile())
+	t.NoError(cmd.parseAttachments())
+
+	t.Equal(3, len(cmd.files))
+	t.Equal("contract.pdf", cmd.files[0].Name())
+	t.True(cmd.files[0].FileHash().Equal(cmd.FileHash.FH))
+	t.Equal("scan.png", cmd.files[1].Name())
+	t.Equal("appendix.pdf", cmd.files[2].Name())
+	t.True(cmd.files[2].Size().Equal(currency.NewBig(8)))
+
+	item := cmd.createItem()
+	t.IsType(blocksign.CreateDocumentsItemMultiFiles{}, item)
+	t.True(item.Size().Equal(currency.NewBig(int64(len(t.body)) + 11)))
+
+	var invalid DocFileFlag
+	t.Error(invalid.UnmarshalText([]byte("sha256:" + strings.Repeat("a", 64) + ",3")))
+}
+
+func (t *testVerifyDocument) TestCreateDocumentAutoWithoutFile() {
+	cmd := NewCreateDocumentCommand()
+	cmd.Size.Big = currency.NewBig(1)
+
+	t.Contains(cmd.parseFile().Error(), "--file is not given")
+}
+
+func (t *testVerifyDocument) TestLoadDocumentData() {
+	fh, size, err := hashLocalFile(t.file, blocksign.FileHashSHA3256)
+	t.NoError(err)
+
+	doc := t.newDocumentData(fh, int64(len(t.body)))
+	b, err := jsonenc.Marshal(doc)
+	t.NoError(err)
+
+	for _, i := range [][]byte{
+		b,
+		[]byte(fmt.Sprintf(`{"_hint":"findme","document":%s,"status":"draft","height":3}`, b)),
+		[]byte(fmt.Sprintf(`{"_embedded":{"document":%s},"_links":{}}`, b)),
+	} {
+		udoc, err := loadDocumentData(i)
+		t.NoError(err)
+		t.True(doc.FileHash().Equal(udoc.FileHash()))
+		t.True(size.Equal(udoc.Size()))
+	}
+}
+
+func (t *testVerifyDocument) TestVerify() {
+	fh, size, err := hashLocalFile(t.file, blocksign.FileHashSHA256)
+	t.NoError(err)
+
+	cmd := NewVerifyDocumentCommand()
+	cmd.File = t.file
+	cmd.HashAlgo = blocksign.FileHashSHA256.String()
+
+	ok, err := cmd.verify(verifiedFile{FileHash: fh, Size: size})
+	t.NoError(err)
+	t.True(ok)
+
+	// NOTE plain filehash without algorithm
+	ok, err = cmd.verify(verifiedFile{FileHash: blocksign.FileHash(fh.Digest()), Size: size})
+	t.NoError(err)
+	t.True(ok)
+
+	ok, err = cmd.verify(verifiedFile{FileHash: fh, Size: size.Add(currency.NewBig(1))})
+	t.NoError(err)
+	t.False(ok)
+
+	t.NoError(ioutil.WriteFile(t.file, bytes.Repeat([]byte("a"), len(t.body)), 0o600))
+
+	ok, err = cmd.verify(verifiedFile{FileHash: fh, Size: size})
+	t.NoError(err)
+	t.False(ok)
+}
+
+func TestVerifyDocument(t *testing.T) {
+	suite.Run(t, new(testVerifyDocument))
+}
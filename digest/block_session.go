@@ -32,6 +32,7 @@ type BlockSession struct {
 	accountModels   []mongo.WriteModel
 	documentModels  []mongo.WriteModel
 	documentsModels []mongo.WriteModel
+	pendingModels   []mongo.WriteModel
 	balanceModels   []mongo.WriteModel
 	statesValue     *sync.Map
 	documentList    []currency.Big
@@ -115,7 +116,13 @@ func (bs *BlockSession) Commit(ctx context.Context) error {
 		}
 	}
 
-	return nil
+	if len(bs.pendingModels) > 0 {
+		if err := bs.writeModels(ctx, defaultColNamePending, bs.pendingModels); err != nil {
+			return err
+		}
+	}
+
+	return bs.st.expireDocuments(bs.block.Height())
 }
 
 func (bs *BlockSession) Close() error {
@@ -193,6 +200,7 @@ func (bs *BlockSession) prepareAccounts() error {
 	var balanceModels []mongo.WriteModel
 	var documentModels []mongo.WriteModel
 	var documentsModels []mongo.WriteModel
+	var pendingModels []mongo.WriteModel
 	for i := range bs.block.States() {
 		st := bs.block.States()[i]
 		switch {
@@ -217,12 +225,18 @@ func (bs *BlockSession) prepareAccounts() error {
 
 				documentModels = append(documentModels, j...)
 			}
-		case blocksign.IsStateDocumentsKey(st.Key()):
+		case blocksign.IsStateDocumentsKey(st.Key()), blocksign.IsStateDocumentsPageKey(st.Key()):
 			if j, err := bs.handleDocumentsState(st); err != nil {
 				return err
 			} else {
 				documentsModels = append(documentsModels, j...)
 			}
+		case blocksign.IsStatePendingDocumentsKey(st.Key()):
+			if j, err := bs.handleDocumentsState(st); err != nil {
+				return err
+			} else {
+				pendingModels = append(pendingModels, j...)
+			}
 		default:
 			continue
 		}
@@ -239,6 +253,10 @@ func (bs *BlockSession) prepareAccounts() error {
 		bs.documentsModels = documentsModels
 	}
 
+	if len(pendingModels) > 0 {
+		bs.pendingModels = pendingModels
+	}
+
 	return nil
 }
 
@@ -332,6 +350,7 @@ func (bs *BlockSession) close() error {
 	bs.balanceModels = nil
 	bs.documentModels = nil
 	bs.documentsModels = nil
+	bs.pendingModels = nil
 
 	return bs.st.Close()
 }
@@ -27,6 +27,7 @@ var (
 	templateSignedAtString   = "2020-10-08T07:53:26Z"
 	templateSignedAt         time.Time
 	templateFileHash         = blocksign.FileHash("abcd")
+	templateAttachedFileHash = blocksign.FileHash("efgh")
 	templateSigncode         = "tigers"
 	templateTitle            = "my_document"
 	templateSize             = currency.NewBig(555)
@@ -49,12 +50,30 @@ func init() {
 type Builder struct {
 	enc       encoder.Encoder
 	networkID base.NetworkID
+	cp        *currency.CurrencyPool
+	policy    blocksign.DocumentPolicy
+	document  func(currency.Big /* document id */) (blocksign.DocumentData, bool, error)
 }
 
 func NewBuilder(enc encoder.Encoder, networkID base.NetworkID) Builder {
 	return Builder{enc: enc, networkID: networkID}
 }
 
+// WithFee makes Builder to output the fee of document operations; policy is
+// the DocumentPolicy stored in chain and document returns the registered
+// document for the fee of signing.
+func (bl Builder) WithFee(
+	cp *currency.CurrencyPool,
+	policy blocksign.DocumentPolicy,
+	document func(currency.Big) (blocksign.DocumentData, bool, error),
+) Builder {
+	bl.cp = cp
+	bl.policy = policy
+	bl.document = document
+
+	return bl
+}
+
 func (bl Builder) FactTemplate(ht hint.Hint) (Hal, error) {
 	switch ht.Type() {
 	case currency.CreateAccountsType:
@@ -69,8 +88,24 @@ func (bl Builder) FactTemplate(ht hint.Hint) (Hal, error) {
 		return bl.templateCurrencyPolicyUpdaterFact(), nil
 	case blocksign.CreateDocumentsType:
 		return bl.templateCreateDocumentsFact(), nil
+	case blocksign.CreateDocumentsItemMultiFilesType:
+		return bl.templateCreateDocumentsMultiFilesFact(), nil
 	case blocksign.SignDocumentsType:
 		return bl.templateSignDocumentsFact(), nil
+	case blocksign.RejectDocumentsType:
+		return bl.templateRejectDocumentsFact(), nil
+	case blocksign.RevokeSignDocumentsType:
+		return bl.templateRevokeSignDocumentsFact(), nil
+	case blocksign.TransferDocumentsType:
+		return bl.templateTransferDocumentsFact(), nil
+	case blocksign.UpdateDocumentSignersType:
+		return bl.templateUpdateDocumentSignersFact(), nil
+	case blocksign.ReviseDocumentsType:
+		return bl.templateReviseDocumentsFact(), nil
+	case blocksign.CancelDocumentsType:
+		return bl.templateCancelDocumentsFact(), nil
+	case blocksign.DocumentPolicyUpdaterType:
+		return bl.templateDocumentPolicyUpdaterFact(), nil
 	default:
 		return nil, errors.Errorf("unknown operation, %q", ht)
 	}
@@ -199,12 +234,82 @@ func (Builder) templateCreateDocumentsFact() Hal {
 	})
 }
 
+func (Builder) templateCreateDocumentsMultiFilesFact() Hal {
+	fact := blocksign.NewCreateDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.CreateDocumentsItem{blocksign.NewCreateDocumentsItemMultiFiles(
+			templateId,
+			templateSigncode,
+			templateTitle,
+			[]blocksign.DocFile{
+				blocksign.NewDocFile(templateFileHash, "main.pdf", templateSize),
+				blocksign.NewDocFile(templateAttachedFileHash, "appendix.pdf", templateSize),
+			},
+			[]base.Address{templateSigner},
+			[]string{templateSignerSigncode},
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":                templateToken,
+		"sender":               templateSender,
+		"items.files.filehash": templateFileHash,
+		"currency":             templateCurrencyID,
+	})
+}
+
 func (Builder) templateSignDocumentsFact() Hal {
 
 	fact := blocksign.NewSignDocumentsFact(
 		templateToken,
 		templateSender,
 		[]blocksign.SignDocumentItem{blocksign.NewSignDocumentsItemSingleFile(
+			templateId,
+			templateOwner,
+			templateSignerSigncode,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":     templateToken,
+		"sender":    templateSender,
+		"items.big": templateBig,
+		"currency":  templateCurrencyID,
+	})
+}
+
+func (Builder) templateRejectDocumentsFact() Hal {
+
+	fact := blocksign.NewRejectDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.SignDocumentItem{blocksign.NewRejectDocumentsItemSingleFile(
+			templateId,
+			templateOwner,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":     templateToken,
+		"sender":    templateSender,
+		"items.big": templateBig,
+		"currency":  templateCurrencyID,
+	})
+}
+
+func (Builder) templateRevokeSignDocumentsFact() Hal {
+
+	fact := blocksign.NewRevokeSignDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.SignDocumentItem{blocksign.NewRevokeSignDocumentsItemSingleFile(
 			templateId,
 			templateOwner,
 			templateCurrencyID,
@@ -220,6 +325,103 @@ func (Builder) templateSignDocumentsFact() Hal {
 	})
 }
 
+func (Builder) templateTransferDocumentsFact() Hal {
+	fact := blocksign.NewTransferDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.TransferDocumentsItem{blocksign.NewTransferDocumentsItemSingleFile(
+			templateId,
+			templateSender,
+			templateReceiver,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":          templateToken,
+		"sender":         templateSender,
+		"items.receiver": templateReceiver,
+		"currency":       templateCurrencyID,
+	})
+}
+
+func (Builder) templateUpdateDocumentSignersFact() Hal {
+	fact := blocksign.NewUpdateDocumentSignersFact(
+		templateToken,
+		templateSender,
+		[]blocksign.UpdateDocumentSignersItem{blocksign.NewUpdateDocumentSignersItemSingleFile(
+			templateId,
+			templateSender,
+			[]base.Address{templateSigner},
+			[]string{templateSignerSigncode},
+			nil,
+			nil,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":     templateToken,
+		"sender":    templateSender,
+		"items.add": templateSigner,
+		"currency":  templateCurrencyID,
+	})
+}
+
+func (Builder) templateReviseDocumentsFact() Hal {
+	fact := blocksign.NewReviseDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.ReviseDocumentsItem{blocksign.NewReviseDocumentsItemSingleFile(
+			templateId,
+			templateSender,
+			templateFileHash,
+			templateTitle,
+			templateSize,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":          templateToken,
+		"sender":         templateSender,
+		"items.filehash": templateFileHash,
+		"currency":       templateCurrencyID,
+	})
+}
+
+func (Builder) templateCancelDocumentsFact() Hal {
+	fact := blocksign.NewCancelDocumentsFact(
+		templateToken,
+		templateSender,
+		[]blocksign.CancelDocumentsItem{blocksign.NewCancelDocumentsItemSingleFile(
+			templateId,
+			templateSender,
+			false,
+			templateCurrencyID,
+		)},
+	)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token":    templateToken,
+		"sender":   templateSender,
+		"currency": templateCurrencyID,
+	})
+}
+
+func (Builder) templateDocumentPolicyUpdaterFact() Hal {
+	fact := blocksign.NewDocumentPolicyUpdaterFact(templateToken, blocksign.DefaultDocumentPolicy)
+
+	hal := NewBaseHal(fact, HalLink{})
+	return hal.AddExtras("default", map[string]interface{}{
+		"token": templateToken,
+	})
+}
+
 func (bl Builder) BuildFact(b []byte) (Hal, error) {
 	var fact base.Fact
 	if hinter, err := bl.enc.Decode(b); err != nil {
@@ -245,6 +447,20 @@ func (bl Builder) BuildFact(b []byte) (Hal, error) {
 		return bl.buildFactCreateDocuments(t)
 	case blocksign.SignDocumentsFact:
 		return bl.buildFactSignDocuments(t)
+	case blocksign.RejectDocumentsFact:
+		return bl.buildFactRejectDocuments(t)
+	case blocksign.RevokeSignDocumentsFact:
+		return bl.buildFactRevokeSignDocuments(t)
+	case blocksign.TransferDocumentsFact:
+		return bl.buildFactTransferDocuments(t)
+	case blocksign.UpdateDocumentSignersFact:
+		return bl.buildFactUpdateDocumentSigners(t)
+	case blocksign.ReviseDocumentsFact:
+		return bl.buildFactReviseDocuments(t)
+	case blocksign.CancelDocumentsFact:
+		return bl.buildFactCancelDocuments(t)
+	case blocksign.DocumentPolicyUpdaterFact:
+		return bl.buildFactDocumentPolicyUpdater(t)
 	default:
 		return nil, errors.Errorf("unknown fact, %T", fact)
 	}
@@ -307,8 +523,28 @@ func (bl Builder) buildFactCreateDocuments(fact blocksign.CreateDocumentsFact) (
 	items := make([]blocksign.CreateDocumentsItem, len(fact.Items()))
 	for i := range fact.Items() {
 		item := fact.Items()[i]
-		if len(item.FileHash()) < 1 {
-			return nil, errors.Errorf("empty FileHash")
+		if err := item.FileHash().IsValid(nil); err != nil {
+			return nil, err
+		}
+
+		signcodes := commitSigncodes(item.Signcodes())
+
+		if _, ok := item.(blocksign.CreateDocumentsItemMultiFiles); ok {
+			items[i] = blocksign.NewCreateDocumentsItemMultiFiles(
+				item.DocumentId(),
+				item.Signcode(),
+				item.Title(),
+				item.Files(),
+				item.Signers(),
+				signcodes,
+				item.Currency(),
+			).WithExpiry(item.Expiry()).
+				WithSigningMode(item.SigningMode()).
+				WithQuorum(item.Weights(), item.Threshold()).
+				WithMetadata(item.Metadata()).
+				WithSponsored(item.Sponsored())
+
+			continue
 		}
 
 		items[i] = blocksign.NewCreateDocumentsItemSingleFile(
@@ -318,9 +554,13 @@ func (bl Builder) buildFactCreateDocuments(fact blocksign.CreateDocumentsFact) (
 			item.Title(),
 			item.Size(),
 			item.Signers(),
-			item.Signcodes(),
+			signcodes,
 			item.Currency(),
-		)
+		).WithExpiry(item.Expiry()).
+			WithSigningMode(item.SigningMode()).
+			WithQuorum(item.Weights(), item.Threshold()).
+			WithMetadata(item.Metadata()).
+			WithSponsored(item.Sponsored())
 	}
 
 	nfact := blocksign.NewCreateDocumentsFact(token, fact.Sender(), items)
@@ -329,6 +569,15 @@ func (bl Builder) buildFactCreateDocuments(fact blocksign.CreateDocumentsFact) (
 		return nil, err
 	}
 
+	var fee map[currency.CurrencyID][2]currency.Big
+	if bl.cp != nil {
+		i, err := blocksign.CalculateDocumentItemsFee(bl.cp, bl.policy, nfact.Items())
+		if err != nil {
+			return nil, err
+		}
+		fee = i
+	}
+
 	var hal Hal
 	hal = NewBaseHal(nil, HalLink{})
 	op, err := blocksign.NewCreateDocuments(
@@ -343,6 +592,10 @@ func (bl Builder) buildFactCreateDocuments(fact blocksign.CreateDocumentsFact) (
 	}
 	hal = hal.SetInterface(op)
 
+	if fee != nil {
+		hal = hal.AddExtras("fee", builderFee(fee))
+	}
+
 	return hal.
 		AddExtras("default", map[string]interface{}{
 			"fact_signs.signer":    templatePublickey,
@@ -367,6 +620,7 @@ func (bl Builder) buildFactSignDocuments(fact blocksign.SignDocumentsFact) (Hal,
 		items[i] = blocksign.NewSignDocumentsItemSingleFile(
 			item.DocumentId(),
 			item.Owner(),
+			item.Signcode(),
 			item.Currency(),
 		)
 	}
@@ -377,6 +631,11 @@ func (bl Builder) buildFactSignDocuments(fact blocksign.SignDocumentsFact) (Hal,
 		return nil, err
 	}
 
+	fee, err := bl.signDocumentsFee(nfact)
+	if err != nil {
+		return nil, err
+	}
+
 	var hal Hal
 	hal = NewBaseHal(nil, HalLink{})
 	op, err := blocksign.NewSignDocuments(
@@ -391,6 +650,106 @@ func (bl Builder) buildFactSignDocuments(fact blocksign.SignDocumentsFact) (Hal,
 	}
 	hal = hal.SetInterface(op)
 
+	if fee != nil {
+		hal = hal.AddExtras("fee", builderFee(fee))
+	}
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (bl Builder) buildFactRejectDocuments(fact blocksign.RejectDocumentsFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]blocksign.SignDocumentItem, len(fact.Items()))
+	for i := range fact.Items() {
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
+		}
+
+		items[i] = blocksign.NewRejectDocumentsItemSingleFile(
+			item.DocumentId(),
+			item.Owner(),
+			item.Currency(),
+		)
+	}
+
+	nfact := blocksign.NewRejectDocumentsFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactRejectDocuments(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewRejectDocuments(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (bl Builder) buildFactRevokeSignDocuments(fact blocksign.RevokeSignDocumentsFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]blocksign.SignDocumentItem, len(fact.Items()))
+	for i := range fact.Items() {
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
+		}
+
+		items[i] = blocksign.NewRevokeSignDocumentsItemSingleFile(
+			item.DocumentId(),
+			item.Owner(),
+			item.Currency(),
+		)
+	}
+
+	nfact := blocksign.NewRevokeSignDocumentsFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactRevokeSignDocuments(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewRevokeSignDocuments(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
 	return hal.
 		AddExtras("default", map[string]interface{}{
 			"fact_signs.signer":    templatePublickey,
@@ -647,39 +1006,416 @@ func (Builder) isValidFactCreateDocuments(fact blocksign.CreateDocumentsFact) er
 	}
 
 	for i := range fact.Items() {
-		if same := fact.Items()[i].FileHash().Equal(templateFileHash); same {
-			return errors.Errorf("Please set filehash; filehash is same with template default")
+		files := fact.Items()[i].Files()
+		for j := range files {
+			if fh := files[j].FileHash(); fh.Equal(templateFileHash) || fh.Equal(templateAttachedFileHash) {
+				return errors.Errorf("Please set filehash; filehash is same with template default")
+			}
 		}
 	}
 
 	return nil
 }
 
-func (Builder) isValidFactSignDocuments(fact blocksign.SignDocumentsFact) error {
-	if err := fact.IsValid(nil); err != nil {
-		return err
-	}
-
-	if bytes.Equal(fact.Token(), templateToken) {
-		return errors.Errorf("Please set token; token same with template default")
-	}
-
-	if fact.Sender().Equal(templateSender) {
-		return errors.Errorf("Please set sender; sender is same with template default")
+func (bl Builder) buildFactTransferDocuments(fact blocksign.TransferDocumentsFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
 	}
 
+	items := make([]blocksign.TransferDocumentsItem, len(fact.Items()))
 	for i := range fact.Items() {
-		if same := fact.Items()[i].Owner().Equal(templateOwner); same {
-			return errors.Errorf("Please set owner; owner is same with template default")
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
 		}
-	}
 
-	return nil
-}
+		items[i] = blocksign.NewTransferDocumentsItemSingleFile(
+			item.DocumentId(),
+			fact.Sender(),
+			item.Receiver(),
+			item.Currency(),
+		)
+	}
 
-func (bl Builder) BuildOperation(b []byte) (Hal, error) {
-	var op operation.Operation
-	if hinter, err := bl.enc.Decode(b); err != nil {
+	nfact := blocksign.NewTransferDocumentsFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactTransferDocuments(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewTransferDocuments(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (Builder) isValidFactSignDocuments(fact blocksign.SignDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		if same := fact.Items()[i].Owner().Equal(templateOwner); same {
+			return errors.Errorf("Please set owner; owner is same with template default")
+		}
+	}
+
+	return nil
+}
+
+func (Builder) isValidFactRejectDocuments(fact blocksign.RejectDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		if same := fact.Items()[i].Owner().Equal(templateOwner); same {
+			return errors.Errorf("Please set owner; owner is same with template default")
+		}
+	}
+
+	return nil
+}
+
+func (Builder) isValidFactRevokeSignDocuments(fact blocksign.RevokeSignDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		if same := fact.Items()[i].Owner().Equal(templateOwner); same {
+			return errors.Errorf("Please set owner; owner is same with template default")
+		}
+	}
+
+	return nil
+}
+
+func (Builder) isValidFactTransferDocuments(fact blocksign.TransferDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		if same := fact.Items()[i].Receiver().Equal(templateReceiver); same {
+			return errors.Errorf("Please set receiver; receiver is same with template default")
+		}
+	}
+
+	return nil
+}
+
+func (bl Builder) buildFactUpdateDocumentSigners(fact blocksign.UpdateDocumentSignersFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]blocksign.UpdateDocumentSignersItem, len(fact.Items()))
+	for i := range fact.Items() {
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
+		}
+
+		items[i] = blocksign.NewUpdateDocumentSignersItemSingleFile(
+			item.DocumentId(),
+			fact.Sender(),
+			item.Adds(),
+			commitSigncodes(item.Signcodes()),
+			item.Weights(),
+			item.Removes(),
+			item.Currency(),
+		)
+	}
+
+	nfact := blocksign.NewUpdateDocumentSignersFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactUpdateDocumentSigners(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewUpdateDocumentSigners(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (Builder) isValidFactUpdateDocumentSigners(fact blocksign.UpdateDocumentSignersFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		for j := range fact.Items()[i].Adds() {
+			if fact.Items()[i].Adds()[j].Equal(templateSigner) {
+				return errors.Errorf("Please set signer; signer is same with template default")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bl Builder) buildFactReviseDocuments(fact blocksign.ReviseDocumentsFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]blocksign.ReviseDocumentsItem, len(fact.Items()))
+	for i := range fact.Items() {
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
+		}
+
+		items[i] = blocksign.NewReviseDocumentsItemSingleFile(
+			item.DocumentId(),
+			fact.Sender(),
+			item.FileHash(),
+			item.Title(),
+			item.Size(),
+			item.Currency(),
+		)
+	}
+
+	nfact := blocksign.NewReviseDocumentsFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactReviseDocuments(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewReviseDocuments(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (Builder) isValidFactReviseDocuments(fact blocksign.ReviseDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	for i := range fact.Items() {
+		if same := fact.Items()[i].FileHash().Equal(templateFileHash); same {
+			return errors.Errorf("Please set filehash; filehash is same with template default")
+		}
+	}
+
+	return nil
+}
+
+func (bl Builder) buildFactCancelDocuments(fact blocksign.CancelDocumentsFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]blocksign.CancelDocumentsItem, len(fact.Items()))
+	for i := range fact.Items() {
+		item := fact.Items()[i]
+		if (item.DocumentId() == currency.Big{}) {
+			return nil, errors.Errorf("empty documentid")
+		}
+
+		items[i] = blocksign.NewCancelDocumentsItemSingleFile(
+			item.DocumentId(),
+			fact.Sender(),
+			item.Remove(),
+			item.Currency(),
+		)
+	}
+
+	nfact := blocksign.NewCancelDocumentsFact(token, fact.Sender(), items)
+	nfact = nfact.Rebuild()
+	if err = bl.isValidFactCancelDocuments(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewCancelDocuments(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (bl Builder) buildFactDocumentPolicyUpdater(fact blocksign.DocumentPolicyUpdaterFact) (Hal, error) {
+	token, err := bl.checkToken(fact.Token())
+	if err != nil {
+		return nil, err
+	}
+
+	nfact := blocksign.NewDocumentPolicyUpdaterFact(token, fact.Policy())
+	if err = bl.isValidFactDocumentPolicyUpdater(nfact); err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(nil, HalLink{})
+	op, err := blocksign.NewDocumentPolicyUpdater(
+		nfact,
+		[]operation.FactSign{
+			operation.RawBaseFactSign(templatePublickey, templateSignature, templateSignedAt),
+		},
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.SetInterface(op)
+
+	return hal.
+		AddExtras("default", map[string]interface{}{
+			"fact_signs.signer":    templatePublickey,
+			"fact_signs.signature": templateSignature,
+		}).
+		AddExtras("signature_base", operation.NewBytesForFactSignature(nfact, bl.networkID)), nil
+}
+
+func (Builder) isValidFactDocumentPolicyUpdater(fact blocksign.DocumentPolicyUpdaterFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	return nil
+}
+
+func (Builder) isValidFactCancelDocuments(fact blocksign.CancelDocumentsFact) error {
+	if err := fact.IsValid(nil); err != nil {
+		return err
+	}
+
+	if bytes.Equal(fact.Token(), templateToken) {
+		return errors.Errorf("Please set token; token same with template default")
+	}
+
+	if fact.Sender().Equal(templateSender) {
+		return errors.Errorf("Please set sender; sender is same with template default")
+	}
+
+	return nil
+}
+
+func (bl Builder) BuildOperation(b []byte) (Hal, error) {
+	var op operation.Operation
+	if hinter, err := bl.enc.Decode(b); err != nil {
 		return nil, err
 	} else if f, ok := hinter.(operation.Operation); !ok {
 		return nil, errors.Errorf("not operation.Operation, %T", hinter)
@@ -705,6 +1441,20 @@ func (bl Builder) BuildOperation(b []byte) (Hal, error) {
 			hal, err = bl.buildCreateDocumets(t)
 		case blocksign.SignDocuments:
 			hal, err = bl.buildSignDocumets(t)
+		case blocksign.RejectDocuments:
+			hal, err = bl.buildRejectDocuments(t)
+		case blocksign.RevokeSignDocuments:
+			hal, err = bl.buildRevokeSignDocuments(t)
+		case blocksign.TransferDocuments:
+			hal, err = bl.buildTransferDocuments(t)
+		case blocksign.UpdateDocumentSigners:
+			hal, err = bl.buildUpdateDocumentSigners(t)
+		case blocksign.ReviseDocuments:
+			hal, err = bl.buildReviseDocuments(t)
+		case blocksign.CancelDocuments:
+			hal, err = bl.buildCancelDocuments(t)
+		case blocksign.DocumentPolicyUpdater:
+			hal, err = bl.buildDocumentPolicyUpdater(t)
 		default:
 			return errors.Errorf("unknown operation.Operation, %T", t)
 		}
@@ -831,6 +1581,273 @@ func (bl Builder) buildSignDocumets(op blocksign.SignDocuments) (Hal, error) {
 	}
 }
 
+func (bl Builder) buildRejectDocuments(op blocksign.RejectDocuments) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewRejectDocuments(op.Fact().(blocksign.RejectDocumentsFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactRejectDocuments(nop.Fact().(blocksign.RejectDocumentsFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildRevokeSignDocuments(op blocksign.RevokeSignDocuments) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewRevokeSignDocuments(op.Fact().(blocksign.RevokeSignDocumentsFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactRevokeSignDocuments(nop.Fact().(blocksign.RevokeSignDocumentsFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildTransferDocuments(op blocksign.TransferDocuments) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewTransferDocuments(op.Fact().(blocksign.TransferDocumentsFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactTransferDocuments(nop.Fact().(blocksign.TransferDocumentsFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildUpdateDocumentSigners(op blocksign.UpdateDocumentSigners) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewUpdateDocumentSigners(
+		op.Fact().(blocksign.UpdateDocumentSignersFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactUpdateDocumentSigners(nop.Fact().(blocksign.UpdateDocumentSignersFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildReviseDocuments(op blocksign.ReviseDocuments) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewReviseDocuments(
+		op.Fact().(blocksign.ReviseDocumentsFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactReviseDocuments(nop.Fact().(blocksign.ReviseDocumentsFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildCancelDocuments(op blocksign.CancelDocuments) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewCancelDocuments(
+		op.Fact().(blocksign.CancelDocumentsFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactCancelDocuments(nop.Fact().(blocksign.CancelDocumentsFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+func (bl Builder) buildDocumentPolicyUpdater(op blocksign.DocumentPolicyUpdater) (Hal, error) {
+	fs := bl.updateFactSigns(op.Signs())
+
+	if nop, err := blocksign.NewDocumentPolicyUpdater(
+		op.Fact().(blocksign.DocumentPolicyUpdaterFact), fs, op.Memo); err != nil {
+		return nil, err
+	} else if err := nop.IsValid(bl.networkID); err != nil {
+		return nil, err
+	} else if err := bl.isValidFactDocumentPolicyUpdater(nop.Fact().(blocksign.DocumentPolicyUpdaterFact)); err != nil {
+		return nil, err
+	} else {
+		return NewBaseHal(nop, HalLink{}), nil
+	}
+}
+
+// signDocumentsFee calculates the fee of SignDocumentsFact; without
+// CurrencyPool or unknown document, fee is not calculated.
+func (bl Builder) signDocumentsFee(fact blocksign.SignDocumentsFact) (map[currency.CurrencyID][2]currency.Big, error) {
+	if bl.cp == nil || bl.document == nil {
+		return nil, nil
+	}
+
+	items := fact.Items()
+	docs := make([]blocksign.DocumentData, len(items))
+	for i := range items {
+		switch doc, found, err := bl.document(items[i].DocumentId()); {
+		case err != nil:
+			return nil, err
+		case !found:
+			return nil, nil
+		default:
+			docs[i] = doc
+		}
+	}
+
+	required, _, err := blocksign.CalculateSignDocumentsRequired(bl.cp, bl.policy, items, docs)
+
+	return required, err
+}
+
+// Required returns the sender of fact and the amounts, which are required to
+// the balance of sender by currency id; the first amount will be subtracted
+// from the balance, including the fee and the second is the fee.
+func (bl Builder) Required(fact base.Fact) (base.Address, map[currency.CurrencyID][2]currency.Big, error) {
+	var sender base.Address
+	var required map[currency.CurrencyID][2]currency.Big
+	var err error
+
+	switch t := fact.(type) {
+	case currency.CreateAccountsFact:
+		items := make([]currency.AmountsItem, len(t.Items()))
+		for i := range t.Items() {
+			items[i] = t.Items()[i]
+		}
+
+		sender = t.Sender()
+		required, err = currency.CalculateItemsFee(bl.cp, items)
+	case currency.TransfersFact:
+		items := make([]currency.AmountsItem, len(t.Items()))
+		for i := range t.Items() {
+			items[i] = t.Items()[i]
+		}
+
+		sender = t.Sender()
+		required, err = currency.CalculateItemsFee(bl.cp, items)
+	case currency.KeyUpdaterFact:
+		sender = t.Target()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, []currency.CurrencyID{t.Currency()})
+	case blocksign.CreateDocumentsFact:
+		sender = t.Sender()
+		required, _, err = blocksign.CalculateCreateDocumentsRequired(bl.cp, bl.policy, t.Items())
+	case blocksign.SignDocumentsFact:
+		sender = t.Sender()
+		required, err = bl.signDocumentsRequired(t.Items())
+	case blocksign.RejectDocumentsFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	case blocksign.RevokeSignDocumentsFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	case blocksign.TransferDocumentsFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	case blocksign.UpdateDocumentSignersFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	case blocksign.ReviseDocumentsFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	case blocksign.CancelDocumentsFact:
+		cids := make([]currency.CurrencyID, len(t.Items()))
+		for i := range t.Items() {
+			cids[i] = t.Items()[i].Currency()
+		}
+
+		sender = t.Sender()
+		required, err = blocksign.CalculateFlatItemsFee(bl.cp, cids)
+	default:
+		return nil, nil, errors.Errorf("fee of fact not supported, %T", fact)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sender, required, nil
+}
+
+// signDocumentsRequired returns the amounts of SignDocumentsFact; unlike
+// signDocumentsFee, unknown document is error.
+func (bl Builder) signDocumentsRequired(
+	items []blocksign.SignDocumentItem,
+) (map[currency.CurrencyID][2]currency.Big, error) {
+	docs := make([]blocksign.DocumentData, len(items))
+	for i := range items {
+		if bl.document == nil {
+			return nil, errors.Errorf("document not found, %v", items[i].DocumentId())
+		}
+
+		switch doc, found, err := bl.document(items[i].DocumentId()); {
+		case err != nil:
+			return nil, err
+		case !found:
+			return nil, errors.Errorf("document not found, %v", items[i].DocumentId())
+		default:
+			docs[i] = doc
+		}
+	}
+
+	required, _, err := blocksign.CalculateSignDocumentsRequired(bl.cp, bl.policy, items, docs)
+
+	return required, err
+}
+
+// builderFee shows the fee by currency id.
+func builderFee(required map[currency.CurrencyID][2]currency.Big) map[currency.CurrencyID]currency.Big {
+	fee := map[currency.CurrencyID]currency.Big{}
+	for cid := range required {
+		fee[cid] = required[cid][1]
+	}
+
+	return fee
+}
+
+// commitSigncodes returns the commitments of signer signcodes, so the
+// signcodes are not published in operation.
+func commitSigncodes(signcodes []string) []string {
+	cs := make([]string, len(signcodes))
+	for i := range signcodes {
+		cs[i] = blocksign.SignCode(signcodes[i]).Commitment().String()
+	}
+
+	return cs
+}
+
 // checkToken checks token is valid; empty token will be updated with current
 // time.
 func (Builder) checkToken(token []byte) ([]byte, error) {
@@ -5,13 +5,17 @@ package digest
 import (
 	"bytes"
 	"encoding/base64"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/btcsuite/btcutil/base58"
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/base/key"
 	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
 	"github.com/spikeekips/mitum/util"
 	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
 	"github.com/spikeekips/mitum/util/localtime"
@@ -377,6 +381,139 @@ func (t *testBuilder) TestBuildFactCurrencyPolicyUpdater() {
 	_ = t.buildOperation(uop, sb.([]byte))
 }
 
+func (t *testBuilder) TestBuildFactCreateDocumentsFee() {
+	cid := currency.CurrencyID("SHOWME")
+
+	cp := currency.NewCurrencyPool()
+	{
+		de := currency.NewCurrencyDesign(
+			currency.NewAmount(currency.NewBig(33), cid),
+			currency.NewTestAddress(),
+			currency.NewCurrencyPolicy(currency.ZeroBig, currency.NewFixedFeeer(currency.NewTestAddress(), currency.NewBig(3))),
+		)
+
+		st, err := state.NewStateV0(currency.StateKeyCurrencyDesign(de.Currency()), nil, base.Height(33))
+		t.NoError(err)
+
+		nst, err := currency.SetStateCurrencyDesignValue(st, de)
+		t.NoError(err)
+
+		t.NoError(cp.Set(nst))
+	}
+
+	fact := blocksign.NewCreateDocumentsFact(
+		util.UUID().Bytes(),
+		currency.NewTestAddress(),
+		[]blocksign.CreateDocumentsItem{blocksign.NewCreateDocumentsItemSingleFile(
+			blocksign.FileHash("sha256:"+strings.Repeat("a", 64)),
+			templateId,
+			templateSigncode,
+			templateTitle,
+			templateSize,
+			nil,
+			nil,
+			cid,
+		)},
+	)
+
+	bl := NewBuilder(t.JSONEnc, t.networkID)
+
+	hal, err := bl.buildFactCreateDocuments(fact)
+	t.NoError(err)
+	_, found := hal.Extras()["fee"]
+	t.False(found)
+
+	hal, err = bl.WithFee(cp, blocksign.DefaultDocumentPolicy, nil).buildFactCreateDocuments(fact)
+	t.NoError(err)
+
+	fee, found := hal.Extras()["fee"]
+	t.True(found)
+	t.Equal(currency.NewBig(3), fee.(map[currency.CurrencyID]currency.Big)[cid])
+}
+
+func (t *testBuilder) TestRequired() {
+	cid := currency.CurrencyID("SHOWME")
+
+	cp := currency.NewCurrencyPool()
+	{
+		de := currency.NewCurrencyDesign(
+			currency.NewAmount(currency.NewBig(33), cid),
+			currency.NewTestAddress(),
+			currency.NewCurrencyPolicy(currency.ZeroBig, currency.NewFixedFeeer(currency.NewTestAddress(), currency.NewBig(3))),
+		)
+
+		st, err := state.NewStateV0(currency.StateKeyCurrencyDesign(de.Currency()), nil, base.Height(33))
+		t.NoError(err)
+
+		nst, err := currency.SetStateCurrencyDesignValue(st, de)
+		t.NoError(err)
+
+		t.NoError(cp.Set(nst))
+	}
+
+	sender := currency.NewTestAddress()
+	creator := currency.NewTestAddress()
+
+	doc := blocksign.MustNewDocumentData(
+		blocksign.NewDocInfo(3, blocksign.MustFileHash("ABCD")),
+		creator,
+		"user0",
+		"title",
+		currency.NewBig(10),
+		[]blocksign.DocSign{blocksign.MustNewDocSign(sender, "user1", false)},
+	)
+
+	var escrowed bool
+	bl := NewBuilder(t.JSONEnc, t.networkID).WithFee(cp, blocksign.DefaultDocumentPolicy, func(id currency.Big) (blocksign.DocumentData, bool, error) {
+		if !id.Equal(currency.NewBig(3)) {
+			return blocksign.DocumentData{}, false, nil
+		}
+
+		if escrowed {
+			return doc.WithEscrow(currency.NewAmount(currency.NewBig(3), cid)), true, nil
+		}
+
+		return doc, true, nil
+	})
+
+	newSignFact := func(id int64) blocksign.SignDocumentsFact {
+		return blocksign.NewSignDocumentsFact(util.UUID().Bytes(), sender, []blocksign.SignDocumentItem{
+			blocksign.NewSignDocumentsItemSingleFile(currency.NewBig(id), creator, "user1", cid),
+		})
+	}
+
+	s, required, err := bl.Required(newSignFact(3))
+	t.NoError(err)
+	t.True(s.Equal(sender))
+	t.Equal([2]currency.Big{currency.NewBig(3), currency.NewBig(3)}, required[cid])
+
+	// NOTE escrow of document pays fee
+	escrowed = true
+	_, required, err = bl.Required(newSignFact(3))
+	t.NoError(err)
+	t.Empty(required)
+
+	_, _, err = bl.Required(newSignFact(4))
+	t.Contains(err.Error(), "document not found")
+
+	amounts, enough := requiredAmounts(
+		map[currency.CurrencyID][2]currency.Big{cid: {currency.NewBig(3), currency.NewBig(3)}},
+		map[currency.CurrencyID]currency.Big{cid: currency.NewBig(2)},
+	)
+	t.False(enough)
+	t.False(amounts[cid].Enough)
+	t.Equal(currency.NewBig(2), amounts[cid].Balance)
+
+	_, enough = requiredAmounts(
+		map[currency.CurrencyID][2]currency.Big{cid: {currency.NewBig(3), currency.NewBig(3)}},
+		map[currency.CurrencyID]currency.Big{cid: currency.NewBig(3)},
+	)
+	t.True(enough)
+
+	_, _, err = bl.Required(currency.NewCurrencyPolicyUpdaterFact(util.UUID().Bytes(), cid, currency.NewCurrencyPolicy(currency.ZeroBig, currency.NewNilFeeer())))
+	t.Contains(err.Error(), "not supported")
+}
+
 func (t *testBuilder) buildOperation(op operation.Operation, sb []byte) operation.Operation {
 	priv := key.MustNewBTCPrivatekey()
 	sig, err := priv.Sign(sb)
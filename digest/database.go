@@ -32,6 +32,7 @@ var (
 	defaultColNameAccount   = "digest_ac"
 	defaultColNameDocument  = "digest_dm"
 	defaultColNameDocuments = "digest_dv"
+	defaultColNamePending   = "digest_pd"
 	defaultColNameBalance   = "digest_bl"
 	defaultColNameOperation = "digest_op"
 )
@@ -184,6 +185,7 @@ func (st *Database) clean() error {
 		defaultColNameOperation,
 		defaultColNameDocument,
 		defaultColNameDocuments,
+		defaultColNamePending,
 	} {
 		if err := st.database.Client().Collection(col).Drop(context.Background()); err != nil {
 			return storage.MergeStorageError(err)
@@ -227,6 +229,7 @@ func (st *Database) cleanByHeight(height base.Height) error {
 		defaultColNameOperation,
 		defaultColNameDocument,
 		defaultColNameDocuments,
+		defaultColNamePending,
 	} {
 		res, err := st.database.Client().Collection(col).BulkWrite(
 			context.Background(),
@@ -240,9 +243,145 @@ func (st *Database) cleanByHeight(height base.Height) error {
 		st.Log().Debug().Str("collection", col).Interface("result", res).Msg("clean collection by height")
 	}
 
+	if err := st.restoreExpiredDocuments(height); err != nil {
+		return err
+	}
+
 	return st.setLastBlock(height - 1)
 }
 
+// expireDocuments marks the latest documents, which are not fully signed
+// until their expiry height, as expired; the previous status is kept with the
+// height of expiry, so cleanByHeight can restore it.
+func (st *Database) expireDocuments(height base.Height) error {
+	latest, err := st.latestDocumentsToExpire(height)
+	if err != nil {
+		return err
+	}
+
+	expired := blocksign.DocumentExpired.String()
+	for status := range latest {
+		res, err := st.database.Client().Collection(defaultColNameDocument).UpdateMany(
+			context.Background(),
+			bson.M{"_id": bson.M{"$in": latest[status]}},
+			bson.M{"$set": bson.M{
+				"status":         expired,
+				"d.status":       expired,
+				"expired_height": height,
+				"expired_status": status,
+			}},
+		)
+		if err != nil {
+			return storage.MergeStorageError(err)
+		}
+
+		st.Log().Debug().Str("status", status).Int64("expired", res.ModifiedCount).Msg("expire documents by height")
+	}
+
+	return nil
+}
+
+// latestDocumentsToExpire returns the ids of the latest document rows to be
+// expired at height by their status; the older rows of same document are not
+// touched.
+func (st *Database) latestDocumentsToExpire(height base.Height) (map[string][]interface{}, error) {
+	statuses := []string{
+		blocksign.DocumentDraft.String(),
+		blocksign.DocumentPartiallySigned.String(),
+	}
+	filter := bson.M{
+		"expiry": bson.M{"$gte": base.Height(0), "$lt": height},
+		"status": bson.M{"$in": statuses},
+	}
+
+	col := st.database.Client().Collection(defaultColNameDocument)
+
+	var documentids []interface{}
+	if err := aggregate(col, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$documentid"}}},
+	}, func(cursor *mongo.Cursor) error {
+		documentids = append(documentids, cursor.Current.Lookup("_id"))
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if len(documentids) < 1 {
+		return nil, nil
+	}
+
+	latest := map[string][]interface{}{}
+	if err := aggregate(col, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"documentid": bson.M{"$in": documentids}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "documentid", Value: 1}, {Key: "height", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":    "$documentid",
+			"oid":    bson.M{"$first": "$_id"},
+			"expiry": bson.M{"$first": "$expiry"},
+			"status": bson.M{"$first": "$status"},
+		}}},
+		{{Key: "$match", Value: filter}},
+	}, func(cursor *mongo.Cursor) error {
+		status, ok := cursor.Current.Lookup("status").StringValueOK()
+		if !ok {
+			return errors.Errorf("invalid status of document")
+		}
+		latest[status] = append(latest[status], cursor.Current.Lookup("oid"))
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return latest, nil
+}
+
+// restoreExpiredDocuments restores the status of the documents, which are
+// expired at or over height.
+func (st *Database) restoreExpiredDocuments(height base.Height) error {
+	for _, status := range []string{
+		blocksign.DocumentDraft.String(),
+		blocksign.DocumentPartiallySigned.String(),
+	} {
+		res, err := st.database.Client().Collection(defaultColNameDocument).UpdateMany(
+			context.Background(),
+			bson.M{"expired_height": bson.M{"$gte": height}, "expired_status": status},
+			bson.M{
+				"$set":   bson.M{"status": status, "d.status": status},
+				"$unset": bson.M{"expired_height": "", "expired_status": ""},
+			},
+		)
+		if err != nil {
+			return storage.MergeStorageError(err)
+		}
+
+		st.Log().Debug().Str("status", status).Int64("restored", res.ModifiedCount).Msg("restore expired documents by height")
+	}
+
+	return nil
+}
+
+// aggregate runs pipeline and calls callback with each result.
+func aggregate(col *mongo.Collection, pipeline mongo.Pipeline, callback func(*mongo.Cursor) error) error {
+	cursor, err := col.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return storage.MergeStorageError(err)
+	}
+	defer func() {
+		_ = cursor.Close(context.Background())
+	}()
+
+	for cursor.Next(context.Background()) {
+		if err := callback(cursor); err != nil {
+			return err
+		}
+	}
+
+	return storage.MergeStorageError(cursor.Err())
+}
+
 func (st *Database) ManifestByHeight(height base.Height) (block.Manifest, bool, error) {
 	return st.mitum.ManifestByHeight(height)
 }
@@ -300,6 +439,23 @@ func (st *Database) Manifests(
 	)
 }
 
+// DocumentSignersHistory finds the UpdateDocumentSigners operations, which
+// updated the signers of the given document, in the order of height and index.
+func (st *Database) DocumentSignersHistory(
+	documentid currency.Big,
+	reverse bool,
+	offset string,
+	limit int64,
+	callback func(valuehash.Hash /* fact hash */, OperationValue) (bool, error),
+) error {
+	filter, err := buildOperationsFilterByDocumentId(documentid, offset, reverse)
+	if err != nil {
+		return err
+	}
+
+	return st.Operations(filter, true, reverse, limit, callback)
+}
+
 // OperationsByAddress finds the operation.Operations, which are related with
 // the given Address. The returned valuehash.Hash is the
 // operation.Operation.Fact().Hash().
@@ -458,10 +614,11 @@ func (st *Database) DocumentsByAddress(
 	address base.Address,
 	reverse bool,
 	offset string,
+	status string, /* document status; empty for all */
 	limit int64,
 	callback func(currency.Big /* document id */, DocumentValue) (bool, error),
 ) error {
-	filter, err := buildDocumentsFilterByAddress(address, offset, reverse)
+	filter, err := buildDocumentsFilterByAddress(address, offset, status, reverse)
 	if err != nil {
 		return err
 	}
@@ -574,6 +731,67 @@ func (st *Database) Documents(
 	)
 }
 
+// NextDocumentId returns the document id which will be assigned by chain to
+// the next document created without id.
+func (st *Database) NextDocumentId() (currency.Big, error) {
+	sta, _, err := st.mitum.State(blocksign.StateKeyLastDocumentId)
+	if err != nil {
+		return currency.NilBig, err
+	}
+
+	return blocksign.NextDocumentId(sta)
+}
+
+// DocumentPolicy returns the document policy, which is stored in chain.
+func (st *Database) DocumentPolicy() (blocksign.DocumentPolicy, error) {
+	return blocksign.LoadDocumentPolicy(st.mitum.State)
+}
+
+// PendingDocuments returns the documents, which address still has to sign, by
+// the pending document inventory of address; the documents, which are already
+// signed by address or can not be signed anymore, are skipped.
+func (st *Database) PendingDocuments(
+	a base.Address,
+	callback func(currency.Big /* document id */, DocumentValue) (bool, error),
+) error {
+	inv, _, _, err := st.documentInventory(defaultColNamePending, a)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range inv.Documents() {
+		va, found, err := st.Document(info.Index())
+		switch {
+		case err != nil:
+			return err
+		case !found:
+			continue
+		case !isPendingDocument(va, a):
+			continue
+		}
+
+		if keep, err := callback(info.Index(), va); err != nil {
+			return err
+		} else if !keep {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func isPendingDocument(va DocumentValue, a base.Address) bool {
+	switch va.Status() {
+	case blocksign.DocumentDraft, blocksign.DocumentPartiallySigned:
+	default:
+		return false
+	}
+
+	ds, found := va.Document().Signer(a)
+
+	return found && ds.Status() == blocksign.DocSignPending
+}
+
 // Account returns AccountValue.
 func (st *Database) Account(a base.Address) (AccountValue, bool /* exists */, error) {
 	var rs AccountValue
@@ -738,42 +956,50 @@ func (st *Database) balance(a base.Address) ([]currency.Amount, base.Height, bas
 
 // documentList return document invetory by address
 func (st *Database) documentList(a base.Address) (blocksign.DocumentInventory, base.Height, base.Height, error) {
-	var lastHeight, previousHeight base.Height = base.NilHeight, base.NilHeight
-	doc := blocksign.DocumentInventory{}
-	filter := util.NewBSONFilter("address", currency.StateAddressKeyPrefix(a))
-	q := filter.D()
-	var sta state.State
-	if err := st.database.Client().GetByFilter(
-		defaultColNameDocuments,
-		q,
-		func(res *mongo.SingleResult) error {
-			i, err := LoadDocuments(res.Decode, st.database.Encoders())
-			if err != nil {
-				return err
-			}
-			sta = i
+	return st.documentInventory(defaultColNameDocuments, a)
+}
 
-			return nil
-		},
-		options.FindOne().SetSort(util.NewBSONFilter("height", -1).D()),
-	); err != nil {
-		if errors.Is(err, util.NotFoundError) {
+// documentInventory collects the documents of the latest document inventory
+// states of address; the documents of address are stored in the multiple
+// pages.
+func (st *Database) documentInventory(
+	col string,
+	a base.Address,
+) (blocksign.DocumentInventory, base.Height, base.Height, error) {
+	lastHeight, previousHeight := base.NilHeight, base.NilHeight
 
-			return blocksign.NewDocumentInventory([]blocksign.DocInfo{}), lastHeight, previousHeight, nil
+	// NOTE the latest state of each page key is selected in one query and
+	// the states are sorted by height, so the last one is the latest.
+	var docs []blocksign.DocInfo
+	if err := aggregate(st.database.Client().Collection(col), mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"address": currency.StateAddressKeyPrefix(a)}}},
+		{{Key: "$sort", Value: bson.D{{Key: "key", Value: 1}, {Key: "height", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$key", "doc": bson.M{"$first": "$$ROOT"}}}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$doc"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "height", Value: 1}}}},
+	}, func(cursor *mongo.Cursor) error {
+		sta, err := LoadDocuments(cursor.Decode, st.database.Encoders())
+		if err != nil {
+			return err
 		}
-	}
 
-	i, err := blocksign.StateDocumentsValue(sta)
-	if err != nil {
-		return blocksign.DocumentInventory{}, lastHeight, previousHeight, err
-	}
-	doc = i
+		i, err := blocksign.StateDocumentsValue(sta)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, i.Documents()...)
 
-	if h := sta.Height(); h > lastHeight {
-		lastHeight = h
+		lastHeight = sta.Height()
 		previousHeight = sta.PreviousHeight()
+
+		return nil
+	}); err != nil {
+		return blocksign.DocumentInventory{}, base.NilHeight, base.NilHeight, err
 	}
 
+	doc := blocksign.NewDocumentInventory(docs)
+	doc.Sort(true)
+
 	return doc, lastHeight, previousHeight, nil
 }
 
@@ -840,8 +1066,22 @@ func buildOperationsFilterByAddress(address base.Address, offset string, reverse
 	return filter, nil
 }
 
-func buildDocumentsFilterByAddress(address base.Address, offset string, reverse bool) (bson.M, error) {
+func buildOperationsFilterByDocumentId(documentid currency.Big, offset string, reverse bool) (bson.M, error) {
+	filter, err := buildOperationsFilterByOffset(offset, reverse)
+	if err != nil {
+		return nil, err
+	}
+	filter["documentids"] = documentid.String()
+	filter["in_state"] = true
+
+	return filter, nil
+}
+
+func buildDocumentsFilterByAddress(address base.Address, offset string, status string, reverse bool) (bson.M, error) {
 	filter := bson.M{"addresses": bson.M{"$in": []string{currency.StateAddressKeyPrefix(address)}}}
+	if len(status) > 0 {
+		filter["status"] = status
+	}
 	if len(offset) > 0 {
 		height, documentid, err := parseOffset(offset)
 		if err != nil {
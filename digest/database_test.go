@@ -3,6 +3,7 @@
 package digest
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/spikeekips/mitum/util/localtime"
 	"github.com/spikeekips/mitum/util/valuehash"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
 
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
 )
 
@@ -516,6 +519,61 @@ func (t *testDatabase) TestCleanByHeight() {
 	}
 }
 
+func (t *testDatabase) TestExpireDocuments() {
+	_ = t.Encs.TestAddHinter(DocumentValue{})
+	_ = t.Encs.TestAddHinter(blocksign.DocInfo{})
+	_ = t.Encs.TestAddHinter(blocksign.DocSign{})
+	_ = t.Encs.TestAddHinter(blocksign.DocumentData{})
+
+	st, _ := t.Database()
+
+	creator := currency.MustAddress(util.UUID().String())
+	signer := currency.MustAddress(util.UUID().String())
+
+	info := blocksign.MustNewDocInfo(3, blocksign.MustFileHash("ABCD"))
+	dd := blocksign.NewDocumentData(info, creator, "user0", "title", currency.NewBig(10),
+		[]blocksign.DocSign{blocksign.NewDocSign(signer, "user1", false)},
+	).WithExpiry(base.Height(4))
+
+	// NOTE older row of same document
+	for _, height := range []base.Height{base.Height(1), base.Height(2)} {
+		doc, err := NewDocumentDoc(t.BSONEnc, dd, height)
+		t.NoError(err)
+		t.insertDoc(st, defaultColNameDocument, doc)
+	}
+
+	count := func(filter bson.M) int64 {
+		n, err := st.database.Client().Collection(defaultColNameDocument).CountDocuments(context.Background(), filter)
+		t.NoError(err)
+
+		return n
+	}
+
+	expired := blocksign.DocumentExpired.String()
+
+	t.NoError(st.expireDocuments(base.Height(4)))
+	t.Equal(int64(0), count(bson.M{"status": expired}))
+
+	t.NoError(st.expireDocuments(base.Height(5)))
+	t.Equal(int64(1), count(bson.M{"status": expired, "height": base.Height(2), "d.status": expired}))
+	t.Equal(int64(1), count(bson.M{"status": blocksign.DocumentDraft.String(), "height": base.Height(1)}))
+
+	va, found, err := st.Document(info.Index())
+	t.NoError(err)
+	t.True(found)
+	t.Equal(blocksign.DocumentExpired, va.Status())
+
+	// NOTE expiry is reverted by cleaning the height, where document is expired
+	t.NoError(st.CleanByHeight(base.Height(5)))
+	t.Equal(int64(0), count(bson.M{"status": expired}))
+	t.Equal(int64(0), count(bson.M{"expired_height": bson.M{"$exists": true}}))
+
+	va, found, err = st.Document(info.Index())
+	t.NoError(err)
+	t.True(found)
+	t.Equal(blocksign.DocumentDraft, va.Status())
+}
+
 func (t *testDatabase) TestAccountsWithBadState() {
 	ac := t.newAccount()
 
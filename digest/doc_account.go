@@ -1,6 +1,8 @@
 package digest
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
@@ -113,9 +115,29 @@ func (doc DocumentsDoc) MarshalBSON() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	address := doc.st.Key()[:len(doc.st.Key())-len(blocksign.StateKeyDocumentsSuffix)]
-	m["address"] = address
+	m["address"] = documentsStateAddress(doc.st.Key())
+	m["key"] = doc.st.Key()
 	m["height"] = doc.st.Height()
 
 	return bsonenc.Marshal(m)
 }
+
+// documentsStateAddress returns the address part of the key of document
+// inventory state; the key of page has the page after address.
+func documentsStateAddress(key string) string {
+	var k string
+	switch {
+	case blocksign.IsStateDocumentsPageKey(key):
+		k = strings.TrimSuffix(key, blocksign.StateKeyDocumentsPageSuffix)
+	case blocksign.IsStatePendingDocumentsKey(key):
+		k = strings.TrimSuffix(key, blocksign.StateKeyPendingDocumentsSuffix)
+	default:
+		return strings.TrimSuffix(key, blocksign.StateKeyDocumentsSuffix)
+	}
+
+	if i := strings.LastIndex(k, "-"); i >= 0 {
+		return k[:i]
+	}
+
+	return k
+}
@@ -56,10 +56,39 @@ func (doc DocumentDoc) MarshalBSON() ([]byte, error) {
 	}
 
 	m["filehash"] = doc.va.Document().FileHash()
+	if files := doc.va.Document().Files(); len(files) > 0 {
+		fhs := make([]string, len(files))
+		for i := range files {
+			fhs[i] = files[i].FileHash().String()
+		}
+		m["filehashes"] = fhs
+	}
 	m["documentid"] = doc.va.Document().Info().Index()
 	m["creator"] = currency.StateAddressKeyPrefix(doc.va.Document().Creator())
+	m["status"] = doc.va.Status().String()
+	m["expiry"] = doc.va.Document().Expiry()
+	m["canceled"] = doc.va.Document().Canceled()
+	m["tags"] = doc.va.Document().Metadata().Tags()
+	m["metadata"] = indexedMetadata(doc.va.Document().Metadata())
 	m["addresses"] = doc.addresses
 	m["height"] = doc.height
 
 	return bsonenc.Marshal(m)
 }
+
+// indexedMetadata returns the metadata of document, which has only the keys
+// indexed in digest.
+func indexedMetadata(md blocksign.DocumentMetadata) map[string]string {
+	m := map[string]string{}
+	for _, k := range []string{
+		blocksign.MetadataKeyMimeType,
+		blocksign.MetadataKeyReference,
+		blocksign.MetadataKeyDepartment,
+	} {
+		if v, found := md[k]; found {
+			m[k] = v
+		}
+	}
+
+	return m
+}
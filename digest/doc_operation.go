@@ -3,6 +3,7 @@ package digest
 import (
 	"time"
 
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/base/operation"
@@ -16,6 +17,7 @@ type OperationDoc struct {
 	va        OperationValue
 	op        operation.Operation
 	addresses []string
+	docids    []string // documents, whose signers are updated
 	height    base.Height
 }
 
@@ -40,6 +42,14 @@ func NewOperationDoc(
 		}
 	}
 
+	var docids []string
+	if fact, ok := op.Fact().(blocksign.UpdateDocumentSignersFact); ok {
+		docids = make([]string, len(fact.Items()))
+		for i := range fact.Items() {
+			docids[i] = fact.Items()[i].DocumentId().String()
+		}
+	}
+
 	va := NewOperationValue(op, height, confirmedAt, inState, reason, index)
 	b, err := mongodbstorage.NewBaseDoc(nil, va, enc)
 	if err != nil {
@@ -51,6 +61,7 @@ func NewOperationDoc(
 		va:        va,
 		op:        op,
 		addresses: addresses,
+		docids:    docids,
 		height:    height,
 	}, nil
 }
@@ -65,6 +76,10 @@ func (doc OperationDoc) MarshalBSON() ([]byte, error) {
 	m["fact"] = doc.op.Fact().Hash()
 	m["height"] = doc.height
 	m["index"] = doc.va.index
+	if len(doc.docids) > 0 {
+		m["documentids"] = doc.docids
+		m["in_state"] = doc.va.inState
+	}
 
 	return bsonenc.Marshal(m)
 }
@@ -14,6 +14,7 @@ var (
 
 type DocumentValue struct {
 	doc    blocksign.DocumentData
+	status blocksign.DocumentStatus
 	height base.Height
 }
 
@@ -24,6 +25,7 @@ func NewDocumentValue(
 
 	return DocumentValue{
 		doc:    doc,
+		status: doc.StatusAt(height),
 		height: height,
 	}
 }
@@ -36,6 +38,10 @@ func (dv DocumentValue) Document() blocksign.DocumentData {
 	return dv.doc
 }
 
+func (dv DocumentValue) Status() blocksign.DocumentStatus {
+	return dv.status
+}
+
 func (dv DocumentValue) Height() base.Height {
 	return dv.height
 }
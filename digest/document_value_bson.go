@@ -11,6 +11,7 @@ func (dv DocumentValue) MarshalBSON() ([]byte, error) {
 		bsonenc.NewHintedDoc(dv.Hint()),
 		bson.M{
 			"document": dv.doc,
+			"status":   dv.status.String(),
 			"height":   dv.height,
 		},
 	))
@@ -18,6 +19,7 @@ func (dv DocumentValue) MarshalBSON() ([]byte, error) {
 
 type DocumentValueBSONUnpacker struct {
 	DM bson.Raw    `bson:"document"`
+	ST string      `bson:"status"`
 	HT base.Height `bson:"height"`
 }
 
@@ -27,5 +29,5 @@ func (dv *DocumentValue) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
 		return err
 	}
 
-	return dv.unpack(enc, uva.DM, uva.HT)
+	return dv.unpack(enc, uva.DM, uva.ST, uva.HT)
 }
@@ -6,7 +6,7 @@ import (
 	"github.com/spikeekips/mitum/util/encoder"
 )
 
-func (dv *DocumentValue) unpack(enc encoder.Encoder, bdm []byte, height base.Height) error {
+func (dv *DocumentValue) unpack(enc encoder.Encoder, bdm []byte, st string, height base.Height) error {
 
 	if bdm != nil {
 		i, err := blocksign.DecodeDocumentData(bdm, enc)
@@ -16,6 +16,17 @@ func (dv *DocumentValue) unpack(enc encoder.Encoder, bdm []byte, height base.Hei
 		dv.doc = i
 	}
 
+	// NOTE document value without status is derived from document
+	if len(st) < 1 {
+		dv.status = dv.doc.StatusAt(height)
+	} else {
+		status, err := blocksign.ParseDocumentStatus(st)
+		if err != nil {
+			return err
+		}
+		dv.status = status
+	}
+
 	dv.height = height
 
 	return nil
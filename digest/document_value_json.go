@@ -11,6 +11,7 @@ import (
 type DocumentValueJSONPacker struct {
 	jsonenc.HintedHead
 	DM blocksign.DocumentData `json:"document"`
+	ST string                 `json:"status"`
 	HT base.Height            `json:"height"`
 }
 
@@ -18,12 +19,14 @@ func (va DocumentValue) MarshalJSON() ([]byte, error) {
 	return jsonenc.Marshal(DocumentValueJSONPacker{
 		HintedHead: jsonenc.NewHintedHead(va.Hint()),
 		DM:         va.doc,
+		ST:         va.status.String(),
 		HT:         va.height,
 	})
 }
 
 type DocumentValueJSONUnpacker struct {
 	DM json.RawMessage `json:"document"`
+	ST string          `json:"status"`
 	HT base.Height     `json:"height"`
 }
 
@@ -33,7 +36,7 @@ func (dv *DocumentValue) UnpackJSON(b []byte, enc *jsonenc.Encoder) error {
 		return err
 	}
 
-	if err := dv.unpack(enc, uva.DM, uva.HT); err != nil {
+	if err := dv.unpack(enc, uva.DM, uva.ST, uva.HT); err != nil {
 		return err
 	}
 	return nil
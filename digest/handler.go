@@ -12,9 +12,11 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/prprocessor"
 	"github.com/spikeekips/mitum/base/seal"
 	"github.com/spikeekips/mitum/launch/process"
 	"github.com/spikeekips/mitum/network"
+	"github.com/spikeekips/mitum/storage"
 	"github.com/spikeekips/mitum/util/encoder"
 	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
 	"github.com/spikeekips/mitum/util/logging"
@@ -33,6 +35,10 @@ var (
 	HandlerPathCurrency                   = `/currency/{currencyid:.*}`
 	HandlerPathDocuments                  = `/block/documents`
 	HandlerPathDocument                   = `/block/document/{documentid:[0-9]+}`
+	HandlerPathDocumentNextId             = `/block/document/next`
+	HandlerPathDocumentsByFileHash        = `/block/document/filehash/{filehash:[^/]+}`
+	HandlerPathDocumentSigners            = `/block/document/{documentid:[0-9]+}/signers`
+	HandlerPathDocumentRevisions          = `/block/document/{documentid:[0-9]+}/revisions`
 	HandlerPathManifests                  = `/block/manifests`
 	HandlerPathOperations                 = `/block/operations`
 	HandlerPathOperation                  = `/block/operation/{hash:(?i)[0-9a-z][0-9a-z]+}`
@@ -45,10 +51,13 @@ var (
 	HandlerPathAccount                    = `/account/{address:(?i)[0-9a-z][0-9a-z\-]+:[a-z0-9][a-z0-9\-_\+]*[a-z0-9]-v[0-9\.]*}`            // revive:disable-line:line-length-limit
 	HandlerPathAccountOperations          = `/account/{address:(?i)[0-9a-z][0-9a-z\-]+:[a-z0-9][a-z0-9\-_\+]*[a-z0-9]-v[0-9\.]*}/operations` // revive:disable-line:line-length-limit
 	HandlerPathAccounts                   = `/accounts`
-	HandlerPathAccountDocuments           = `/account/{address:(?i)[0-9a-z][0-9a-z\-]+:[a-z0-9][a-z0-9\-_\+]*[a-z0-9]-v[0-9\.]*}/documents` // revive:disable-line:line-length-limit
+	HandlerPathAccountDocuments           = `/account/{address:(?i)[0-9a-z][0-9a-z\-]+:[a-z0-9][a-z0-9\-_\+]*[a-z0-9]-v[0-9\.]*}/documents`         // revive:disable-line:line-length-limit
+	HandlerPathAccountPendingDocuments    = `/account/{address:(?i)[0-9a-z][0-9a-z\-]+:[a-z0-9][a-z0-9\-_\+]*[a-z0-9]-v[0-9\.]*}/documents/pending` // revive:disable-line:line-length-limit
 	HandlerPathOperationBuildFactTemplate = `/builder/operation/fact/template/{fact:[\w][\w\-]*}`
 	HandlerPathOperationBuildFact         = `/builder/operation/fact`
 	HandlerPathOperationBuildSign         = `/builder/operation/sign`
+	HandlerPathOperationBuildFee          = `/builder/operation/fee`
+	HandlerPathOperationBuildSimulate     = `/builder/operation/simulate`
 	HandlerPathOperationBuild             = `/builder/operation`
 	HandlerPathSend                       = `/builder/send`
 )
@@ -59,6 +68,10 @@ var RateLimitHandlerMap = map[string]string{
 	"currency":                        HandlerPathCurrency,
 	"documents":                       HandlerPathDocuments,
 	"document":                        HandlerPathDocument,
+	"document-next-id":                HandlerPathDocumentNextId,
+	"documents-by-filehash":           HandlerPathDocumentsByFileHash,
+	"document-signers":                HandlerPathDocumentSigners,
+	"document-revisions":              HandlerPathDocumentRevisions,
 	"block-manifests":                 HandlerPathManifests,
 	"block-operations":                HandlerPathOperations,
 	"block-operation":                 HandlerPathOperation,
@@ -72,9 +85,12 @@ var RateLimitHandlerMap = map[string]string{
 	"account-operations":              HandlerPathAccountOperations,
 	"accounts":                        HandlerPathAccounts,
 	"account-documents":               HandlerPathAccountDocuments,
+	"account-pending-documents":       HandlerPathAccountPendingDocuments,
 	"builder-operation-fact-template": HandlerPathOperationBuildFactTemplate,
 	"builder-operation-fact":          HandlerPathOperationBuildFact,
 	"builder-operation-sign":          HandlerPathOperationBuildSign,
+	"builder-operation-fee":           HandlerPathOperationBuildFee,
+	"builder-operation-simulate":      HandlerPathOperationBuildSimulate,
 	"builder-operation":               HandlerPathOperationBuild,
 	"builder-send":                    HandlerPathSend,
 }
@@ -104,6 +120,8 @@ type Handlers struct {
 	cp              *currency.CurrencyPool
 	nodeInfoHandler network.NodeInfoHandler
 	send            func(interface{}) (seal.Seal, error)
+	opr             prprocessor.OperationProcessor // operation processor to simulate operation
+	storage         storage.Database               // database of node to simulate operation
 	router          *mux.Router
 	routes          map[ /* path */ string]*mux.Route
 	itemsLimiter    func(string /* request type */) int64
@@ -181,6 +199,14 @@ func (hd *Handlers) setHandlers() {
 		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathDocument, hd.handleDocument, true).
 		Methods(http.MethodOptions, "GET")
+	_ = hd.setHandler(HandlerPathDocumentSigners, hd.handleDocumentSigners, true).
+		Methods(http.MethodOptions, "GET")
+	_ = hd.setHandler(HandlerPathDocumentRevisions, hd.handleDocumentRevisions, true).
+		Methods(http.MethodOptions, "GET")
+	_ = hd.setHandler(HandlerPathDocumentNextId, hd.handleDocumentNextId, true).
+		Methods(http.MethodOptions, "GET")
+	_ = hd.setHandler(HandlerPathDocumentsByFileHash, hd.handleDocumentsByFileHash, true).
+		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathManifests, hd.handleManifests, true).
 		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathOperations, hd.handleOperations, true).
@@ -207,12 +233,18 @@ func (hd *Handlers) setHandlers() {
 		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathAccountDocuments, hd.handleAccountDocuments, true).
 		Methods(http.MethodOptions, "GET")
+	_ = hd.setHandler(HandlerPathAccountPendingDocuments, hd.handleAccountPendingDocuments, true).
+		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathOperationBuildFactTemplate, hd.handleOperationBuildFactTemplate, true).
 		Methods(http.MethodOptions, "GET")
 	_ = hd.setHandler(HandlerPathOperationBuildFact, hd.handleOperationBuildFact, false).
 		Methods(http.MethodOptions, http.MethodPost)
 	_ = hd.setHandler(HandlerPathOperationBuildSign, hd.handleOperationBuildSign, false).
 		Methods(http.MethodOptions, http.MethodPost)
+	_ = hd.setHandler(HandlerPathOperationBuildFee, hd.handleOperationBuildFee, false).
+		Methods(http.MethodOptions, http.MethodPost)
+	_ = hd.setHandler(HandlerPathOperationBuildSimulate, hd.handleOperationBuildSimulate, false).
+		Methods(http.MethodOptions, http.MethodPost)
 	_ = hd.setHandler(HandlerPathOperationBuild, hd.handleOperationBuild, true).
 		Methods(http.MethodOptions, http.MethodGet, http.MethodPost)
 	_ = hd.setHandler(HandlerPathSend, hd.handleSend, false).
@@ -97,6 +97,12 @@ func (hd *Handlers) buildAccountHal(va AccountValue) (Hal, error) {
 		AddLink("documents:{offset}", NewHalLink(h+"?offset={offset}", nil).SetTemplated()).
 		AddLink("documents:{offset,reverse}", NewHalLink(h+"?offset={offset}&reverse=1", nil).SetTemplated())
 
+	h, err = hd.combineURL(HandlerPathAccountPendingDocuments, "address", hinted)
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.AddLink("pending_documents", NewHalLink(h, nil))
+
 	h, err = hd.combineURL(HandlerPathBlockByHeight, "height", va.Height().String())
 	if err != nil {
 		return nil, err
@@ -389,15 +395,23 @@ func (hd *Handlers) handleAccountDocuments(w http.ResponseWriter, r *http.Reques
 	limit := parseLimitQuery(r.URL.Query().Get("limit"))
 	offset := parseOffsetQuery(r.URL.Query().Get("offset"))
 	reverse := parseBoolQuery(r.URL.Query().Get("reverse"))
+	status, err := parseDocumentStatusQuery(r.URL.Query().Get("status"))
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
 
-	cachekey := CacheKey(r.URL.Path, stringOffsetQuery(offset), stringBoolQuery("reverse", reverse))
+		return
+	}
+
+	cachekey := CacheKey(
+		r.URL.Path, stringDocumentStatusQuery(status), stringOffsetQuery(offset), stringBoolQuery("reverse", reverse),
+	)
 
 	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
 		return
 	}
 
 	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
-		i, filled, err := hd.handleAccountDocumentsInGroup(address, offset, limit, reverse)
+		i, filled, err := hd.handleAccountDocumentsInGroup(address, offset, status, limit, reverse)
 
 		return []interface{}{i, filled}, err
 	}); err != nil {
@@ -427,6 +441,7 @@ func (hd *Handlers) handleAccountDocuments(w http.ResponseWriter, r *http.Reques
 func (hd *Handlers) handleAccountDocumentsInGroup(
 	address base.Address,
 	offset string,
+	status string,
 	l int64,
 	reverse bool,
 ) ([]byte, bool, error) {
@@ -439,7 +454,7 @@ func (hd *Handlers) handleAccountDocumentsInGroup(
 	fmt.Println(limit)
 	var vas []Hal
 	if err := hd.database.DocumentsByAddress(
-		address, reverse, offset, limit,
+		address, reverse, offset, status, limit,
 		func(_ currency.Big, va DocumentValue) (bool, error) {
 			hal, err := hd.buildDocumentHal(va)
 			if err != nil {
@@ -455,7 +470,7 @@ func (hd *Handlers) handleAccountDocumentsInGroup(
 		return nil, false, util.NotFoundError.Errorf("documents not found")
 	}
 
-	i, err := hd.buildAccountDocumentsHal(address, vas, offset, reverse)
+	i, err := hd.buildAccountDocumentsHal(address, vas, offset, status, reverse)
 	if err != nil {
 		return nil, false, err
 	}
@@ -468,12 +483,14 @@ func (hd *Handlers) buildAccountDocumentsHal(
 	address base.Address,
 	vas []Hal,
 	offset string,
+	status string,
 	reverse bool,
 ) (Hal, error) {
 	baseSelf, err := hd.combineURL(HandlerPathAccountDocuments, "address", address.String())
 	if err != nil {
 		return nil, err
 	}
+	baseSelf = addQueryValue(baseSelf, stringDocumentStatusQuery(status))
 
 	self := baseSelf
 	if len(offset) > 0 {
@@ -515,3 +532,70 @@ func (hd *Handlers) buildAccountDocumentsHal(
 
 	return hal, nil
 }
+
+func (hd *Handlers) handleAccountPendingDocuments(w http.ResponseWriter, r *http.Request) {
+	cachekey := CacheKeyPath(r)
+
+	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
+		return
+	}
+
+	var address base.Address
+	if a, err := base.DecodeAddressFromString(strings.TrimSpace(mux.Vars(r)["address"]), hd.enc); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+
+		return
+	} else if err := a.IsValid(nil); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+		return
+	} else {
+		address = a
+	}
+
+	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
+		return hd.handleAccountPendingDocumentsInGroup(address)
+	}); err != nil {
+		HTTP2HandleError(w, err)
+	} else {
+		HTTP2WriteHalBytes(hd.enc, w, v.([]byte), http.StatusOK)
+		if !shared {
+			HTTP2WriteCache(w, cachekey, time.Second*2)
+		}
+	}
+}
+
+func (hd *Handlers) handleAccountPendingDocumentsInGroup(address base.Address) ([]byte, error) {
+	var vas []Hal
+	if err := hd.database.PendingDocuments(
+		address,
+		func(_ currency.Big, va DocumentValue) (bool, error) {
+			hal, err := hd.buildDocumentHal(va)
+			if err != nil {
+				return false, err
+			}
+			vas = append(vas, hal)
+
+			return true, nil
+		},
+	); err != nil {
+		return nil, err
+	} else if len(vas) < 1 {
+		return nil, util.NotFoundError.Errorf("pending documents not found")
+	}
+
+	self, err := hd.combineURL(HandlerPathAccountPendingDocuments, "address", address.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var hal Hal
+	hal = NewBaseHal(vas, NewHalLink(self, nil))
+
+	h, err := hd.combineURL(HandlerPathAccount, "address", address.String())
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.AddLink("account", NewHalLink(h, nil))
+
+	return hd.enc.Marshal(hal)
+}
@@ -9,8 +9,10 @@ import (
 	"sort"
 	"testing"
 
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/state"
 	"github.com/spikeekips/mitum/util"
 	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
 	"github.com/spikeekips/mitum/util/localtime"
@@ -308,6 +310,91 @@ func (t *testHandlerAccount) TestAccountOperationsPagingOverOffset() {
 	t.Contains(problem.Error(), "operations not found")
 }
 
+func (t *testHandlerAccount) TestAccountPendingDocuments() {
+	_ = t.Encs.TestAddHinter(DocumentValue{})
+	_ = t.Encs.TestAddHinter(blocksign.DocInfo{})
+	_ = t.Encs.TestAddHinter(blocksign.DocSign{})
+	_ = t.Encs.TestAddHinter(blocksign.DocumentData{})
+	_ = t.Encs.TestAddHinter(blocksign.DocumentInventory{})
+
+	st, _ := t.Database()
+
+	creator := currency.MustAddress(util.UUID().String())
+	signer := currency.MustAddress(util.UUID().String())
+	height := base.Height(33)
+
+	// NOTE document 1 is already signed by signer
+	var infos []blocksign.DocInfo
+	for _, i := range []int64{0, 1, 150} {
+		info := blocksign.MustNewDocInfo(i, blocksign.FileHash(fmt.Sprintf("filehash%d", i)))
+		infos = append(infos, info)
+
+		dd := blocksign.NewDocumentData(info, creator, "user0", "title", currency.NewBig(10),
+			[]blocksign.DocSign{blocksign.NewDocSign(signer, "user1", i == 1)},
+		)
+		doc, err := NewDocumentDoc(t.BSONEnc, dd, height)
+		t.NoError(err)
+		t.insertDoc(st, defaultColNameDocument, doc)
+	}
+
+	// NOTE the pending documents of signer are stored in the pages; only the
+	// latest state of page is used
+	insertPage := func(page currency.Big, h base.Height, docs []blocksign.DocInfo) {
+		pst, err := state.NewStateV0(blocksign.StateKeyPendingDocuments(signer, page), nil, h)
+		t.NoError(err)
+		nst, err := blocksign.SetStatePendingDocumentsValue(pst, blocksign.NewDocumentInventory(docs))
+		t.NoError(err)
+
+		doc, err := NewDocumentsDoc(nst, t.BSONEnc)
+		t.NoError(err)
+		t.insertDoc(st, defaultColNamePending, doc)
+	}
+
+	var page0, page1 []blocksign.DocInfo
+	for i := range infos {
+		if blocksign.DocumentInventoryPage(infos[i].Index()).IsZero() {
+			page0 = append(page0, infos[i])
+		} else {
+			page1 = append(page1, infos[i])
+		}
+	}
+
+	insertPage(currency.ZeroBig, height-1, page0[:1])
+	insertPage(currency.ZeroBig, height, page0)
+	insertPage(currency.NewBig(1), height, page1)
+
+	handlers := t.handlers(st, DummyCache{})
+
+	self, err := handlers.router.Get(HandlerPathAccountPendingDocuments).URLPath("address", signer.String())
+	t.NoError(err)
+
+	w := t.requestOK(handlers, "GET", self.Path, nil)
+
+	b, err := io.ReadAll(w.Result().Body)
+	t.NoError(err)
+
+	hal := t.loadHal(b)
+	t.Equal(self.String(), hal.Links()["self"].Href())
+
+	var em []BaseHal
+	t.NoError(jsonenc.Unmarshal(hal.RawInterface(), &em))
+	t.Equal(2, len(em))
+
+	var ids []string
+	for _, b := range em {
+		hinter, err := t.JSONEnc.Decode(b.RawInterface())
+		t.NoError(err)
+		ids = append(ids, hinter.(DocumentValue).Document().Info().Index().String())
+	}
+	t.Equal([]string{"0", "150"}, ids)
+
+	// NOTE creator has nothing to sign
+	self, err = handlers.router.Get(HandlerPathAccountPendingDocuments).URLPath("address", creator.String())
+	t.NoError(err)
+
+	_ = t.request404(handlers, "GET", self.Path, nil)
+}
+
 func TestHandlerAccount(t *testing.T) {
 	suite.Run(t, new(testHandlerAccount))
 }
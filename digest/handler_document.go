@@ -8,9 +8,11 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/base"
 	"github.com/spikeekips/mitum/util"
+	"github.com/spikeekips/mitum/util/valuehash"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
@@ -60,7 +62,14 @@ func (hd *Handlers) handleDocumentInGroup(i currency.Big) ([]byte, error) {
 	}
 }
 
-func (hd *Handlers) handleDocuments(w http.ResponseWriter, r *http.Request) {
+func (hd *Handlers) handleDocumentSigners(w http.ResponseWriter, r *http.Request) {
+	i, err := parseDocIdFromPath(mux.Vars(r)["documentid"])
+	if err != nil {
+		HTTP2ProblemWithError(w, errors.Errorf("invalid document id for document signers: %q", err), http.StatusBadRequest)
+
+		return
+	}
+
 	limit := parseLimitQuery(r.URL.Query().Get("limit"))
 	offset := parseOffsetQuery(r.URL.Query().Get("offset"))
 	reverse := parseBoolQuery(r.URL.Query().Get("reverse"))
@@ -72,7 +81,215 @@ func (hd *Handlers) handleDocuments(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
-		i, filled, err := hd.handleDocumentsInGroup(offset, reverse, limit)
+		i, filled, err := hd.handleDocumentSignersInGroup(i, offset, limit, reverse)
+
+		return []interface{}{i, filled}, err
+	}); err != nil {
+		HTTP2HandleError(w, err)
+	} else {
+		var b []byte
+		var filled bool
+		{
+			l := v.([]interface{})
+			b = l[0].([]byte)
+			filled = l[1].(bool)
+		}
+
+		HTTP2WriteHalBytes(hd.enc, w, b, http.StatusOK)
+
+		if !shared {
+			expire := hd.expireNotFilled
+			if len(offset) > 0 && filled {
+				expire = time.Hour * 30
+			}
+
+			HTTP2WriteCache(w, cachekey, expire)
+		}
+	}
+}
+
+func (hd *Handlers) handleDocumentSignersInGroup(
+	documentid currency.Big,
+	offset string,
+	l int64,
+	reverse bool,
+) ([]byte, bool, error) {
+	var limit int64
+	if l < 0 {
+		limit = hd.itemsLimiter("document-signers")
+	} else {
+		limit = l
+	}
+
+	var vas []Hal
+	if err := hd.database.DocumentSignersHistory(
+		documentid, reverse, offset, limit,
+		func(_ valuehash.Hash, va OperationValue) (bool, error) {
+			hal, err := hd.buildOperationHal(va)
+			if err != nil {
+				return false, err
+			}
+			vas = append(vas, hal)
+
+			return true, nil
+		},
+	); err != nil {
+		return nil, false, err
+	} else if len(vas) < 1 {
+		return nil, false, util.NotFoundError.Errorf("signers history not found")
+	}
+
+	baseSelf, err := hd.combineURL(HandlerPathDocumentSigners, "documentid", documentid.String())
+	if err != nil {
+		return nil, false, err
+	}
+
+	self := baseSelf
+	if len(offset) > 0 {
+		self = addQueryValue(baseSelf, stringOffsetQuery(offset))
+	}
+	if reverse {
+		self = addQueryValue(self, stringBoolQuery("reverse", reverse))
+	}
+
+	var hal Hal = NewBaseHal(vas, NewHalLink(self, nil))
+
+	h, err := hd.combineURL(HandlerPathDocument, "documentid", documentid.String())
+	if err != nil {
+		return nil, false, err
+	}
+	hal = hal.AddLink("document", NewHalLink(h, nil))
+
+	va := vas[len(vas)-1].Interface().(OperationValue)
+	next := addQueryValue(baseSelf, stringOffsetQuery(buildOffset(va.Height(), va.Index())))
+	if reverse {
+		next = addQueryValue(next, stringBoolQuery("reverse", reverse))
+	}
+	hal = hal.AddLink("next", NewHalLink(next, nil))
+	hal = hal.AddLink("reverse", NewHalLink(addQueryValue(baseSelf, stringBoolQuery("reverse", !reverse)), nil))
+
+	b, err := hd.enc.Marshal(hal)
+
+	return b, int64(len(vas)) == limit, err
+}
+
+func (hd *Handlers) handleDocumentRevisions(w http.ResponseWriter, r *http.Request) {
+	cachekey := CacheKeyPath(r)
+
+	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
+		return
+	}
+
+	h, err := parseDocIdFromPath(mux.Vars(r)["documentid"])
+	if err != nil {
+		HTTP2ProblemWithError(w, errors.Errorf("invalid document id for document revisions: %q", err), http.StatusBadRequest)
+
+		return
+	}
+
+	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
+		return hd.handleDocumentRevisionsInGroup(h)
+	}); err != nil {
+		HTTP2HandleError(w, err)
+	} else {
+		HTTP2WriteHalBytes(hd.enc, w, v.([]byte), http.StatusOK)
+
+		if !shared {
+			HTTP2WriteCache(w, cachekey, time.Second*2)
+		}
+	}
+}
+
+func (hd *Handlers) handleDocumentRevisionsInGroup(i currency.Big) ([]byte, error) {
+	switch va, found, err := hd.database.Document(i); {
+	case err != nil:
+		return nil, err
+	case !found:
+		return nil, util.NotFoundError.Errorf("document value not found")
+	default:
+		h, err := hd.combineURL(HandlerPathDocumentRevisions, "documentid", i.String())
+		if err != nil {
+			return nil, err
+		}
+
+		revisions := va.Document().Revisions()
+		vas := make([]Hal, len(revisions))
+		for j := range revisions {
+			vas[j] = NewBaseHal(revisions[j], HalLink{})
+		}
+
+		var hal Hal = NewBaseHal(vas, NewHalLink(h, nil))
+
+		h, err = hd.combineURL(HandlerPathDocument, "documentid", i.String())
+		if err != nil {
+			return nil, err
+		}
+		hal = hal.AddLink("document", NewHalLink(h, nil))
+
+		return hd.enc.Marshal(hal)
+	}
+}
+
+func (hd *Handlers) handleDocumentNextId(w http.ResponseWriter, r *http.Request) {
+	cachekey := CacheKeyPath(r)
+
+	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
+		return
+	}
+
+	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
+		return hd.handleDocumentNextIdInGroup()
+	}); err != nil {
+		HTTP2HandleError(w, err)
+	} else {
+		HTTP2WriteHalBytes(hd.enc, w, v.([]byte), http.StatusOK)
+
+		if !shared {
+			HTTP2WriteCache(w, cachekey, time.Second*2)
+		}
+	}
+}
+
+func (hd *Handlers) handleDocumentNextIdInGroup() ([]byte, error) {
+	i, err := hd.database.NextDocumentId()
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := hd.combineURL(HandlerPathDocumentNextId)
+	if err != nil {
+		return nil, err
+	}
+
+	var hal Hal = NewBaseHal(blocksign.DocId(i), NewHalLink(h, nil))
+	hal = hal.AddLink("document:{documentid}", NewHalLink(HandlerPathDocument, nil).SetTemplated())
+
+	return hd.enc.Marshal(hal)
+}
+
+func (hd *Handlers) handleDocuments(w http.ResponseWriter, r *http.Request) {
+	limit := parseLimitQuery(r.URL.Query().Get("limit"))
+	offset := parseOffsetQuery(r.URL.Query().Get("offset"))
+	reverse := parseBoolQuery(r.URL.Query().Get("reverse"))
+	status, err := parseDocumentStatusQuery(r.URL.Query().Get("status"))
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+
+		return
+	}
+	tag := parseTagQuery(r.URL.Query().Get("tag"))
+
+	cachekey := CacheKey(
+		r.URL.Path, stringDocumentStatusQuery(status), stringTagQuery(tag),
+		stringOffsetQuery(offset), stringBoolQuery("reverse", reverse),
+	)
+
+	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
+		return
+	}
+
+	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
+		i, filled, err := hd.handleDocumentsInGroup(offset, reverse, status, tag, limit)
 
 		return []interface{}{i, filled}, err
 	}); err != nil {
@@ -102,6 +319,8 @@ func (hd *Handlers) handleDocuments(w http.ResponseWriter, r *http.Request) {
 func (hd *Handlers) handleDocumentsInGroup(
 	offset string,
 	reverse bool,
+	status string,
+	tag string,
 	l int64,
 ) ([]byte, bool, error) {
 	var limit int64
@@ -114,6 +333,12 @@ func (hd *Handlers) handleDocumentsInGroup(
 	if err != nil {
 		return nil, false, err
 	}
+	if len(status) > 0 {
+		filter["status"] = status
+	}
+	if len(tag) > 0 {
+		filter["tags"] = tag
+	}
 
 	var vas []Hal
 	switch l, e := hd.loadDocumentsHALFromDatabase(filter, reverse, limit); {
@@ -129,6 +354,101 @@ func (hd *Handlers) handleDocumentsInGroup(
 	if err != nil {
 		return nil, false, err
 	}
+	h = addQueryValue(h, stringDocumentStatusQuery(status))
+	h = addQueryValue(h, stringTagQuery(tag))
+	hal := hd.buildDocumentsHal(h, vas, offset, reverse)
+	if next := nextOffsetOfDocuments(h, vas, reverse); len(next) > 0 {
+		hal = hal.AddLink("next", NewHalLink(next, nil))
+	}
+
+	b, err := hd.enc.Marshal(hal)
+	return b, int64(len(vas)) == limit, err
+}
+
+func (hd *Handlers) handleDocumentsByFileHash(w http.ResponseWriter, r *http.Request) {
+	limit := parseLimitQuery(r.URL.Query().Get("limit"))
+	offset := parseOffsetQuery(r.URL.Query().Get("offset"))
+	reverse := parseBoolQuery(r.URL.Query().Get("reverse"))
+
+	fh, err := blocksign.ParseLegacyFileHash(mux.Vars(r)["filehash"])
+	if err != nil {
+		HTTP2ProblemWithError(w, errors.Wrap(err, "invalid filehash"), http.StatusBadRequest)
+
+		return
+	}
+
+	cachekey := CacheKey(r.URL.Path, stringOffsetQuery(offset), stringBoolQuery("reverse", reverse))
+
+	if err := LoadFromCache(hd.cache, cachekey, w); err == nil {
+		return
+	}
+
+	if v, err, shared := hd.rg.Do(cachekey, func() (interface{}, error) {
+		i, filled, err := hd.handleDocumentsByFileHashInGroup(fh, offset, reverse, limit)
+
+		return []interface{}{i, filled}, err
+	}); err != nil {
+		HTTP2HandleError(w, err)
+	} else {
+		var b []byte
+		var filled bool
+		{
+			l := v.([]interface{})
+			b = l[0].([]byte)
+			filled = l[1].(bool)
+		}
+
+		HTTP2WriteHalBytes(hd.enc, w, b, http.StatusOK)
+
+		if !shared {
+			expire := hd.expireNotFilled
+			if len(offset) > 0 && filled {
+				expire = time.Hour * 30
+			}
+
+			HTTP2WriteCache(w, cachekey, expire)
+		}
+	}
+}
+
+func (hd *Handlers) handleDocumentsByFileHashInGroup(
+	fh blocksign.FileHash,
+	offset string,
+	reverse bool,
+	l int64,
+) ([]byte, bool, error) {
+	var limit int64
+	if l < 0 {
+		limit = hd.itemsLimiter("documents")
+	} else {
+		limit = l
+	}
+	filter, err := buildDocumentsFilterByOffset(offset, reverse)
+	if err != nil {
+		return nil, false, err
+	}
+	// NOTE the files of document with multiple files are in filehashes
+	filter["$and"] = []bson.M{
+		{"$or": []bson.M{
+			{"filehash": fh.String()},
+			{"filehashes": fh.String()},
+		}},
+	}
+
+	var vas []Hal
+	switch l, e := hd.loadDocumentsHALFromDatabase(filter, reverse, limit); {
+	case e != nil:
+		return nil, false, e
+	case len(l) < 1:
+		return nil, false, util.NotFoundError.Errorf("documents not found")
+	default:
+		vas = l
+	}
+
+	h, err := hd.combineURL(HandlerPathDocumentsByFileHash, "filehash", fh.String())
+	if err != nil {
+		return nil, false, err
+	}
 	hal := hd.buildDocumentsHal(h, vas, offset, reverse)
 	if next := nextOffsetOfDocuments(h, vas, reverse); len(next) > 0 {
 		hal = hal.AddLink("next", NewHalLink(next, nil))
@@ -250,6 +570,26 @@ func (hd *Handlers) buildDocumentHal(va DocumentValue) (Hal, error) {
 	}
 	hal = hal.AddLink("manifest", NewHalLink(h, nil))
 
+	h, err = hd.combineURL(HandlerPathDocumentSigners, "documentid", va.Document().Info().Index().String())
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.AddLink("signers_history", NewHalLink(h, nil))
+
+	h, err = hd.combineURL(HandlerPathDocumentRevisions, "documentid", va.Document().Info().Index().String())
+	if err != nil {
+		return nil, err
+	}
+	hal = hal.AddLink("revisions", NewHalLink(h, nil))
+
+	if next, found := va.Document().NextSigner(); found {
+		h, err = hd.combineURL(HandlerPathAccount, "address", next.Address().String())
+		if err != nil {
+			return nil, err
+		}
+		hal = hal.AddLink("next_signer", NewHalLink(h, nil))
+	}
+
 	return hal, nil
 }
 
@@ -9,15 +9,26 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/soonkuk/mitum-blocksign/blocksign"
 	"github.com/spikeekips/mitum-currency/currency"
 	"github.com/spikeekips/mitum/util/hint"
 )
 
 var factTypesByHint = map[string]hint.Hinter{
-	"create-accounts":   currency.CreateAccounts{},
-	"key-updater":       currency.KeyUpdater{},
-	"transfers":         currency.Transfers{},
-	"currency-register": currency.CurrencyRegister{},
+	"create-accounts":              currency.CreateAccounts{},
+	"key-updater":                  currency.KeyUpdater{},
+	"transfers":                    currency.Transfers{},
+	"currency-register":            currency.CurrencyRegister{},
+	"create-documents":             blocksign.CreateDocuments{},
+	"create-documents-multi-files": blocksign.CreateDocumentsItemMultiFilesHinter,
+	"sign-documents":               blocksign.SignDocuments{},
+	"reject-documents":             blocksign.RejectDocuments{},
+	"revoke-sign-documents":        blocksign.RevokeSignDocuments{},
+	"transfer-documents":           blocksign.TransferDocuments{},
+	"update-document-signers":      blocksign.UpdateDocumentSigners{},
+	"revise-documents":             blocksign.ReviseDocuments{},
+	"cancel-documents":             blocksign.CancelDocuments{},
+	"document-policy-updater":      blocksign.DocumentPolicyUpdater{},
 }
 
 func (hd *Handlers) handleOperationBuild(w http.ResponseWriter, r *http.Request) {
@@ -91,7 +102,14 @@ func (hd *Handlers) handleOperationBuildFact(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	builder := NewBuilder(hd.enc, hd.networkID)
+	policy, err := hd.documentPolicy()
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	builder := NewBuilder(hd.enc, hd.networkID).WithFee(hd.cp, policy, hd.document)
 	hal, err := builder.BuildFact(body.Bytes())
 	if err != nil {
 		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
@@ -110,6 +128,30 @@ func (hd *Handlers) handleOperationBuildFact(w http.ResponseWriter, r *http.Requ
 	HTTP2WriteHal(hd.enc, w, hal, http.StatusOK)
 }
 
+// documentPolicy returns the document policy of chain; without database,
+// blocksign.DefaultDocumentPolicy.
+func (hd *Handlers) documentPolicy() (blocksign.DocumentPolicy, error) {
+	if hd.database == nil {
+		return blocksign.DefaultDocumentPolicy, nil
+	}
+
+	return hd.database.DocumentPolicy()
+}
+
+// document returns the registered document.
+func (hd *Handlers) document(id currency.Big) (blocksign.DocumentData, bool, error) {
+	if hd.database == nil {
+		return blocksign.DocumentData{}, false, nil
+	}
+
+	va, found, err := hd.database.Document(id)
+	if err != nil || !found {
+		return blocksign.DocumentData{}, found, err
+	}
+
+	return va.Document(), true, nil
+}
+
 func (hd *Handlers) handleOperationBuildSign(w http.ResponseWriter, r *http.Request) {
 	body := &bytes.Buffer{}
 	if _, err := io.Copy(body, r.Body); err != nil {
@@ -0,0 +1,130 @@
+package digest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+)
+
+// RequiredAmount is the amount of currency, which is required to the sender
+// balance for fact.
+type RequiredAmount struct {
+	Amount  currency.Big `json:"amount"` // amount to be subtracted, including fee
+	Fee     currency.Big `json:"fee"`
+	Balance currency.Big `json:"balance"` // current balance of sender in digest
+	Enough  bool         `json:"enough"`
+}
+
+func (hd *Handlers) handleOperationBuildFee(w http.ResponseWriter, r *http.Request) {
+	body := &bytes.Buffer{}
+	if _, err := io.Copy(body, r.Body); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var fact base.Fact
+	if hinter, err := hd.enc.Decode(body.Bytes()); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+
+		return
+	} else if f, ok := hinter.(base.Fact); !ok {
+		HTTP2ProblemWithError(w, errors.Errorf("not base.Fact, %T", hinter), http.StatusBadRequest)
+
+		return
+	} else {
+		fact = f
+	}
+
+	policy, err := hd.documentPolicy()
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	sender, required, err := NewBuilder(hd.enc, hd.networkID).WithFee(hd.cp, policy, hd.document).Required(fact)
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+
+		return
+	}
+
+	balance, err := hd.senderBalance(sender)
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	amounts, enough := requiredAmounts(required, balance)
+
+	h, err := hd.combineURL(HandlerPathOperationBuildFee)
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	hal := NewBaseHal(fact, NewHalLink(h, nil)).
+		AddExtras("sender", sender).
+		AddExtras("required", amounts).
+		AddExtras("enough", enough)
+
+	HTTP2WriteHal(hd.enc, w, hal, http.StatusOK)
+}
+
+// senderBalance returns the balance of sender in digest; unknown sender has
+// empty balance.
+func (hd *Handlers) senderBalance(sender base.Address) (map[currency.CurrencyID]currency.Big, error) {
+	balance := map[currency.CurrencyID]currency.Big{}
+	if hd.database == nil || sender == nil {
+		return balance, nil
+	}
+
+	va, found, err := hd.database.Account(sender)
+	switch {
+	case err != nil:
+		return nil, err
+	case !found:
+		return balance, nil
+	}
+
+	for _, am := range va.Balance() {
+		balance[am.Currency()] = am.Big()
+	}
+
+	return balance, nil
+}
+
+func requiredAmounts(
+	required map[currency.CurrencyID][2]currency.Big,
+	balance map[currency.CurrencyID]currency.Big,
+) (map[currency.CurrencyID]RequiredAmount, bool) {
+	amounts := map[currency.CurrencyID]RequiredAmount{}
+
+	enough := true
+	for cid := range required {
+		rq := required[cid]
+
+		b, found := balance[cid]
+		if !found {
+			b = currency.ZeroBig
+		}
+
+		// NOTE like the operation processor, sender should have balance of
+		// currency
+		ok := found && b.Compare(rq[0]) >= 0
+		if !ok {
+			enough = false
+		}
+
+		amounts[cid] = RequiredAmount{Amount: rq[0], Fee: rq[1], Balance: b, Enough: ok}
+	}
+
+	return amounts, enough
+}
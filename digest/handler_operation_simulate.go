@@ -0,0 +1,125 @@
+package digest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/prprocessor"
+	"github.com/spikeekips/mitum/base/state"
+	"github.com/spikeekips/mitum/storage"
+)
+
+// SetSimulator sets the operation processor and the database of node to
+// simulate operation over the latest state.
+func (hd *Handlers) SetSimulator(opr prprocessor.OperationProcessor, st storage.Database) *Handlers {
+	hd.opr = opr
+	hd.storage = st
+
+	return hd
+}
+
+func (hd *Handlers) handleOperationBuildSimulate(w http.ResponseWriter, r *http.Request) {
+	if hd.opr == nil || hd.storage == nil {
+		HTTP2NotSupported(w, nil)
+
+		return
+	}
+
+	body := &bytes.Buffer{}
+	if _, err := io.Copy(body, r.Body); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	var op operation.Operation
+	if hinter, err := hd.enc.Decode(body.Bytes()); err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusBadRequest)
+
+		return
+	} else if i, ok := hinter.(operation.Operation); !ok {
+		HTTP2ProblemWithError(w, errors.Errorf("not operation.Operation, %T", hinter), http.StatusBadRequest)
+
+		return
+	} else {
+		op = i
+	}
+
+	hal, err := hd.simulate(op)
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+
+	h, err := hd.combineURL(HandlerPathOperationBuildSimulate)
+	if err != nil {
+		HTTP2ProblemWithError(w, err, http.StatusInternalServerError)
+
+		return
+	}
+	hal = hal.SetSelf(NewHalLink(h, nil))
+
+	HTTP2WriteHal(hd.enc, w, hal, http.StatusOK)
+}
+
+// simulate processes operation in the throwaway Statepool; the rejected
+// operation has the reason instead of the states.
+func (hd *Handlers) simulate(op operation.Operation) (Hal, error) {
+	hal := NewBaseHal(op, HalLink{})
+
+	rejected := func(err error) Hal {
+		return hal.AddExtras("valid", false).AddExtras("reason", err.Error())
+	}
+
+	if err := op.IsValid(hd.networkID); err != nil {
+		return rejected(err), nil
+	}
+
+	switch found, err := hd.storage.HasOperationFact(op.Fact().Hash()); {
+	case err != nil:
+		return nil, err
+	case found:
+		return rejected(errors.Errorf("operation already processed, %v", op.Fact().Hash())), nil
+	}
+
+	sp, ok := op.(state.Processor)
+	if !ok {
+		return rejected(errors.Errorf("operation can not be processed, %T", op)), nil
+	}
+
+	pool, err := storage.NewStatepool(hd.storage)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Done()
+
+	opr := hd.opr.New(pool)
+	if err := opr.Process(sp); err != nil {
+		var rerr operation.ReasonError
+		if errors.As(err, &rerr) {
+			return rejected(err), nil
+		}
+
+		return nil, err
+	}
+
+	// NOTE collected fee is also processed like proposal
+	if err := opr.Close(); err != nil {
+		return nil, err
+	}
+
+	updates := pool.Updates()
+	sts := make([]state.State, len(updates))
+	for i := range updates {
+		sts[i] = updates[i].GetState()
+	}
+
+	return hal.
+		AddExtras("valid", true).
+		AddExtras("height", pool.Height()).
+		AddExtras("states", sts), nil
+}
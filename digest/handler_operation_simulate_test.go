@@ -0,0 +1,188 @@
+// +build mongodb
+
+package digest
+
+import (
+	"testing"
+
+	"github.com/soonkuk/mitum-blocksign/blocksign"
+	"github.com/spikeekips/mitum-currency/currency"
+	"github.com/spikeekips/mitum/base"
+	"github.com/spikeekips/mitum/base/key"
+	"github.com/spikeekips/mitum/base/operation"
+	"github.com/spikeekips/mitum/base/state"
+	mongodbstorage "github.com/spikeekips/mitum/storage/mongodb"
+	"github.com/spikeekips/mitum/util"
+	jsonenc "github.com/spikeekips/mitum/util/encoder/json"
+	"github.com/stretchr/testify/suite"
+)
+
+type testHandlerOperationSimulate struct {
+	baseTestHandlers
+	cp *currency.CurrencyPool
+}
+
+func (t *testHandlerOperationSimulate) SetupTest() {
+	de := currency.NewCurrencyDesign(
+		currency.MustNewAmount(currency.NewBig(1000), t.cid),
+		currency.NewTestAddress(),
+		currency.NewCurrencyPolicy(currency.ZeroBig, currency.NewNilFeeer()),
+	)
+
+	i, err := state.NewStateV0(currency.StateKeyCurrencyDesign(t.cid), nil, base.Height(1))
+	t.NoError(err)
+	st, err := currency.SetStateCurrencyDesignValue(i, de)
+	t.NoError(err)
+
+	t.cp = currency.NewCurrencyPool()
+	t.NoError(t.cp.Set(st))
+}
+
+func (t *testHandlerOperationSimulate) handlersWithSimulator() (*Handlers, *mongodbstorage.Database) {
+	st, mst := t.Database()
+	handlers := t.handlers(st, DummyCache{})
+
+	opr := blocksign.NewOperationProcessor(t.cp)
+	_, err := opr.SetProcessor(currency.Transfers{}, currency.NewTransfersProcessor(t.cp))
+	t.NoError(err)
+
+	_ = handlers.SetSimulator(opr, mst)
+
+	return handlers, mst
+}
+
+func (t *testHandlerOperationSimulate) newAccountStates(priv key.Privatekey, amount int64) (base.Address, []state.State) {
+	k, err := currency.NewKey(priv.Publickey(), 100)
+	t.NoError(err)
+	keys, err := currency.NewKeys([]currency.Key{k}, 100)
+	t.NoError(err)
+	ac, err := currency.NewAccountFromKeys(keys)
+	t.NoError(err)
+
+	i, err := state.NewStateV0(currency.StateKeyAccount(ac.Address()), nil, base.Height(1))
+	t.NoError(err)
+	ast, err := currency.SetStateAccountValue(i, ac)
+	t.NoError(err)
+
+	j, err := state.NewStateV0(currency.StateKeyBalance(ac.Address(), t.cid), nil, base.Height(1))
+	t.NoError(err)
+	bst, err := currency.SetStateBalanceValue(j, currency.MustNewAmount(currency.NewBig(amount), t.cid))
+	t.NoError(err)
+
+	return ac.Address(), []state.State{ast, bst}
+}
+
+func (t *testHandlerOperationSimulate) newTransfers(priv key.Privatekey, sender, receiver base.Address, amount int64) currency.Transfers {
+	items := []currency.TransfersItem{currency.NewTransfersItemSingleAmount(
+		receiver,
+		currency.MustNewAmount(currency.NewBig(amount), t.cid),
+	)}
+	fact := currency.NewTransfersFact(util.UUID().Bytes(), sender, items)
+
+	sig, err := operation.NewFactSignature(priv, fact, t.networkID)
+	t.NoError(err)
+
+	op, err := currency.NewTransfers(fact, []operation.FactSign{operation.NewBaseFactSign(priv.Publickey(), sig)}, "")
+	t.NoError(err)
+
+	return op
+}
+
+func (t *testHandlerOperationSimulate) simulate(handlers *Handlers, op operation.Operation) BaseHal {
+	self, err := handlers.router.Get(HandlerPathOperationBuildSimulate).URL()
+	t.NoError(err)
+
+	b, err := jsonenc.Marshal(op)
+	t.NoError(err)
+
+	w := t.requestOK(handlers, "POST", self.String(), b)
+
+	return t.loadHal(w.Body.Bytes())
+}
+
+func (t *testHandlerOperationSimulate) TestNotSupported() {
+	st, _ := t.Database()
+	handlers := t.handlers(st, DummyCache{})
+
+	self, err := handlers.router.Get(HandlerPathOperationBuildSimulate).URL()
+	t.NoError(err)
+
+	op := t.newTransfer(currency.MustAddress(util.UUID().String()), currency.MustAddress(util.UUID().String()))
+
+	b, err := jsonenc.Marshal(op)
+	t.NoError(err)
+
+	_, problem := t.request500(handlers, "POST", self.String(), b)
+
+	t.Contains(problem.Error(), "not supported")
+}
+
+func (t *testHandlerOperationSimulate) TestSimulate() {
+	handlers, mst := t.handlersWithSimulator()
+
+	priv := key.MustNewBTCPrivatekey()
+	sender, sts := t.newAccountStates(priv, 33)
+	receiver, rsts := t.newAccountStates(key.MustNewBTCPrivatekey(), 0)
+
+	for _, st := range append(sts, rsts...) {
+		t.NoError(mst.NewState(st))
+	}
+
+	op := t.newTransfers(priv, sender, receiver, 10)
+
+	hal := t.simulate(handlers, op)
+
+	t.Equal(true, hal.Extras()["valid"])
+	t.NotContains(hal.Extras(), "reason")
+
+	ust, ok := hal.Extras()["states"].([]interface{})
+	t.True(ok)
+	t.Equal(2, len(ust))
+
+	// NOTE simulation does not store states
+	bst, found, err := mst.State(currency.StateKeyBalance(sender, t.cid))
+	t.NoError(err)
+	t.True(found)
+
+	am, err := currency.StateBalanceValue(bst)
+	t.NoError(err)
+	t.Equal(currency.NewBig(33), am.Big())
+
+	found, err = mst.HasOperationFact(op.Fact().Hash())
+	t.NoError(err)
+	t.False(found)
+}
+
+func (t *testHandlerOperationSimulate) TestInsufficientBalance() {
+	handlers, mst := t.handlersWithSimulator()
+
+	priv := key.MustNewBTCPrivatekey()
+	sender, sts := t.newAccountStates(priv, 3)
+	receiver, rsts := t.newAccountStates(key.MustNewBTCPrivatekey(), 0)
+
+	for _, st := range append(sts, rsts...) {
+		t.NoError(mst.NewState(st))
+	}
+
+	hal := t.simulate(handlers, t.newTransfers(priv, sender, receiver, 10))
+
+	t.Equal(false, hal.Extras()["valid"])
+	t.Contains(hal.Extras()["reason"], "insufficient balance")
+	t.NotContains(hal.Extras(), "states")
+}
+
+func (t *testHandlerOperationSimulate) TestUnknownSender() {
+	handlers, _ := t.handlersWithSimulator()
+
+	priv := key.MustNewBTCPrivatekey()
+	sender, _ := t.newAccountStates(priv, 33)
+
+	hal := t.simulate(handlers, t.newTransfers(priv, sender, currency.MustAddress(util.UUID().String()), 10))
+
+	t.Equal(false, hal.Extras()["valid"])
+	t.Contains(hal.Extras()["reason"], "does not exist")
+}
+
+func TestHandlerOperationSimulate(t *testing.T) {
+	suite.Run(t, new(testHandlerOperationSimulate))
+}
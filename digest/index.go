@@ -68,6 +68,93 @@ var documentIndexModels = []mongo.IndexModel{
 		Options: options.Index().
 			SetName("mitum_digest_document_height"),
 	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "status", Value: 1},
+			bson.E{Key: "height", Value: -1},
+			bson.E{Key: "documentid", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_status"),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "addresses", Value: 1},
+			bson.E{Key: "status", Value: 1},
+			bson.E{Key: "height", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_address_status"),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "expiry", Value: 1},
+			bson.E{Key: "status", Value: 1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_expiry"),
+	},
+	{
+		Keys: bson.D{bson.E{Key: "expired_height", Value: 1}},
+		Options: options.Index().
+			SetName("mitum_digest_document_expired_height").
+			SetSparse(true),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "filehash", Value: 1},
+			bson.E{Key: "height", Value: -1},
+			bson.E{Key: "documentid", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_filehash"),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "filehashes", Value: 1},
+			bson.E{Key: "height", Value: -1},
+			bson.E{Key: "documentid", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_filehashes").
+			SetSparse(true),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "tags", Value: 1},
+			bson.E{Key: "height", Value: -1},
+			bson.E{Key: "documentid", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_tags"),
+	},
+	{
+		Keys: bson.D{bson.E{Key: "metadata.reference", Value: 1}},
+		Options: options.Index().
+			SetName("mitum_digest_document_metadata_reference").
+			SetSparse(true),
+	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "metadata.department", Value: 1},
+			bson.E{Key: "height", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_metadata_department").
+			SetSparse(true),
+	},
+}
+
+var documentsIndexModels = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			bson.E{Key: "address", Value: 1},
+			bson.E{Key: "key", Value: 1},
+			bson.E{Key: "height", Value: -1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_documents"),
+	},
 }
 
 var operationIndexModels = []mongo.IndexModel{
@@ -86,6 +173,15 @@ var operationIndexModels = []mongo.IndexModel{
 		Options: options.Index().
 			SetName("mitum_digest_operation_height"),
 	},
+	{
+		Keys: bson.D{
+			bson.E{Key: "documentids", Value: 1},
+			bson.E{Key: "height", Value: 1},
+			bson.E{Key: "index", Value: 1},
+		},
+		Options: options.Index().
+			SetName("mitum_digest_document_signers_operation"),
+	},
 }
 
 var defaultIndexes = map[string] /* collection */ []mongo.IndexModel{
@@ -94,4 +190,6 @@ var defaultIndexes = map[string] /* collection */ []mongo.IndexModel{
 	defaultColNameBalance:   balanceIndexModels,
 	defaultColNameDocument:  documentIndexModels,
 	defaultColNameOperation: operationIndexModels,
+	defaultColNameDocuments: documentsIndexModels,
+	defaultColNamePending:   documentsIndexModels,
 }
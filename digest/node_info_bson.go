@@ -12,9 +12,18 @@ func (ni NodeInfo) MarshalBSON() ([]byte, error) {
 	}))
 }
 
+type NodeInfoBSONUnpacker struct {
+	IN bson.Raw `bson:"internal"`
+}
+
 func (ni *NodeInfo) UnpackBSON(b []byte, enc *bsonenc.Encoder) error {
+	var uni NodeInfoBSONUnpacker
+	if err := enc.Unmarshal(b, &uni); err != nil {
+		return err
+	}
+
 	internal := new(network.NodeInfoV0)
-	if err := internal.UnpackBSON(b, enc); err != nil {
+	if err := internal.UnpackBSON(uni.IN, enc); err != nil {
 		return err
 	}
 
@@ -3,6 +3,7 @@ package digest
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -115,6 +116,42 @@ func stringOffsetQuery(offset string) string {
 	return fmt.Sprintf("offset=%s", offset)
 }
 
+// parseDocumentStatusQuery returns the valid document status string; empty
+// string means no status is given.
+func parseDocumentStatusQuery(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 1 {
+		return "", nil
+	}
+
+	st, err := blocksign.ParseDocumentStatus(s)
+	if err != nil {
+		return "", err
+	}
+
+	return st.String(), nil
+}
+
+func stringDocumentStatusQuery(status string) string {
+	if len(status) < 1 {
+		return ""
+	}
+
+	return fmt.Sprintf("status=%s", status)
+}
+
+func parseTagQuery(s string) string {
+	return strings.TrimSpace(s)
+}
+
+func stringTagQuery(tag string) string {
+	if len(tag) < 1 {
+		return ""
+	}
+
+	return fmt.Sprintf("tag=%s", url.QueryEscape(tag))
+}
+
 func parseBoolQuery(s string) bool {
 	return s == "1"
 }